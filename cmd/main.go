@@ -5,12 +5,26 @@ import (
 	"auth-go-service/internal/config"
 	"auth-go-service/internal/database"
 	"auth-go-service/internal/handlers"
+	"auth-go-service/internal/jobs"
+	"auth-go-service/internal/mailer"
 	"auth-go-service/internal/middleware"
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
 	"auth-go-service/internal/services"
+	"auth-go-service/internal/social"
+	"context"
+	"flag"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"gorm.io/gorm"
 	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // @title           인증 서비스 API
@@ -35,14 +49,95 @@ import (
 func main() {
 	cfg := config.LoadConfig()
 
-	database.InitDatabase(cfg)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(cfg, os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "clients" {
+		runClients(cfg, os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "roles" {
+		runRoles(cfg, os.Args[2:])
+		return
+	}
 
-	emailService := services.NewEmailService(cfg)
-	authService := services.NewAuthService(emailService, cfg.JWTSecretKey)
+	db := database.InitDatabase(cfg)
+	// 스키마가 바이너리보다 뒤처져 있으면 서비스하지 않는다. 배포 전에 "migrate up"을 먼저 실행해야 한다.
+	if err := newMigrator(db).CheckCurrent(); err != nil {
+		log.Fatal("Database schema check failed (run `./main migrate up`): ", err)
+	}
+	repos := repository.NewGormRepositories(db)
+
+	mail, err := mailer.New(cfg)
+	if err != nil {
+		log.Fatal("Failed to create mailer:", err)
+	}
+	renderer, err := mailer.NewRenderer(mailer.Brand{
+		ProductName:  cfg.BrandProductName,
+		SupportEmail: cfg.BrandSupportEmail,
+		WebsiteURL:   cfg.BrandWebsiteURL,
+	}, cfg.MailDefaultLocale)
+	if err != nil {
+		log.Fatal("Failed to load mail templates:", err)
+	}
+	emailService := services.NewEmailService(mail, renderer)
+	keySet, err := services.LoadKeySet(cfg)
+	if err != nil {
+		log.Fatal("Failed to load JWT signing keys:", err)
+	}
+	tokenService := services.NewTokenService(cfg, repos, keySet)
+	mfaService := services.NewMFAService(repos, cfg.MFAIssuer)
+	lockoutService := services.NewLockoutService(cfg, repos)
+	authService := services.NewAuthService(cfg, repos, emailService, tokenService, mfaService, lockoutService)
+	userService := services.NewUserService(cfg, repos, tokenService, emailService)
+	dormantService := services.NewDormantService(cfg, repos, emailService)
+	termsService := services.NewTermsService(repos)
+	socialProviders, err := social.NewProviders(cfg.OAuthProviders)
+	if err != nil {
+		log.Fatal("Invalid social login settings:", err)
+	}
+	socialLoginService := services.NewSocialLoginService(repos, socialProviders, authService)
+	oauthService := services.NewOAuthService(cfg, repos, tokenService)
+	rbacService := services.NewRBACService(repos, tokenService)
+
+	jobs.Start(context.Background(), jobs.AccountPurge(userService, time.Duration(cfg.AccountPurgeInterval)*time.Second))
+	jobs.Start(context.Background(), jobs.DormantAccounts(dormantService, time.Duration(cfg.DormantJobInterval)*time.Second))
+	jobs.Start(context.Background(), jobs.MarketingReconfirmation(userService, time.Duration(cfg.MarketingJobInterval)*time.Second))
 
 	authHandler := handlers.NewAuthHandler(authService)
+	wellKnownHandler := handlers.NewWellKnownHandler(tokenService)
+	mfaHandler := handlers.NewMFAHandler(mfaService)
+	userHandler := handlers.NewUserHandler(userService)
+	termsHandler := handlers.NewTermsHandler(termsService)
+	socialHandler := handlers.NewSocialHandler(socialLoginService)
+	oauthHandler := handlers.NewOAuthHandler(oauthService)
+	adminHandler := handlers.NewAdminHandler(rbacService, userService)
+
+	var rateLimitStore middleware.RateLimitStore
+	switch cfg.RateLimitStore {
+	case "memory":
+		rateLimitStore = middleware.NewMemoryRateLimitStore()
+	case "redis":
+		rateLimitStore = middleware.NewRedisRateLimitStore(redis.NewClient(&redis.Options{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+			DB:       cfg.RedisDB,
+		}))
+	default:
+		log.Fatalf("Unknown RATE_LIMIT_STORE: %q (memory, redis)", cfg.RateLimitStore)
+	}
+	limiter, err := middleware.NewRateLimiter(rateLimitStore, cfg.RateLimits)
+	if err != nil {
+		log.Fatal("Invalid RATE_LIMITS:", err)
+	}
 
 	router := gin.Default()
+	// 로그인 잠금과 요청 제한은 c.ClientIP()를 키로 쓰므로, 로드 밸런서가 붙인 X-Forwarded-For만 믿는다.
+	// 설정하지 않으면 헤더를 무시하고 접속한 주소를 쓴다.
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
 
 	router.Use(middleware.CORS())
 
@@ -50,17 +145,76 @@ func main() {
 	{
 		auth := v1.Group("/auth")
 		{
-			auth.POST("/login", authHandler.Login)
-			auth.POST("/logout", middleware.AuthRequired(authService), authHandler.Logout)
-			auth.GET("/find-my-email", authHandler.FindMyEmail)
-			auth.POST("/reset-password", authHandler.RequestPasswordReset)
-			auth.PUT("/reset-password/password", authHandler.ResetPassword)
-			auth.POST("/request-email-verification", authHandler.RequestEmailVerification)
-			auth.POST("/verify-email-account", authHandler.VerifyEmailAccount)
-			auth.POST("/sign-up", authHandler.SignUp)
+			auth.POST("/login", limiter.Limit("login"), authHandler.Login)
+			auth.POST("/login/mfa", limiter.Limit("mfa-login"), authHandler.VerifyMFALogin)
+			auth.POST("/logout", middleware.AuthRequired(authService), middleware.UserRequired(), authHandler.Logout)
+			auth.POST("/token/refresh", limiter.Limit("token-refresh"), authHandler.RefreshToken)
+			auth.GET("/find-my-email", limiter.Limit("find-my-email"), authHandler.FindMyEmail)
+			auth.POST("/reset-password", limiter.Limit("reset-password"), authHandler.RequestPasswordReset)
+			auth.PUT("/reset-password/password", limiter.Limit("reset-password-confirm"), authHandler.ResetPassword)
+			auth.POST("/request-email-verification", limiter.Limit("email-verification"), authHandler.RequestEmailVerification)
+			auth.POST("/verify-email-account", limiter.Limit("verify-email"), authHandler.VerifyEmailAccount)
+			auth.POST("/sign-up", limiter.Limit("sign-up"), authHandler.SignUp)
+			auth.POST("/email-change/undo", limiter.Limit("email-change-undo"), userHandler.UndoEmailChange)
+			auth.POST("/restore-account", limiter.Limit("restore-account"), authHandler.RestoreAccount)
+			auth.POST("/reactivate-account", limiter.Limit("reactivate-account"), authHandler.ReactivateAccount)
+
+			socialLogin := auth.Group("/social", limiter.Limit("social-login"))
+			{
+				socialLogin.POST("/:provider/authorize", socialHandler.Authorize)
+				socialLogin.POST("/:provider/callback", socialHandler.Callback)
+				socialLogin.POST("/sign-up", socialHandler.SignUp)
+			}
+
+			mfa := auth.Group("/mfa", middleware.AuthRequired(authService), middleware.UserRequired(), limiter.Limit("mfa"))
+			{
+				mfa.POST("/enroll", mfaHandler.Enroll)
+				mfa.POST("/confirm", mfaHandler.Confirm)
+				mfa.POST("/disable", mfaHandler.Disable)
+			}
+		}
+
+		oauth := v1.Group("/oauth")
+		{
+			oauth.GET("/authorize", limiter.Limit("oauth-authorize"), oauthHandler.AuthorizeRedirect)
+			oauth.POST("/authorize", limiter.Limit("oauth-authorize"), middleware.AuthRequired(authService), middleware.UserRequired(), oauthHandler.Authorize)
+			oauth.POST("/token", limiter.Limit("oauth-token"), oauthHandler.Token)
+			oauth.POST("/introspect", limiter.Limit("oauth-introspect"), oauthHandler.Introspect)
+			oauth.POST("/revoke", limiter.Limit("oauth-revoke"), oauthHandler.Revoke)
+			oauth.GET("/userinfo", oauthHandler.UserInfo)
+			oauth.POST("/userinfo", oauthHandler.UserInfo)
+		}
+
+		v1.GET("/terms", termsHandler.ListTerms)
+
+		users := v1.Group("/users", middleware.AuthRequired(authService), middleware.UserRequired())
+		{
+			users.GET("/me", userHandler.GetMe)
+			users.PATCH("/me", userHandler.UpdateMe)
+			users.DELETE("/me", limiter.Limit("delete-account"), userHandler.DeleteMe)
+			users.GET("/me/marketing-consents", userHandler.GetMarketingConsents)
+			users.PUT("/me/marketing-consents", limiter.Limit("marketing-consent"), userHandler.UpdateMarketingConsents)
+			users.GET("/me/terms", termsHandler.GetMyTerms)
+			users.POST("/me/terms", termsHandler.AcceptTerms)
+			users.PUT("/me/password", limiter.Limit("change-password"), userHandler.ChangePassword)
+			users.POST("/me/email", limiter.Limit("email-change"), userHandler.RequestEmailChange)
+			users.POST("/me/email/verify", limiter.Limit("email-change-verify"), userHandler.ConfirmEmailChange)
+		}
+
+		// 관리자 API는 사용자 토큰만 받고 역할의 권한으로 접근을 제한한다.
+		admin := v1.Group("/admin", middleware.AuthRequired(authService), middleware.UserRequired())
+		{
+			admin.GET("/roles", middleware.RequirePermission(models.PermissionRolesRead), adminHandler.ListRoles)
+			admin.GET("/users/:id", middleware.RequirePermission(models.PermissionUsersRead), adminHandler.GetUser)
+			admin.GET("/users/:id/roles", middleware.RequirePermission(models.PermissionRolesRead), adminHandler.GetUserRoles)
+			admin.POST("/users/:id/roles", middleware.RequirePermission(models.PermissionRolesWrite), adminHandler.AssignUserRole)
+			admin.DELETE("/users/:id/roles/:role", middleware.RequirePermission(models.PermissionRolesWrite), adminHandler.RemoveUserRole)
 		}
 	}
 
+	router.GET("/.well-known/jwks.json", wellKnownHandler.JWKS)
+	router.GET("/.well-known/openid-configuration", oauthHandler.OpenIDConfiguration)
+
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "healthy"})
 	})
@@ -77,3 +231,211 @@ func main() {
 		log.Fatal("Failed to start server:", err)
 	}
 }
+
+// runMigrate는 "migrate up|down [n]|status" 서브커맨드를 실행한다. down은 기본으로 1개만 되돌린다.
+func runMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		log.Fatal("Usage: main migrate up|down [n]|status")
+	}
+
+	migrator := newMigrator(database.InitDatabase(cfg))
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatal("Failed to migrate database:", err)
+		}
+		if len(applied) == 0 {
+			log.Println("Database schema is up to date")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				log.Fatalf("Invalid number of migrations to revert: %q", args[1])
+			}
+			steps = n
+		}
+		reverted, err := migrator.Down(steps)
+		for _, migration := range reverted {
+			log.Printf("Reverted migration %04d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatal("Failed to revert migration:", err)
+		}
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatal("Failed to read migration status:", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Printf("%04d_%-45s %s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		log.Fatalf("Unknown migrate command: %q (up, down, status)", args[0])
+	}
+}
+
+// runClients는 OpenID Connect 클라이언트와 서비스 클라이언트를 관리하는 "clients create|list" 서브커맨드를 실행한다.
+// 예: main clients create -name "Admin" -redirect-uri https://admin.example.com/callback [-public]
+// 서비스 클라이언트: main clients create -name "billing" -service -scope users:read
+func runClients(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		log.Fatal("Usage: main clients create -name <name> (-redirect-uri <uri>... [-public] | -service -scope <scope>...) | list")
+	}
+
+	db := database.InitDatabase(cfg)
+	if err := newMigrator(db).CheckCurrent(); err != nil {
+		log.Fatal("Database schema check failed (run `./main migrate up`): ", err)
+	}
+	repos := repository.NewGormRepositories(db)
+	keySet, err := services.LoadKeySet(cfg)
+	if err != nil {
+		log.Fatal("Failed to load JWT signing keys:", err)
+	}
+	oauthService := services.NewOAuthService(cfg, repos, services.NewTokenService(cfg, repos, keySet))
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("clients create", flag.ExitOnError)
+		name := flags.String("name", "", "클라이언트 이름")
+		public := flags.Bool("public", false, "시크릿 없이 PKCE만 사용하는 공개 클라이언트(SPA, 모바일 앱)")
+		service := flags.Bool("service", false, "client_credentials로 서비스 토큰을 받는 백엔드 서비스 클라이언트")
+		var redirectURIs, scopes stringList
+		flags.Var(&redirectURIs, "redirect-uri", "허용할 redirect URI (여러 번 지정 가능)")
+		flags.Var(&scopes, "scope", "서비스 클라이언트에 허용할 scope (여러 번 지정 가능)")
+		flags.Parse(args[1:])
+		if *name == "" {
+			log.Fatal("-name is required")
+		}
+		if *service && (*public || len(redirectURIs) > 0) {
+			log.Fatal("-service cannot be combined with -public or -redirect-uri")
+		}
+		if !*service && len(scopes) > 0 {
+			log.Fatal("-scope is only for -service clients")
+		}
+
+		var client *models.OAuthClient
+		var secret string
+		if *service {
+			client, secret, err = oauthService.CreateServiceClient(*name, scopes)
+		} else {
+			client, secret, err = oauthService.CreateClient(*name, redirectURIs, *public)
+		}
+		if err != nil {
+			log.Fatal("Failed to create client:", err)
+		}
+		fmt.Printf("client_id:     %s\n", client.ClientID)
+		if secret != "" {
+			// 시크릿은 해시만 저장하므로 다시 볼 수 없다.
+			fmt.Printf("client_secret: %s\n", secret)
+		}
+	case "list":
+		clients, err := oauthService.ListClients()
+		if err != nil {
+			log.Fatal("Failed to list clients:", err)
+		}
+		for _, client := range clients {
+			kind, detail := "confidential", client.RedirectURIs
+			if client.IsPublic() {
+				kind = "public"
+			}
+			if client.AllowsGrantType("client_credentials") {
+				kind, detail = "service", client.Scopes
+			}
+			fmt.Printf("%s  %-12s %-30s %s\n", client.ClientID, kind, client.Name, detail)
+		}
+	default:
+		log.Fatalf("Unknown clients command: %q (create, list)", args[0])
+	}
+}
+
+// runRoles는 사용자 역할을 관리하는 "roles list|grant|revoke" 서브커맨드를 실행한다.
+// 관리자 API도 역할이 있어야 호출할 수 있으므로, 첫 관리자는 여기서 지정한다.
+// 예: main roles grant -email admin@example.com -role admin
+func runRoles(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		log.Fatal("Usage: main roles list | grant|revoke -email <email> -role <role>")
+	}
+
+	db := database.InitDatabase(cfg)
+	if err := newMigrator(db).CheckCurrent(); err != nil {
+		log.Fatal("Database schema check failed (run `./main migrate up`): ", err)
+	}
+	repos := repository.NewGormRepositories(db)
+	keySet, err := services.LoadKeySet(cfg)
+	if err != nil {
+		log.Fatal("Failed to load JWT signing keys:", err)
+	}
+	rbacService := services.NewRBACService(repos, services.NewTokenService(cfg, repos, keySet))
+
+	switch args[0] {
+	case "list":
+		roles, err := rbacService.ListRoles()
+		if err != nil {
+			log.Fatal("Failed to list roles:", err)
+		}
+		for _, role := range roles {
+			permissions := make([]string, 0, len(role.Permissions))
+			for _, permission := range role.Permissions {
+				permissions = append(permissions, permission.Name)
+			}
+			fmt.Printf("%-15s %s\n", role.Name, strings.Join(permissions, " "))
+		}
+	case "grant", "revoke":
+		flags := flag.NewFlagSet("roles "+args[0], flag.ExitOnError)
+		email := flags.String("email", "", "사용자 이메일 주소")
+		role := flags.String("role", "", "역할 이름")
+		flags.Parse(args[1:])
+		if *email == "" || *role == "" {
+			log.Fatal("-email and -role are required")
+		}
+
+		if args[0] == "grant" {
+			_, err = rbacService.AssignRoleByEmail(*email, *role)
+		} else {
+			err = rbacService.RemoveRoleByEmail(*email, *role)
+		}
+		if err != nil {
+			log.Fatalf("Failed to %s role: %v", args[0], err)
+		}
+		fmt.Printf("%s: %s %s\n", *email, args[0], *role)
+	default:
+		log.Fatalf("Unknown roles command: %q (list, grant, revoke)", args[0])
+	}
+}
+
+// stringList는 여러 번 지정할 수 있는 플래그 값이다.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, " ")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// newMigrator는 GORM 연결의 *sql.DB로 마이그레이터를 만든다.
+// PrepareStmt를 사용하는 GORM 세션으로는 여러 문장으로 된 SQL 파일을 실행할 수 없다.
+func newMigrator(db *gorm.DB) *database.Migrator {
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal("Failed to get database connection:", err)
+	}
+	migrator, err := database.NewMigrator(sqlDB)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	return migrator
+}
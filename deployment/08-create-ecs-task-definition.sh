@@ -145,10 +145,6 @@ cat > /tmp/task-definition.json << EOF
           "name": "GIN_MODE",
           "value": "release"
         },
-        {
-          "name": "SKIP_MIGRATION",
-          "value": "true"
-        },
         {
           "name": "SERVER_PORT",
           "value": "8081"
@@ -24,50 +24,39 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/find-my-email": {
+        "/admin/roles": {
             "get": {
-                "description": "이름과 전화번호를 통해 등록된 이메일 주소 찾기",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "역할과 역할별 권한 목록. roles:read 권한 필요",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "인증"
-                ],
-                "summary": "이메일 찾기",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "사용자 이름",
-                        "name": "name",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "전화번호",
-                        "name": "phone",
-                        "in": "query",
-                        "required": true
-                    }
+                    "관리자"
                 ],
+                "summary": "역할 목록 조회",
                 "responses": {
                     "200": {
-                        "description": "이메일 찾기 성공",
+                        "description": "역할 목록",
                         "schema": {
-                            "$ref": "#/definitions/models.FindMyEmailResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Role"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "필수 파라미터 누락",
+                    "401": {
+                        "description": "인증 실패",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "일치하는 계정 없음",
+                    "403": {
+                        "description": "권한 없음 또는 서비스 토큰",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -75,39 +64,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "이메일과 비밀번호를 통해 사용자 로그인 처리",
-                "consumes": [
-                    "application/json"
+        "/admin/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "회원 ID로 회원 정보 조회. users:read 권한 필요",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "인증"
+                    "관리자"
                 ],
-                "summary": "사용자 로그인",
+                "summary": "회원 정보 조회",
                 "parameters": [
                     {
-                        "description": "로그인 요청 정보",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "회원 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "로그인 성공",
+                        "description": "회원 정보",
                         "schema": {
-                            "$ref": "#/definitions/models.LoginResponse"
+                            "$ref": "#/definitions/models.User"
                         }
                     },
                     "400": {
-                        "description": "잘못된 요청 또는 로그인 실패",
+                        "description": "잘못된 회원 ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음 또는 서비스 토큰",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -115,42 +122,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
+        "/admin/users/{id}/roles": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "인증된 사용자 로그아웃 처리",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "회원에게 부여된 역할과 권한 목록. roles:read 권한 필요",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "인증"
+                    "관리자"
+                ],
+                "summary": "회원 역할 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "회원 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "사용자 로그아웃",
                 "responses": {
                     "200": {
-                        "description": "로그아웃 성공",
+                        "description": "부여된 역할 목록",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "message": {
-                                    "type": "string"
-                                }
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Role"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "잘못된 회원 ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음 또는 서비스 토큰",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/auth/request-email-verification": {
+            },
             "post": {
-                "description": "이메일 주소로 인증 코드 발송 요청",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "회원에게 역할을 부여하고 부여 후의 역할 목록을 반환. 이미 있는 역할이면 그대로 둠. 회원이 토큰을 갱신하거나 다시 로그인하면 반영됨. roles:write 권한 필요",
                 "consumes": [
                     "application/json"
                 ],
@@ -158,25 +196,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "인증"
+                    "관리자"
                 ],
-                "summary": "이메일 인증 요청",
+                "summary": "회원 역할 부여",
                 "parameters": [
                     {
-                        "description": "이메일 인증 요청 정보",
+                        "type": "integer",
+                        "description": "회원 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "부여할 역할",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RequestEmailVerificationRequest"
+                            "$ref": "#/definitions/models.AssignRoleRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "인증 코드 발송 성공",
+                        "description": "부여된 역할 목록",
                         "schema": {
-                            "$ref": "#/definitions/models.RequestEmailVerificationResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Role"
+                            }
                         }
                     },
                     "400": {
@@ -184,48 +232,83 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음 또는 서비스 토큰",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 또는 역할 없음",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/auth/reset-password": {
-            "post": {
-                "description": "이메일로 비밀번호 재설정 링크 발송",
-                "consumes": [
-                    "application/json"
+        "/admin/users/{id}/roles/{role}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "회원의 역할을 회수하고 이미 발급된 액세스 토큰을 폐기. 회원은 리프레시 토큰으로 회수된 권한이 빠진 토큰을 다시 받음. roles:write 권한 필요",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "인증"
+                    "관리자"
                 ],
-                "summary": "비밀번호 재설정 요청",
+                "summary": "회원 역할 회수",
                 "parameters": [
                     {
-                        "description": "비밀번호 재설정 요청 정보",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.RequestPasswordResetRequest"
-                        }
+                        "type": "integer",
+                        "description": "회원 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "회수할 역할 이름",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "재설정 이메일 발송 성공",
+                    "204": {
+                        "description": "회수 완료 (부여되지 않은 역할이어도 204)"
+                    },
+                    "400": {
+                        "description": "잘못된 회원 ID",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "message": {
-                                    "type": "string"
-                                }
-                            }
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "잘못된 요청",
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음 또는 서비스 토큰",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 또는 역할 없음",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -233,9 +316,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/reset-password/password": {
-            "put": {
-                "description": "재설정 토큰을 통해 새로운 비밀번호로 변경",
+        "/auth/email-change/undo": {
+            "post": {
+                "description": "이전 이메일로 받은 링크의 토큰으로 이메일을 이전 주소로 되돌림. 모든 기기에서 로그아웃되므로 비밀번호 재설정을 권장",
                 "consumes": [
                     "application/json"
                 ],
@@ -245,21 +328,21 @@ const docTemplate = `{
                 "tags": [
                     "인증"
                 ],
-                "summary": "비밀번호 재설정",
+                "summary": "이메일 변경 되돌리기",
                 "parameters": [
                     {
-                        "description": "비밀번호 재설정 정보",
+                        "description": "되돌리기 토큰",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ResetPasswordRequest"
+                            "$ref": "#/definitions/models.UndoEmailChangeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "비밀번호 재설정 성공",
+                        "description": "되돌리기 성공",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -270,7 +353,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "잘못된 요청 또는 토큰 오류",
+                        "description": "잘못된 요청 또는 만료/사용된 링크",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이전 이메일을 다른 계정이 사용 중",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -278,9 +373,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/sign-up": {
-            "post": {
-                "description": "새로운 사용자 계정 생성",
+        "/auth/find-my-email": {
+            "get": {
+                "description": "이름과 전화번호를 통해 등록된 이메일 주소 찾기",
                 "consumes": [
                     "application/json"
                 ],
@@ -290,32 +385,38 @@ const docTemplate = `{
                 "tags": [
                     "인증"
                 ],
-                "summary": "사용자 회원가입",
+                "summary": "이메일 찾기",
                 "parameters": [
                     {
-                        "description": "회원가입 요청 정보",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.SignUpRequest"
-                        }
+                        "type": "string",
+                        "description": "사용자 이름",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "전화번호",
+                        "name": "phone",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "회원가입 성공",
+                        "description": "이메일 찾기 성공",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "message": {
-                                    "type": "string"
-                                }
-                            }
+                            "$ref": "#/definitions/models.FindMyEmailResponse"
                         }
                     },
                     "400": {
-                        "description": "잘못된 요청 또는 회원가입 실패",
+                        "description": "필수 파라미터 누락",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "일치하는 계정 없음",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -323,9 +424,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/verify-email-account": {
+        "/auth/login": {
             "post": {
-                "description": "발송된 인증 코드를 통해 이메일 계정 인증 처리",
+                "description": "이메일과 비밀번호를 통해 사용자 로그인 처리. 2단계 인증을 사용하는 계정은 토큰 대신 models.MFAChallengeResponse를 반환하며, /auth/login/mfa로 인증을 완료해야 함. 새 버전이 시행된 필수 약관이 있으면 pendingTerms에 담기며, /users/me/terms로 다시 동의받아야 함",
                 "consumes": [
                     "application/json"
                 ],
@@ -335,41 +436,2058 @@ const docTemplate = `{
                 "tags": [
                     "인증"
                 ],
-                "summary": "이메일 계정 인증",
+                "summary": "사용자 로그인",
                 "parameters": [
                     {
-                        "description": "계정 인증 요청 정보",
+                        "description": "로그인 요청 정보",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.VerifyEmailRequest"
+                            "$ref": "#/definitions/models.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "계정 인증 성공",
+                        "description": "로그인 성공",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "message": {
-                                    "type": "string"
-                                }
-                            }
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 로그인 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "휴면 계정 (이메일로 받은 인증 코드로 /auth/reactivate-account 호출) 또는 탈퇴한 계정 (복구 기간 안이면 /auth/restore-account로 복구 가능, models.ErrorResponse)",
+                        "schema": {
+                            "$ref": "#/definitions/models.DormantAccountResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "반복된 실패로 계정이 잠김 (비밀번호 재설정 필요)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "로그인 시도 횟수 초과 (Retry-After 헤더의 초만큼 대기)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login/mfa": {
+            "post": {
+                "description": "로그인 시 발급된 임시 토큰과 인증 앱 코드(또는 복구 코드)로 로그인 완료",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "인증"
+                ],
+                "summary": "2단계 인증 로그인",
+                "parameters": [
+                    {
+                        "description": "2단계 인증 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFALoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "로그인 성공",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 코드 또는 임시 토큰 오류",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "반복된 실패로 계정이 잠김 (비밀번호 재설정 필요)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "인증 시도 횟수 초과 (Retry-After 헤더의 초만큼 대기)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "현재 액세스 토큰과 세션의 리프레시 토큰을 폐기 (allDevices=true이면 모든 기기에서 로그아웃)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "인증"
+                ],
+                "summary": "사용자 로그아웃",
+                "parameters": [
+                    {
+                        "description": "로그아웃 옵션",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "로그아웃 성공",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "인증 앱의 코드로 등록을 완료하고 1회용 복구 코드 발급 (복구 코드는 이 응답에서만 확인 가능)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "2단계 인증"
+                ],
+                "summary": "2단계 인증 등록 확인",
+                "parameters": [
+                    {
+                        "description": "인증 코드",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFAConfirmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "등록 완료",
+                        "schema": {
+                            "$ref": "#/definitions/models.MFARecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 인증 코드 오류",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "현재 비밀번호와 인증 코드(또는 복구 코드)로 본인 확인 후 2단계 인증 해제",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "2단계 인증"
+                ],
+                "summary": "2단계 인증 해제",
+                "parameters": [
+                    {
+                        "description": "본인 확인 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFADisableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "해제 성공",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 본인 확인 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "인증 앱(Google Authenticator 등)에 등록할 TOTP 비밀키와 otpauth URI 발급. 확인 전까지는 로그인에 적용되지 않음",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "2단계 인증"
+                ],
+                "summary": "2단계 인증 등록 시작",
+                "responses": {
+                    "200": {
+                        "description": "비밀키 발급 성공",
+                        "schema": {
+                            "$ref": "#/definitions/models.MFAEnrollResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "이미 2단계 인증 사용 중",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reactivate-account": {
+            "post": {
+                "description": "휴면 계정으로 로그인할 때 받은 인증 ID와 이메일로 발송된 인증 코드로 휴면을 해제하고 로그인. 2단계 인증을 사용하는 계정은 models.MFAChallengeResponse를 반환함",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "인증"
+                ],
+                "summary": "휴면 계정 해제",
+                "parameters": [
+                    {
+                        "description": "휴면 해제 인증 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReactivateAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "휴면 해제 및 로그인 성공",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "반복된 실패로 계정이 잠김 (비밀번호 재설정 필요)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "시도 횟수 초과 (Retry-After 헤더의 초만큼 대기)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/request-email-verification": {
+            "post": {
+                "description": "이메일 주소로 인증 코드 발송 요청",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "인증"
+                ],
+                "summary": "이메일 인증 요청",
+                "parameters": [
+                    {
+                        "description": "이메일 인증 요청 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RequestEmailVerificationRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "메일 언어 (예: ko, en-US;q=0.8)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "인증 코드 발송 성공",
+                        "schema": {
+                            "$ref": "#/definitions/models.RequestEmailVerificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "재발송 대기 시간 (Retry-After 헤더의 초만큼 대기)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "이메일로 비밀번호 재설정 링크 발송 (이전에 발송한 링크는 무효화됨)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "인증"
+                ],
+                "summary": "비밀번호 재설정 요청",
+                "parameters": [
+                    {
+                        "description": "비밀번호 재설정 요청 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RequestPasswordResetRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "사용자에 저장된 언어가 없을 때 사용할 메일 언어",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "재설정 이메일 발송 성공",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset-password/password": {
+            "put": {
+                "description": "재설정 토큰을 통해 새로운 비밀번호로 변경 (토큰은 한 번만 사용할 수 있으며, 성공하면 모든 기기에서 로그아웃됨)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "인증"
+                ],
+                "summary": "비밀번호 재설정",
+                "parameters": [
+                    {
+                        "description": "비밀번호 재설정 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "비밀번호 재설정 성공",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 토큰 오류",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/restore-account": {
+            "post": {
+                "description": "복구 기간 안의 탈퇴 계정을 이메일과 비밀번호로 복구. 비밀번호 실패는 로그인과 같은 잠금 정책으로 집계되며, 복구 후 다시 로그인해야 함",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "인증"
+                ],
+                "summary": "탈퇴 계정 복구",
+                "parameters": [
+                    {
+                        "description": "탈퇴한 계정의 이메일과 비밀번호",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RestoreAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "복구 성공",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청, 복구할 수 없는 계정 또는 비밀번호 오류",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "반복된 실패로 계정이 잠김 (비밀번호 재설정 필요)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "시도 횟수 초과 (Retry-After 헤더의 초만큼 대기)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sign-up": {
+            "post": {
+                "description": "새로운 사용자 계정 생성. GET /terms의 필수 약관(현재 버전)에 모두 동의해야 함",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "인증"
+                ],
+                "summary": "사용자 회원가입",
+                "parameters": [
+                    {
+                        "description": "회원가입 요청 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SignUpRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale이 없을 때 사용할 메일 언어 (예: ko, en-US;q=0.8)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "회원가입 성공",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 회원가입 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/social/sign-up": {
+            "post": {
+                "description": "소셜 로그인 완료 시 받은 가입 전용 토큰으로 계정을 만들고 소셜 계정을 연결. 이메일 인증은 제공자가 확인한 이메일로 대신하며, GET /terms의 필수 약관(현재 버전)에 모두 동의해야 함",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "소셜 로그인"
+                ],
+                "summary": "소셜 계정으로 회원가입",
+                "parameters": [
+                    {
+                        "description": "회원가입 요청 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SocialSignUpRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale이 없을 때 사용할 메일 언어 (예: ko, en-US;q=0.8)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "가입 및 로그인 성공",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 회원가입 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/social/{provider}/authorize": {
+            "post": {
+                "description": "제공자(kakao, naver, google) 로그인 화면 주소와 state 발급 (인가 코드 + PKCE). 사용자를 authorizationUrl로 보내고, redirect URL로 돌아온 code와 state를 /callback으로 보내야 함",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "소셜 로그인"
+                ],
+                "summary": "소셜 로그인 시작",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "제공자 (kakao, naver, google)",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "로그인 화면 주소",
+                        "schema": {
+                            "$ref": "#/definitions/models.SocialAuthorizeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "지원하지 않는(설정되지 않은) 제공자",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/social/{provider}/callback": {
+            "post": {
+                "description": "제공자가 돌려준 인가 코드로 로그인. 연결된 계정이 없으면 제공자가 확인한 이메일로 기존 계정과 연결하며, 기존 계정도 없으면 models.SocialSignUpRequiredResponse(가입 전용 토큰)를 반환함. 2단계 인증을 사용하는 계정은 models.MFAChallengeResponse를 반환함",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "소셜 로그인"
+                ],
+                "summary": "소셜 로그인 완료",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "제공자 (kakao, naver, google)",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "인가 코드와 state",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SocialCallbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "로그인 성공",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청, 만료된 state 또는 제공자 오류",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "휴면 계정 (이메일로 받은 인증 코드로 /auth/reactivate-account 호출)",
+                        "schema": {
+                            "$ref": "#/definitions/models.DormantAccountResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "지원하지 않는(설정되지 않은) 제공자",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/token/refresh": {
+            "post": {
+                "description": "리프레시 토큰으로 새 액세스 토큰과 리프레시 토큰을 발급 (사용한 리프레시 토큰은 폐기되며, 재사용 시 해당 세션 전체가 폐기됨)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "인증"
+                ],
+                "summary": "액세스 토큰 재발급",
+                "parameters": [
+                    {
+                        "description": "리프레시 토큰",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "토큰 재발급 성공",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "유효하지 않거나 재사용된 리프레시 토큰",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-email-account": {
+            "post": {
+                "description": "발송된 인증 코드를 통해 이메일 계정 인증 처리 (인증 요청마다 시도 횟수가 제한되며, 초과하면 코드를 다시 요청해야 함)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "인증"
+                ],
+                "summary": "이메일 계정 인증",
+                "parameters": [
+                    {
+                        "description": "계정 인증 요청 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "계정 인증 성공",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/authorize": {
+            "get": {
+                "description": "클라이언트 앱이 사용자 브라우저를 보내는 인가 엔드포인트 (인가 코드 + PKCE S256). 요청을 확인한 뒤 같은 쿼리로 프론트엔드 로그인 화면(OIDC_LOGIN_URL)으로 보냄. client_id나 redirect_uri가 잘못되면 400, 그 밖의 오류는 redirect_uri로 error를 돌려줌",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OpenID Connect"
+                ],
+                "summary": "OpenID Connect 인가 요청",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "response_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "클라이언트 ID",
+                        "name": "client_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "등록된 redirect URI",
+                        "name": "redirect_uri",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "openid 필수 (profile, email, phone)",
+                        "name": "scope",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "redirect 시 그대로 돌려주는 값",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID 토큰에 담을 값",
+                        "name": "nonce",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PKCE code_challenge",
+                        "name": "code_challenge",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "S256",
+                        "name": "code_challenge_method",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "로그인 화면 또는 redirect_uri(오류)로 이동"
+                    },
+                    "400": {
+                        "description": "등록되지 않은 client_id 또는 redirect_uri",
+                        "schema": {
+                            "$ref": "#/definitions/models.OAuthErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "로그인한 프론트엔드가 인가 요청 값을 그대로 보내 인가 코드를 발급받음. 응답의 redirectUrl로 브라우저를 보내면 클라이언트 앱이 코드를 받음",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OpenID Connect"
+                ],
+                "summary": "OpenID Connect 인가 코드 발급",
+                "parameters": [
+                    {
+                        "description": "인가 요청 값",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AuthorizeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "인가 코드를 담은 redirect 주소",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuthorizeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 인가 요청",
+                        "schema": {
+                            "$ref": "#/definitions/models.OAuthErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/introspect": {
+            "post": {
+                "description": "액세스 토큰 또는 리프레시 토큰이 지금 사용할 수 있는지와 클레임을 반환. 토큰을 직접 검증할 수 없는 서비스용이며 기밀 클라이언트 인증(HTTP Basic 또는 client_id/client_secret 폼 값)이 필요함. 사용할 수 없는 토큰은 active=false",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OpenID Connect"
+                ],
+                "summary": "토큰 확인 (RFC 7662)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "확인할 토큰",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "access_token 또는 refresh_token",
+                        "name": "token_type_hint",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "클라이언트 ID (HTTP Basic을 쓰지 않을 때)",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "클라이언트 시크릿 (HTTP Basic을 쓰지 않을 때)",
+                        "name": "client_secret",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "토큰 상태",
+                        "schema": {
+                            "$ref": "#/definitions/models.IntrospectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/models.OAuthErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "클라이언트 인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.OAuthErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/revoke": {
+            "post": {
+                "description": "액세스 토큰 또는 리프레시 토큰 폐기. 리프레시 토큰은 해당 로그인 세션 전체를 폐기함. 기밀 클라이언트 인증이 필요하며, 다른 클라이언트에 발급된 토큰은 폐기하지 않고 이미 사용할 수 없는 토큰과 마찬가지로 200으로 응답함",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OpenID Connect"
+                ],
+                "summary": "토큰 폐기 (RFC 7009)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "폐기할 토큰",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "access_token 또는 refresh_token",
+                        "name": "token_type_hint",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "클라이언트 ID (HTTP Basic을 쓰지 않을 때)",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "클라이언트 시크릿 (HTTP Basic을 쓰지 않을 때)",
+                        "name": "client_secret",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "폐기 완료"
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/models.OAuthErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "클라이언트 인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.OAuthErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/token": {
+            "post": {
+                "description": "authorization_code: 인가 코드와 PKCE code_verifier로 액세스 토큰과 ID 토큰 발급. client_credentials: 서비스 클라이언트에 사용자 없는 서비스 토큰(sub_type=service) 발급. 기밀 클라이언트는 HTTP Basic 또는 client_id/client_secret 폼 값으로 인증. 리프레시 토큰은 발급하지 않음",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OpenID Connect"
+                ],
+                "summary": "OpenID Connect 토큰 발급",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "authorization_code 또는 client_credentials",
+                        "name": "grant_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "인가 코드 (authorization_code)",
+                        "name": "code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "인가 요청의 redirect_uri (authorization_code)",
+                        "name": "redirect_uri",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PKCE code_verifier (authorization_code)",
+                        "name": "code_verifier",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "요청할 scope (client_credentials, 생략하면 허용된 scope 전체)",
+                        "name": "scope",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "클라이언트 ID (HTTP Basic을 쓰지 않을 때)",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "클라이언트 시크릿 (HTTP Basic을 쓰지 않을 때)",
+                        "name": "client_secret",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "토큰 발급 성공",
+                        "schema": {
+                            "$ref": "#/definitions/models.OAuthTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청, 인가 코드 또는 scope",
+                        "schema": {
+                            "$ref": "#/definitions/models.OAuthErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "클라이언트 인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.OAuthErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/userinfo": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "토큰 엔드포인트에서 받은 액세스 토큰의 scope(profile, email, phone)에 해당하는 사용자 정보",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OpenID Connect"
+                ],
+                "summary": "OpenID Connect 사용자 정보",
+                "responses": {
+                    "200": {
+                        "description": "사용자 정보",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "잘못되었거나 만료된 액세스 토큰",
+                        "schema": {
+                            "$ref": "#/definitions/models.OAuthErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "openid scope가 없는 토큰",
+                        "schema": {
+                            "$ref": "#/definitions/models.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms": {
+            "get": {
+                "description": "현재 시행 중인 약관(문서 종류별 최신 버전) 목록. 회원가입 시 required인 약관은 모두 동의해야 함",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "약관"
+                ],
+                "summary": "약관 목록 조회",
+                "responses": {
+                    "200": {
+                        "description": "약관 목록",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Terms"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "액세스 토큰의 사용자 정보 조회. 수정할 때는 응답의 updatedAt을 함께 보내야 함",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원"
+                ],
+                "summary": "내 회원 정보 조회",
+                "responses": {
+                    "200": {
+                        "description": "회원 정보",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "현재 비밀번호 확인 후 탈퇴 처리. 모든 기기에서 로그아웃되며, restoreUntil까지는 /auth/restore-account로 복구할 수 있고 이후 개인정보가 삭제됨",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원"
+                ],
+                "summary": "회원 탈퇴",
+                "parameters": [
+                    {
+                        "description": "현재 비밀번호",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteAccountRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "알림 메일 언어 (사용자 설정이 없을 때 사용)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "탈퇴 성공",
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 비밀번호 오류",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "이름, 전화번호, 마케팅 수신 동의 중 보낸 항목만 수정. 마케팅 수신 동의는 모든 채널에 적용되며 동의 이력에 남음. updatedAt이 현재 값과 다르면(다른 곳에서 먼저 수정했으면) 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원"
+                ],
+                "summary": "내 회원 정보 수정",
+                "parameters": [
+                    {
+                        "description": "수정할 회원 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "수정된 회원 정보",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "다른 요청에서 먼저 수정됨",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/email": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "현재 비밀번호 확인 후 새 이메일 주소로 인증 코드 발송. 인증을 마치기 전까지 이메일은 바뀌지 않음",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원"
+                ],
+                "summary": "이메일 변경 요청",
+                "parameters": [
+                    {
+                        "description": "새 이메일 주소와 현재 비밀번호",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RequestEmailChangeRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "메일 언어 (사용자 설정이 없을 때 사용)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "인증 코드 발송 성공",
+                        "schema": {
+                            "$ref": "#/definitions/models.RequestEmailVerificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 현재 비밀번호 오류",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이미 사용 중인 이메일",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "재발송 대기 시간 또는 요청 횟수 초과 (Retry-After 헤더의 초만큼 대기)",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/email/verify": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "새 이메일로 받은 인증 코드로 이메일 변경 완료. 기존 토큰은 모두 폐기되고 새 토큰이 발급되며, 이전 주소로 되돌리기 링크가 발송됨",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원"
+                ],
+                "summary": "이메일 변경 확인",
+                "parameters": [
+                    {
+                        "description": "인증 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ConfirmEmailChangeRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "알림 메일 언어 (사용자 설정이 없을 때 사용)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "변경 성공 (새 토큰)",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 인증 코드 오류",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이미 사용 중인 이메일",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/marketing-consents": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "채널별(email, sms, push) 마케팅 수신 동의 여부와 동의/철회 이력 조회",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원"
+                ],
+                "summary": "마케팅 수신 동의 조회",
+                "responses": {
+                    "200": {
+                        "description": "수신 동의 현황",
+                        "schema": {
+                            "$ref": "#/definitions/models.MarketingConsentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "보낸 채널의 마케팅 수신 동의 여부를 변경. 변경 사항은 시간, IP, 약관 버전과 함께 이력에 남음",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원"
+                ],
+                "summary": "마케팅 수신 동의 변경",
+                "parameters": [
+                    {
+                        "description": "채널별 수신 동의 여부",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateMarketingConsentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "변경된 수신 동의 현황",
+                        "schema": {
+                            "$ref": "#/definitions/models.MarketingConsentResponse"
                         }
                     },
                     "400": {
-                        "description": "잘못된 요청 또는 인증 실패",
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "현재 비밀번호 확인 후 비밀번호 변경. 이 요청에 사용한 세션을 제외한 모든 기기에서 로그아웃되며 변경 알림 메일이 발송됨",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원"
+                ],
+                "summary": "비밀번호 변경",
+                "parameters": [
+                    {
+                        "description": "현재 비밀번호와 새 비밀번호",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangePasswordRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "알림 메일 언어 (사용자 설정이 없을 때 사용)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "변경 성공",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청, 현재 비밀번호 오류 또는 비밀번호 정책 위반",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 횟수 초과",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/terms": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "현재 약관별로 마지막으로 동의한 버전과 시간, 다시 동의해야 하는지(pending) 여부 조회",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "약관"
+                ],
+                "summary": "약관 동의 현황 조회",
+                "responses": {
+                    "200": {
+                        "description": "약관 동의 현황",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TermsStatus"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "현재 시행 중인 약관 버전에 동의. 동의 시간과 IP가 기록됨. 현재 버전이 아니면 400",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "약관"
+                ],
+                "summary": "약관 동의",
+                "parameters": [
+                    {
+                        "description": "동의할 약관",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AcceptTermsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "갱신된 약관 동의 현황",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TermsStatus"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
+        }
+    },
+    "definitions": {
+        "models.AcceptTermsRequest": {
+            "type": "object",
+            "required": [
+                "terms"
+            ],
+            "properties": {
+                "terms": {
+                    "description": "동의할 약관",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.AcceptedTerms"
+                    }
+                }
+            }
+        },
+        "models.AcceptedTerms": {
+            "type": "object",
+            "required": [
+                "code",
+                "version"
+            ],
+            "properties": {
+                "code": {
+                    "description": "약관 종류",
+                    "type": "string",
+                    "example": "service"
+                },
+                "version": {
+                    "description": "동의한 약관 버전 (GET /terms의 현재 버전)",
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "models.AssignRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "description": "부여할 역할 이름",
+                    "type": "string",
+                    "example": "support"
+                }
+            }
+        },
+        "models.AuthorizeRequest": {
+            "type": "object",
+            "required": [
+                "client_id",
+                "code_challenge",
+                "code_challenge_method",
+                "redirect_uri",
+                "response_type",
+                "scope"
+            ],
+            "properties": {
+                "client_id": {
+                    "description": "등록된 클라이언트 ID",
+                    "type": "string",
+                    "example": "app_3f9a..."
+                },
+                "code_challenge": {
+                    "description": "PKCE code_challenge",
+                    "type": "string",
+                    "example": "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+                },
+                "code_challenge_method": {
+                    "description": "S256만 지원",
+                    "type": "string",
+                    "example": "S256"
+                },
+                "nonce": {
+                    "description": "ID 토큰에 그대로 담는 값",
+                    "type": "string",
+                    "example": "n-0S6_WzA2Mj"
+                },
+                "redirect_uri": {
+                    "description": "등록된 redirect URI와 정확히 일치해야 함",
+                    "type": "string",
+                    "example": "https://app.example.com/callback"
+                },
+                "response_type": {
+                    "description": "code만 지원",
+                    "type": "string",
+                    "example": "code"
+                },
+                "scope": {
+                    "description": "openid 필수 (profile, email, phone)",
+                    "type": "string",
+                    "example": "openid profile email"
+                },
+                "state": {
+                    "description": "redirect 시 그대로 돌려주는 값",
+                    "type": "string",
+                    "example": "af0ifjsldkj"
+                }
+            }
+        },
+        "models.AuthorizeResponse": {
+            "type": "object",
+            "properties": {
+                "redirectUrl": {
+                    "description": "인가 코드를 담은 클라이언트 redirect 주소 (브라우저를 이 주소로 보냄)",
+                    "type": "string",
+                    "example": "https://app.example.com/callback?code=...\u0026state=af0ifjsldkj"
+                }
+            }
+        },
+        "models.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "currentPassword",
+                "newPassword"
+            ],
+            "properties": {
+                "currentPassword": {
+                    "description": "현재 비밀번호",
+                    "type": "string",
+                    "example": "password123"
+                },
+                "newPassword": {
+                    "description": "새 비밀번호 (8자 이상, 영문자와 숫자 포함)",
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "newpass123"
+                }
+            }
+        },
+        "models.ConfirmEmailChangeRequest": {
+            "type": "object",
+            "required": [
+                "newEmail",
+                "verificationCode",
+                "verificationId"
+            ],
+            "properties": {
+                "newEmail": {
+                    "description": "변경할 이메일 주소",
+                    "type": "string",
+                    "example": "new@example.com"
+                },
+                "verificationCode": {
+                    "description": "새 이메일로 받은 인증 코드",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "verificationId": {
+                    "description": "이메일 변경 요청 시 받은 인증 ID",
+                    "type": "integer",
+                    "example": 12345
+                }
+            }
+        },
+        "models.DeleteAccountRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "description": "본인 확인용 현재 비밀번호",
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "models.DeleteAccountResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "응답 메시지",
+                    "type": "string",
+                    "example": "Account deleted"
+                },
+                "restoreUntil": {
+                    "description": "이 시각까지 /auth/restore-account로 복구 가능",
+                    "type": "string",
+                    "example": "2024-02-01T00:00:00Z"
+                }
+            }
+        },
+        "models.DormantAccountResponse": {
+            "type": "object",
+            "properties": {
+                "dormant": {
+                    "description": "휴면 계정 여부",
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "description": "응답 메시지",
+                    "type": "string",
+                    "example": "휴면 상태인 계정입니다."
+                },
+                "verificationId": {
+                    "description": "휴면 해제 인증 ID (인증 코드는 이메일로 발송)",
+                    "type": "integer",
+                    "example": 12345
+                }
+            }
+        },
         "models.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -383,54 +2501,456 @@ const docTemplate = `{
                         "[\"필드 검증 실패\"]"
                     ]
                 },
-                "message": {
-                    "description": "오류 메시지",
+                "message": {
+                    "description": "오류 메시지",
+                    "type": "string",
+                    "example": "요청 처리 중 오류가 발생했습니다."
+                }
+            }
+        },
+        "models.FindMyEmailResponse": {
+            "type": "object",
+            "properties": {
+                "maskedEmail": {
+                    "description": "마스킹된 이메일 주소",
+                    "type": "string",
+                    "example": "us***@example.com"
+                }
+            }
+        },
+        "models.IntrospectionResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "사용할 수 있는 토큰인지 여부 (false이면 다른 값은 없음)",
+                    "type": "boolean",
+                    "example": true
+                },
+                "client_id": {
+                    "description": "토큰을 발급받은 클라이언트 (이 서비스에서 직접 로그인한 토큰은 비어 있음)",
+                    "type": "string",
+                    "example": "app_3f9a..."
+                },
+                "email": {
+                    "description": "액세스 토큰에 담긴 이메일",
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "exp": {
+                    "description": "만료 시간 (Unix)",
+                    "type": "integer",
+                    "example": 1760000900
+                },
+                "iat": {
+                    "description": "발급 시간 (Unix)",
+                    "type": "integer",
+                    "example": 1760000000
+                },
+                "jti": {
+                    "description": "액세스 토큰 ID",
+                    "type": "string",
+                    "example": "7d2f0c9e-1b4a-4e8f-a3c6-5e9b1d2f4a80"
+                },
+                "name": {
+                    "description": "액세스 토큰에 담긴 이름",
+                    "type": "string",
+                    "example": "홍길동"
+                },
+                "permissions": {
+                    "description": "액세스 토큰에 담긴 권한",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "users:read"
+                    ]
+                },
+                "roles": {
+                    "description": "액세스 토큰에 담긴 역할",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "support"
+                    ]
+                },
+                "scope": {
+                    "description": "클라이언트에 발급한 토큰의 scope",
+                    "type": "string",
+                    "example": "users:read"
+                },
+                "sid": {
+                    "description": "로그인 세션 ID",
+                    "type": "string",
+                    "example": "0b6e3c1e-5f1a-4c0e-9a4b-3f0d2c9e7a11"
+                },
+                "sub": {
+                    "description": "사용자 ID 또는 서비스 클라이언트의 client_id",
+                    "type": "string",
+                    "example": "42"
+                },
+                "sub_type": {
+                    "description": "user, service",
+                    "type": "string",
+                    "example": "user"
+                },
+                "token_type": {
+                    "description": "access_token, refresh_token",
+                    "type": "string",
+                    "example": "access_token"
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "사용자 이메일 주소",
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "description": "사용자 비밀번호",
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expiresIn": {
+                    "description": "토큰 만료 시간(초)",
+                    "type": "integer",
+                    "example": 900
+                },
+                "pendingTerms": {
+                    "description": "다시 동의해야 하는 필수 약관 (새 버전 시행 시. 동의 전까지 /users/me/terms로 동의 요청 필요)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Terms"
+                    }
+                },
+                "refreshExpiresIn": {
+                    "description": "리프레시 토큰 만료 시간(초)",
+                    "type": "integer",
+                    "example": 1209600
+                },
+                "refreshToken": {
+                    "description": "액세스 토큰 재발급용 리프레시 토큰",
+                    "type": "string",
+                    "example": "hR3f...Qk"
+                },
+                "token": {
+                    "description": "JWT 인증 토큰",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "models.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "allDevices": {
+                    "description": "true이면 모든 기기의 로그인 세션을 종료",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "models.MFAConfirmRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "인증 앱의 6자리 코드",
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "models.MFADisableRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "description": "인증 앱의 6자리 코드 또는 복구 코드",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "password": {
+                    "description": "현재 비밀번호",
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "models.MFAEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "otpauthUri": {
+                    "description": "QR 코드 생성용 URI",
+                    "type": "string",
+                    "example": "otpauth://totp/Momentir:user@example.com?secret=JBSWY3DPEHPK3PXP"
+                },
+                "secret": {
+                    "description": "인증 앱 수동 등록용 비밀키",
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "models.MFALoginRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfaToken"
+            ],
+            "properties": {
+                "code": {
+                    "description": "인증 앱의 6자리 코드 또는 복구 코드",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "mfaToken": {
+                    "description": "로그인 시 발급받은 임시 토큰",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "models.MFARecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
+                    "description": "1회용 복구 코드 (다시 조회할 수 없음)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "a1b2c-d3e4f"
+                    ]
+                }
+            }
+        },
+        "models.MarketingConsent": {
+            "type": "object",
+            "properties": {
+                "agreed": {
+                    "type": "boolean"
+                },
+                "channel": {
+                    "description": "email, sms, push",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ipAddress": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "sign_up, profile, settings, reconfirmation_notice (기존 동의는 migration)",
+                    "type": "string"
+                },
+                "termsVersion": {
+                    "description": "동의 당시 마케팅 수신 약관 버전",
+                    "type": "string"
+                }
+            }
+        },
+        "models.MarketingConsentChange": {
+            "type": "object",
+            "required": [
+                "agreed",
+                "channel"
+            ],
+            "properties": {
+                "agreed": {
+                    "description": "수신 동의 여부",
+                    "type": "boolean",
+                    "example": true
+                },
+                "channel": {
+                    "description": "채널 (email, sms, push)",
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "sms",
+                        "push"
+                    ],
+                    "example": "email"
+                }
+            }
+        },
+        "models.MarketingConsentResponse": {
+            "type": "object",
+            "properties": {
+                "confirmedAt": {
+                    "description": "마지막 동의 또는 재확인 안내 시간 (2년마다 재확인 안내)",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "consents": {
+                    "description": "채널별 수신 동의 여부",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MarketingConsentStatus"
+                    }
+                },
+                "history": {
+                    "description": "동의/철회 이력 (최신순)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MarketingConsent"
+                    }
+                }
+            }
+        },
+        "models.MarketingConsentStatus": {
+            "type": "object",
+            "properties": {
+                "agreed": {
+                    "description": "수신 동의 여부",
+                    "type": "boolean",
+                    "example": true
+                },
+                "channel": {
+                    "description": "채널 (email, sms, push)",
+                    "type": "string",
+                    "example": "email"
+                },
+                "updatedAt": {
+                    "description": "마지막으로 동의 또는 철회한 시간 (이력이 없으면 null)",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.OAuthErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "OAuth 2.0 오류 코드",
+                    "type": "string",
+                    "example": "invalid_grant"
+                },
+                "error_description": {
+                    "description": "오류 설명",
                     "type": "string",
-                    "example": "요청 처리 중 오류가 발생했습니다."
+                    "example": "Invalid authorization code"
                 }
             }
         },
-        "models.FindMyEmailResponse": {
+        "models.OAuthTokenResponse": {
             "type": "object",
             "properties": {
-                "maskedEmail": {
-                    "description": "마스킹된 이메일 주소",
+                "access_token": {
+                    "description": "액세스 토큰 (userinfo 호출용, client_credentials는 서비스 토큰)",
                     "type": "string",
-                    "example": "us***@example.com"
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_in": {
+                    "description": "액세스 토큰 만료 시간(초)",
+                    "type": "integer",
+                    "example": 900
+                },
+                "id_token": {
+                    "description": "ID 토큰",
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjIwMjYtMTAifQ..."
+                },
+                "scope": {
+                    "description": "허용된 scope",
+                    "type": "string",
+                    "example": "openid profile email"
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
                 }
             }
         },
-        "models.LoginRequest": {
+        "models.Permission": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReactivateAccountRequest": {
             "type": "object",
             "required": [
                 "email",
-                "password"
+                "verificationCode",
+                "verificationId"
             ],
             "properties": {
                 "email": {
-                    "description": "사용자 이메일 주소",
+                    "description": "휴면 계정의 이메일 주소",
                     "type": "string",
                     "example": "user@example.com"
                 },
-                "password": {
-                    "description": "사용자 비밀번호",
+                "verificationCode": {
+                    "description": "이메일로 받은 인증 코드",
                     "type": "string",
-                    "example": "password123"
+                    "example": "123456"
+                },
+                "verificationId": {
+                    "description": "로그인 시 받은 휴면 해제 인증 ID",
+                    "type": "integer",
+                    "example": 12345
                 }
             }
         },
-        "models.LoginResponse": {
+        "models.RefreshTokenRequest": {
             "type": "object",
+            "required": [
+                "refreshToken"
+            ],
             "properties": {
-                "expiresIn": {
-                    "description": "토큰 만료 시간(초)",
-                    "type": "integer",
-                    "example": 3600
+                "refreshToken": {
+                    "description": "로그인 시 발급받은 리프레시 토큰",
+                    "type": "string",
+                    "example": "hR3f...Qk"
+                }
+            }
+        },
+        "models.RequestEmailChangeRequest": {
+            "type": "object",
+            "required": [
+                "newEmail",
+                "password"
+            ],
+            "properties": {
+                "newEmail": {
+                    "description": "변경할 이메일 주소 (인증 코드 발송)",
+                    "type": "string",
+                    "example": "new@example.com"
                 },
-                "token": {
-                    "description": "JWT 인증 토큰",
+                "password": {
+                    "description": "현재 비밀번호",
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "password123"
                 }
             }
         },
@@ -472,6 +2992,11 @@ const docTemplate = `{
                     "description": "비밀번호를 재설정할 이메일 주소",
                     "type": "string",
                     "example": "user@example.com"
+                },
+                "tenant": {
+                    "description": "재설정 링크를 받을 프론트엔드 (생략하면 기본 주소)",
+                    "type": "string",
+                    "example": "momentir"
                 }
             }
         },
@@ -489,12 +3014,51 @@ const docTemplate = `{
                     "example": "newpass123"
                 },
                 "token": {
-                    "description": "이메일로 받은 재설정 토큰",
+                    "description": "이메일로 받은 재설정 토큰 (한 번만 사용 가능)",
                     "type": "string",
                     "example": "reset_token_abc123"
                 }
             }
         },
+        "models.RestoreAccountRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "탈퇴한 계정의 이메일 주소",
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "description": "탈퇴 전 비밀번호",
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "models.Role": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Permission"
+                    }
+                }
+            }
+        },
         "models.SignUpRequest": {
             "type": "object",
             "required": [
@@ -514,6 +3078,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "user@example.com"
                 },
+                "locale": {
+                    "description": "안내 메일 언어 (ko, en). 생략하면 Accept-Language 헤더 기준",
+                    "type": "string",
+                    "example": "ko"
+                },
                 "name": {
                     "description": "사용자 이름",
                     "type": "string",
@@ -529,6 +3098,301 @@ const docTemplate = `{
                     "description": "전화번호",
                     "type": "string",
                     "example": "010-1234-5678"
+                },
+                "terms": {
+                    "description": "동의한 약관 (필수 약관의 현재 버전은 모두 포함해야 함)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AcceptedTerms"
+                    }
+                }
+            }
+        },
+        "models.SocialAuthorizeResponse": {
+            "type": "object",
+            "properties": {
+                "authorizationUrl": {
+                    "description": "사용자를 보낼 제공자 로그인 화면 주소",
+                    "type": "string",
+                    "example": "https://kauth.kakao.com/oauth/authorize?response_type=code\u0026client_id=..."
+                },
+                "expiresIn": {
+                    "description": "state 유효 시간(초)",
+                    "type": "integer",
+                    "example": 600
+                },
+                "state": {
+                    "description": "콜백에서 돌려받은 state와 비교하고 /callback에 그대로 보낼 값",
+                    "type": "string",
+                    "example": "hR3f...Qk"
+                }
+            }
+        },
+        "models.SocialCallbackRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "state"
+            ],
+            "properties": {
+                "code": {
+                    "description": "제공자가 redirect URL로 돌려준 인가 코드",
+                    "type": "string",
+                    "example": "SplxlOBeZQQYbYS6WxSbIA"
+                },
+                "state": {
+                    "description": "제공자가 redirect URL로 돌려준 state",
+                    "type": "string",
+                    "example": "hR3f...Qk"
+                }
+            }
+        },
+        "models.SocialSignUpRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "phone",
+                "signUpToken"
+            ],
+            "properties": {
+                "agreedMarketingOptIn": {
+                    "description": "마케팅 수신 동의",
+                    "type": "boolean",
+                    "example": true
+                },
+                "locale": {
+                    "description": "안내 메일 언어 (ko, en). 생략하면 Accept-Language 헤더 기준",
+                    "type": "string",
+                    "example": "ko"
+                },
+                "name": {
+                    "description": "사용자 이름",
+                    "type": "string",
+                    "example": "홍길동"
+                },
+                "phone": {
+                    "description": "전화번호",
+                    "type": "string",
+                    "example": "010-1234-5678"
+                },
+                "signUpToken": {
+                    "description": "콜백에서 받은 가입 전용 토큰",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "terms": {
+                    "description": "동의한 약관 (필수 약관의 현재 버전은 모두 포함해야 함)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AcceptedTerms"
+                    }
+                }
+            }
+        },
+        "models.Terms": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "문서 종류 (service, privacy, age_14 등)",
+                    "type": "string"
+                },
+                "displayOrder": {
+                    "type": "integer"
+                },
+                "effectiveAt": {
+                    "type": "string"
+                },
+                "required": {
+                    "description": "가입과 이용에 동의가 필요한지 여부",
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "문서 본문 주소",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "문서 버전",
+                    "type": "string"
+                }
+            }
+        },
+        "models.TermsStatus": {
+            "type": "object",
+            "properties": {
+                "agreedAt": {
+                    "description": "마지막으로 동의한 시간",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "agreedVersion": {
+                    "description": "마지막으로 동의한 버전 (동의한 적 없으면 빈 문자열)",
+                    "type": "string",
+                    "example": "1"
+                },
+                "code": {
+                    "description": "문서 종류 (service, privacy, age_14 등)",
+                    "type": "string"
+                },
+                "displayOrder": {
+                    "type": "integer"
+                },
+                "effectiveAt": {
+                    "type": "string"
+                },
+                "pending": {
+                    "description": "현재 버전에 다시 동의해야 하는 필수 약관인지 여부",
+                    "type": "boolean",
+                    "example": false
+                },
+                "required": {
+                    "description": "가입과 이용에 동의가 필요한지 여부",
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "문서 본문 주소",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "문서 버전",
+                    "type": "string"
+                }
+            }
+        },
+        "models.UndoEmailChangeRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "description": "이전 이메일로 받은 되돌리기 토큰 (한 번만 사용 가능)",
+                    "type": "string",
+                    "example": "undo_token_abc123"
+                }
+            }
+        },
+        "models.UpdateMarketingConsentRequest": {
+            "type": "object",
+            "required": [
+                "consents"
+            ],
+            "properties": {
+                "consents": {
+                    "description": "변경할 채널 (보내지 않은 채널은 그대로)",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.MarketingConsentChange"
+                    }
+                }
+            }
+        },
+        "models.UpdateProfileRequest": {
+            "type": "object",
+            "required": [
+                "updatedAt"
+            ],
+            "properties": {
+                "agreedMarketingOptIn": {
+                    "description": "마케팅 수신 동의 (생략하면 변경하지 않음)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "description": "사용자 이름 (생략하면 변경하지 않음)",
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 1,
+                    "example": "홍길동"
+                },
+                "phone": {
+                    "description": "전화번호 (생략하면 변경하지 않음)",
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "010-1234-5678"
+                },
+                "updatedAt": {
+                    "description": "조회한 회원 정보의 updatedAt (다른 곳에서 변경되었으면 409)",
+                    "type": "string",
+                    "example": "2026-01-01T09:00:00Z"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "agreedMarketingOptIn": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "signUpStatus": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserInfo": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "email scope",
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "email_verified": {
+                    "description": "email scope",
+                    "type": "boolean",
+                    "example": true
+                },
+                "locale": {
+                    "description": "profile scope",
+                    "type": "string",
+                    "example": "ko"
+                },
+                "name": {
+                    "description": "profile scope",
+                    "type": "string",
+                    "example": "홍길동"
+                },
+                "phone_number": {
+                    "description": "phone scope",
+                    "type": "string",
+                    "example": "010-1234-5678"
+                },
+                "sub": {
+                    "description": "사용자 ID",
+                    "type": "string",
+                    "example": "42"
+                },
+                "updated_at": {
+                    "description": "profile scope",
+                    "type": "integer",
+                    "example": 1704067200
                 }
             }
         },
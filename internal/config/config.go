@@ -4,6 +4,8 @@ import (
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 )
 
 type Config struct {
@@ -13,12 +15,62 @@ type Config struct {
 	DBPassword            string
 	DBName                string
 	JWTSecretKey          string
+	JWTSigningKey         string
+	JWTSigningKeyID       string
+	JWTSigningKeysDir     string
+	JWTActiveKeyID        string
+	JWTExpiresIn          int
+	RefreshTokenExpiresIn int
+	MFAIssuer             string
+	LockoutThreshold      int
+	LockoutWindow         int
+	LockoutDuration       int
+	LockoutMaxDuration    int
+	LockoutPermanentAfter int
+	IPLockoutThreshold    int
+	IPLockoutWindow       int
+	IPLockoutDuration     int
+	TrustedProxies        []string
+	PasswordResetURL      string
+	PasswordResetURLs     map[string]string
+	ResetTokenExpiresIn   int
+	VerifyCodeMaxAttempts int
+	VerifyCodeCooldown    int
+	EmailUndoURL          string
+	EmailUndoExpiresIn    int
+	AccountRestorePeriod  int
+	AccountRetention      int
+	AccountPurgeInterval  int
+	DormantPeriod         int
+	DormantNoticeBefore   int
+	DormantJobInterval    int
+	MarketingTermsVersion string
+	MarketingReconfirm    int
+	MarketingJobInterval  int
+	OAuthProviders        map[string]OAuthProvider
+	OIDCIssuer            string
+	OIDCLoginURL          string
 	AWSRegion             string
 	AWSSESAccessKey       string
 	AWSSESSecretAccessKey string
 	AWSSESFromEmail       string
+	MailDriver            string
+	MailFrom              string
+	MailFileDir           string
+	SMTPHost              string
+	SMTPPort              string
+	SMTPUsername          string
+	SMTPPassword          string
+	MailDefaultLocale     string
+	BrandProductName      string
+	BrandSupportEmail     string
+	BrandWebsiteURL       string
+	RateLimitStore        string
+	RateLimits            map[string]string
+	RedisAddr             string
+	RedisPassword         string
+	RedisDB               int
 	ServerPort            string
-	SkipMigration         bool
 }
 
 func LoadConfig() *Config {
@@ -27,6 +79,8 @@ func LoadConfig() *Config {
 		log.Println("Warning: .env file not found, using environment variables")
 	}
 
+	sesFromEmail := getEnv("AWS_SES_FROM_EMAIL", "noreply@yourdomain.com")
+
 	return &Config{
 		DBHost:                getEnv("DATABASE_HOST", "localhost"),
 		DBPort:                getEnv("DATABASE_PORT", "3306"),
@@ -34,18 +88,187 @@ func LoadConfig() *Config {
 		DBPassword:            getEnv("DATABASE_PASSWORD", "password"),
 		DBName:                getEnv("DATABASE_DEFAULT_SCHEMA", "auth_service"),
 		JWTSecretKey:          getEnv("JWT_SECRET_KEY", "your-secret-key-here"),
+		JWTSigningKey:         getEnv("JWT_SIGNING_KEY", ""),
+		JWTSigningKeyID:       getEnv("JWT_SIGNING_KEY_ID", "default"),
+		JWTSigningKeysDir:     getEnv("JWT_SIGNING_KEYS_DIR", ""),
+		JWTActiveKeyID:        getEnv("JWT_ACTIVE_KEY_ID", ""),
+		JWTExpiresIn:          getEnvInt("JWT_EXPIRES_IN", 60*15),                 // 15 minutes
+		RefreshTokenExpiresIn: getEnvInt("REFRESH_TOKEN_EXPIRES_IN", 60*60*24*14), // 14 days
+		MFAIssuer:             getEnv("MFA_ISSUER", "Momentir"),
+		LockoutThreshold:      getEnvInt("LOGIN_LOCKOUT_THRESHOLD", 5),
+		LockoutWindow:         getEnvInt("LOGIN_LOCKOUT_WINDOW", 60*15),       // 15 minutes
+		LockoutDuration:       getEnvInt("LOGIN_LOCKOUT_DURATION", 60),        // 1 minute, doubled on each lockout
+		LockoutMaxDuration:    getEnvInt("LOGIN_LOCKOUT_MAX_DURATION", 60*60), // 1 hour
+		LockoutPermanentAfter: getEnvInt("LOGIN_LOCKOUT_PERMANENT_AFTER", 5),  // 0 disables permanent lock
+		IPLockoutThreshold:    getEnvInt("LOGIN_IP_LOCKOUT_THRESHOLD", 20),
+		IPLockoutWindow:       getEnvInt("LOGIN_IP_LOCKOUT_WINDOW", 60*15),   // 15 minutes
+		IPLockoutDuration:     getEnvInt("LOGIN_IP_LOCKOUT_DURATION", 60*15), // 15 minutes, doubled on each lockout
+		TrustedProxies:        getEnvList("TRUSTED_PROXIES"),                 // load balancer CIDRs, none trusted by default
+		PasswordResetURL:      getEnv("PASSWORD_RESET_URL", "https://yourdomain.com/auth/reset-password"),
+		PasswordResetURLs:     getEnvMap("PASSWORD_RESET_URLS"),
+		ResetTokenExpiresIn:   getEnvInt("PASSWORD_RESET_EXPIRES_IN", 60*60), // 1 hour
+		VerifyCodeMaxAttempts: getEnvInt("EMAIL_VERIFICATION_MAX_ATTEMPTS", 5),
+		VerifyCodeCooldown:    getEnvInt("EMAIL_VERIFICATION_RESEND_COOLDOWN", 60), // seconds
+		EmailUndoURL:          getEnv("EMAIL_CHANGE_UNDO_URL", "https://yourdomain.com/auth/undo-email-change"),
+		EmailUndoExpiresIn:    getEnvInt("EMAIL_CHANGE_UNDO_EXPIRES_IN", 60*60*24*7), // 7 days
+		AccountRestorePeriod:  getEnvInt("ACCOUNT_RESTORE_PERIOD", 60*60*24*30),      // 30 days
+		AccountRetention:      getEnvInt("ACCOUNT_RETENTION_PERIOD", 60*60*24*30),    // 30 days, never shorter than the restore period
+		AccountPurgeInterval:  getEnvInt("ACCOUNT_PURGE_INTERVAL", 60*60),            // 1 hour, 0 disables the purge job
+		DormantPeriod:         getEnvInt("DORMANT_PERIOD", 60*60*24*365),             // 1 year without login
+		DormantNoticeBefore:   getEnvInt("DORMANT_NOTICE_BEFORE", 60*60*24*30),       // 30 days
+		DormantJobInterval:    getEnvInt("DORMANT_JOB_INTERVAL", 60*60),              // 1 hour, 0 disables the dormant job
+		MarketingTermsVersion: getEnv("MARKETING_TERMS_VERSION", "1"),
+		MarketingReconfirm:    getEnvInt("MARKETING_RECONFIRM_PERIOD", 60*60*24*730), // 2 years
+		MarketingJobInterval:  getEnvInt("MARKETING_JOB_INTERVAL", 60*60),            // 1 hour, 0 disables the re-confirmation job
+		OAuthProviders:        oauthProviders(),
+		OIDCIssuer:            strings.TrimSuffix(getEnv("OIDC_ISSUER", "http://localhost:8081"), "/"),
+		OIDCLoginURL:          getEnv("OIDC_LOGIN_URL", "https://yourdomain.com/auth/login"),
 		AWSRegion:             getEnv("AWS_REGION", "ap-northeast-2"),
 		AWSSESAccessKey:       getEnv("AWS_SES_ACCESS_KEY", ""),
 		AWSSESSecretAccessKey: getEnv("AWS_SES_SECRET_ACCESS_KEY", ""),
-		AWSSESFromEmail:       getEnv("AWS_SES_FROM_EMAIL", "noreply@yourdomain.com"),
+		AWSSESFromEmail:       sesFromEmail,
+		MailDriver:            getEnv("MAIL_DRIVER", "ses"),
+		MailFrom:              getEnv("MAIL_FROM", sesFromEmail),
+		MailFileDir:           getEnv("MAIL_FILE_DIR", "./tmp/mail"),
+		SMTPHost:              getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:              getEnv("SMTP_PORT", "1025"),
+		SMTPUsername:          getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:          getEnv("SMTP_PASSWORD", ""),
+		MailDefaultLocale:     getEnv("MAIL_DEFAULT_LOCALE", "ko"),
+		BrandProductName:      getEnv("BRAND_PRODUCT_NAME", "Momentir"),
+		BrandSupportEmail:     getEnv("BRAND_SUPPORT_EMAIL", "support@yourdomain.com"),
+		BrandWebsiteURL:       getEnv("BRAND_WEBSITE_URL", "https://yourdomain.com"),
+		RateLimitStore:        getEnv("RATE_LIMIT_STORE", "memory"),
+		RateLimits:            rateLimits(),
+		RedisAddr:             getEnv("REDIS_ADDR", "localhost:6379"),
+		RedisPassword:         getEnv("REDIS_PASSWORD", ""),
+		RedisDB:               getEnvInt("REDIS_DB", 0),
 		ServerPort:            getEnv("SERVER_PORT", "8081"),
-		SkipMigration:         getEnv("SKIP_MIGRATION", "false") == "true",
 	}
 }
 
+// OAuthProvider는 소셜 로그인 제공자(카카오, 네이버, 구글) 설정이다. ClientID가 없으면 사용하지 않는다.
+type OAuthProvider struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // 제공자가 인가 코드를 돌려줄 프론트엔드 주소
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+}
+
+// oauthProviders는 <PROVIDER>_CLIENT_ID 등의 환경 변수로 제공자 설정을 읽는다.
+// 엔드포인트는 기본값이 있으며, 테스트나 로컬 개발에서는 가짜 OAuth 서버 주소로 바꿀 수 있다.
+func oauthProviders() map[string]OAuthProvider {
+	defaults := map[string]OAuthProvider{
+		"kakao": {
+			AuthURL:     "https://kauth.kakao.com/oauth/authorize",
+			TokenURL:    "https://kauth.kakao.com/oauth/token",
+			UserInfoURL: "https://kapi.kakao.com/v2/user/me",
+		},
+		"naver": {
+			AuthURL:     "https://nid.naver.com/oauth2.0/authorize",
+			TokenURL:    "https://nid.naver.com/oauth2.0/token",
+			UserInfoURL: "https://openapi.naver.com/v1/nid/me",
+		},
+		"google": {
+			AuthURL:     "https://accounts.google.com/o/oauth2/v2/auth",
+			TokenURL:    "https://oauth2.googleapis.com/token",
+			UserInfoURL: "https://openidconnect.googleapis.com/v1/userinfo",
+		},
+	}
+
+	providers := map[string]OAuthProvider{}
+	for name, provider := range defaults {
+		prefix := strings.ToUpper(name) + "_"
+		provider.ClientID = getEnv(prefix+"CLIENT_ID", "")
+		if provider.ClientID == "" {
+			continue
+		}
+		provider.ClientSecret = getEnv(prefix+"CLIENT_SECRET", "")
+		provider.RedirectURL = getEnv(prefix+"REDIRECT_URL", "")
+		provider.AuthURL = getEnv(prefix+"AUTH_URL", provider.AuthURL)
+		provider.TokenURL = getEnv(prefix+"TOKEN_URL", provider.TokenURL)
+		provider.UserInfoURL = getEnv(prefix+"USERINFO_URL", provider.UserInfoURL)
+		providers[name] = provider
+	}
+	return providers
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+// rateLimits는 라우트별 요청 제한 기본값에 RATE_LIMITS 환경 변수 값을 덮어쓴다.
+// 예: RATE_LIMITS="reset-password=5/10m:ip+2/10m:email,login=off"
+func rateLimits() map[string]string {
+	limits := map[string]string{
+		"login":                  "20/1m:ip",
+		"mfa-login":              "10/1m:ip",
+		"token-refresh":          "60/1m:ip",
+		"find-my-email":          "10/10m:ip",
+		"reset-password":         "20/10m:ip+3/10m:email",
+		"reset-password-confirm": "20/10m:ip",
+		"email-verification":     "20/10m:ip+3/10m:email",
+		"verify-email":           "30/10m:ip",
+		"sign-up":                "10/10m:ip",
+		"mfa":                    "10/10m:user",
+		"change-password":        "5/10m:user",
+		"email-change":           "5/10m:user",
+		"email-change-verify":    "10/10m:user",
+		"email-change-undo":      "10/10m:ip",
+		"delete-account":         "5/10m:user",
+		"restore-account":        "10/10m:ip",
+		"reactivate-account":     "10/10m:ip",
+		"marketing-consent":      "10/10m:user",
+		"social-login":           "20/1m:ip",
+		"oauth-authorize":        "30/1m:ip",
+		"oauth-token":            "60/1m:ip",
+		"oauth-introspect":       "1200/1m:ip",
+		"oauth-revoke":           "60/1m:ip",
+	}
+	for name, spec := range getEnvMap("RATE_LIMITS") {
+		limits[name] = spec
+	}
+	return limits
+}
+
+// getEnvMap은 "key1=value1,key2=value2" 형식의 환경 변수를 map으로 읽는다.
+func getEnvMap(key string) map[string]string {
+	result := map[string]string{}
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || name == "" {
+			continue
+		}
+		result[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return result
+}
+
+// getEnvList는 "value1,value2" 형식의 환경 변수를 목록으로 읽는다. 값이 없으면 nil이다.
+func getEnvList(key string) []string {
+	var result []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Warning: invalid integer for %s, using default %d", key, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	migrations, err := loadMigrations(migrationFS, "migrations")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		assert.Equal(t, int64(i+1), migration.Version, "migration versions should be sequential")
+	}
+}
+
+func TestLoadMigrationsRequiresPairs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/0002_second.up.sql":   {Data: []byte("SELECT 2;")},
+		"m/0002_second.down.sql": {Data: []byte("SELECT -2;")},
+		"m/0001_first.up.sql":    {Data: []byte("SELECT 1;")},
+		"m/0001_first.down.sql":  {Data: []byte("SELECT -1;")},
+	}
+	migrations, err := loadMigrations(fsys, "m")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, "first", migrations[0].Name)
+	assert.Equal(t, "SELECT -2;", migrations[1].Down)
+
+	delete(fsys, "m/0002_second.down.sql")
+	_, err = loadMigrations(fsys, "m")
+	assert.Error(t, err)
+
+	fsys["m/0002_second.down.sql"] = &fstest.MapFile{Data: []byte("SELECT -2;")}
+	fsys["m/0002_other.up.sql"] = &fstest.MapFile{Data: []byte("SELECT 2;")}
+	_, err = loadMigrations(fsys, "m")
+	assert.Error(t, err)
+
+	_, err = loadMigrations(fstest.MapFS{"m/notes.txt": {Data: []byte("x")}}, "m")
+	assert.Error(t, err)
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS password_reset_tokens;
+DROP TABLE IF EXISTS login_failures;
+DROP TABLE IF EXISTS email_verifications;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,45 @@
+-- 기존 AutoMigrate로 만들어진 데이터베이스에도 적용할 수 있도록 IF NOT EXISTS를 사용한다.
+CREATE TABLE IF NOT EXISTS users (
+    id bigserial PRIMARY KEY,
+    name varchar(30) NOT NULL,
+    email varchar(60) NOT NULL,
+    encrypted_password varchar(256) NOT NULL,
+    phone varchar(30) NOT NULL,
+    sign_up_token varchar(50),
+    reset_password_token varchar(256),
+    agreed_marketing_opt_in boolean DEFAULT false,
+    sign_up_status varchar(20) DEFAULT 'IN_PROGRESS',
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS email_verifications (
+    id bigserial PRIMARY KEY,
+    email varchar(60) NOT NULL,
+    verification_code varchar(10) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    verified_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_email_verifications_email ON email_verifications (email);
+
+CREATE TABLE IF NOT EXISTS login_failures (
+    id bigserial PRIMARY KEY,
+    email varchar(60) NOT NULL,
+    failure_reason varchar(50) NOT NULL,
+    created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_login_failures_email ON login_failures (email);
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    token varchar(256) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz,
+    CONSTRAINT fk_password_reset_tokens_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
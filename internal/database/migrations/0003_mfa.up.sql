@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS user_mfas (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    secret varchar(64) NOT NULL,
+    last_used_step bigint NOT NULL DEFAULT 0,
+    confirmed_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_mfas_user_id ON user_mfas (user_id);
+
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    code_hash varchar(64) NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_mfa_recovery_codes_user_id ON mfa_recovery_codes (user_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_password_reset_tokens_token;
+ALTER TABLE password_reset_tokens DROP COLUMN IF EXISTS used_at;
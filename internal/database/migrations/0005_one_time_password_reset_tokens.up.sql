@@ -0,0 +1,5 @@
+ALTER TABLE password_reset_tokens ADD COLUMN IF NOT EXISTS used_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_token ON password_reset_tokens (token);
+
+-- 이전 방식(JWT 원문 저장)으로 발급된 토큰은 해시와 비교할 수 없으므로 정리한다.
+DELETE FROM password_reset_tokens WHERE length(token) <> 64;
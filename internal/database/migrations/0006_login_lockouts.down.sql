@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS login_lockouts;
+ALTER TABLE login_failures DROP COLUMN IF EXISTS ip_address;
@@ -0,0 +1,14 @@
+ALTER TABLE login_failures ADD COLUMN IF NOT EXISTS ip_address varchar(45);
+
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    id bigserial PRIMARY KEY,
+    scope varchar(10) NOT NULL,
+    identifier varchar(100) NOT NULL,
+    failure_count bigint NOT NULL DEFAULT 0,
+    window_started_at timestamptz,
+    lockout_count bigint NOT NULL DEFAULT 0,
+    locked_until timestamptz,
+    permanent boolean NOT NULL DEFAULT false,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_login_lockouts_scope_identifier ON login_lockouts (scope, identifier);
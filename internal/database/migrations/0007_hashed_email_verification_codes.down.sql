@@ -0,0 +1,4 @@
+ALTER TABLE email_verifications DROP COLUMN IF EXISTS attempts;
+-- bcrypt 해시는 varchar(10)에 들어가지 않으므로 진행 중이던 인증은 모두 정리한다.
+DELETE FROM email_verifications WHERE length(verification_code) > 10;
+ALTER TABLE email_verifications ALTER COLUMN verification_code TYPE varchar(10);
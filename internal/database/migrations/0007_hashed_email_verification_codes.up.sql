@@ -0,0 +1,6 @@
+ALTER TABLE email_verifications ALTER COLUMN verification_code TYPE varchar(60);
+ALTER TABLE email_verifications ADD COLUMN IF NOT EXISTS attempts bigint NOT NULL DEFAULT 0;
+
+-- 평문으로 저장된 진행 중인 인증 코드는 해시와 비교할 수 없으므로 만료시킨다.
+UPDATE email_verifications SET expires_at = now()
+WHERE verified_at IS NULL AND expires_at > now() AND length(verification_code) <> 60;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS email_changes;
+
+-- 용도 구분이 없어지면 이메일 변경 인증이 회원가입 인증으로 취급되므로 먼저 정리한다.
+DELETE FROM email_verifications WHERE purpose <> 'sign_up';
+DROP INDEX IF EXISTS idx_email_verifications_user_id;
+ALTER TABLE email_verifications DROP COLUMN IF EXISTS user_id;
+ALTER TABLE email_verifications DROP COLUMN IF EXISTS purpose;
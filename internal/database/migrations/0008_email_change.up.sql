@@ -0,0 +1,16 @@
+ALTER TABLE email_verifications ADD COLUMN purpose varchar(20) NOT NULL DEFAULT 'sign_up';
+ALTER TABLE email_verifications ADD COLUMN user_id bigint;
+CREATE INDEX idx_email_verifications_user_id ON email_verifications (user_id);
+
+CREATE TABLE email_changes (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users (id),
+    old_email varchar(60) NOT NULL,
+    new_email varchar(60) NOT NULL,
+    undo_token_hash varchar(64) NOT NULL,
+    undo_expires_at timestamptz NOT NULL,
+    undone_at timestamptz,
+    created_at timestamptz
+);
+CREATE INDEX idx_email_changes_user_id ON email_changes (user_id);
+CREATE UNIQUE INDEX idx_email_changes_undo_token_hash ON email_changes (undo_token_hash);
@@ -0,0 +1,6 @@
+ALTER TABLE users ADD COLUMN anonymized_at timestamptz;
+
+-- 회원가입 시 소프트 삭제되던 미완료 가입 행은 이메일 유니크 인덱스를 계속 점유하므로 정리한다.
+DELETE FROM password_reset_tokens
+WHERE user_id IN (SELECT id FROM users WHERE deleted_at IS NOT NULL AND sign_up_status = 'IN_PROGRESS');
+DELETE FROM users WHERE deleted_at IS NOT NULL AND sign_up_status = 'IN_PROGRESS';
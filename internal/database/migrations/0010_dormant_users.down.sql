@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS dormant_users;
+DROP INDEX IF EXISTS idx_users_last_login_at;
+ALTER TABLE users DROP COLUMN IF EXISTS dormant_at;
+ALTER TABLE users DROP COLUMN IF EXISTS dormant_notified_at;
+ALTER TABLE users DROP COLUMN IF EXISTS last_login_at;
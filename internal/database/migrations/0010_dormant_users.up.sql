@@ -0,0 +1,18 @@
+ALTER TABLE users ADD COLUMN last_login_at timestamptz;
+UPDATE users SET last_login_at = updated_at;
+ALTER TABLE users ALTER COLUMN last_login_at SET NOT NULL;
+ALTER TABLE users ALTER COLUMN last_login_at SET DEFAULT now();
+ALTER TABLE users ADD COLUMN dormant_notified_at timestamptz;
+ALTER TABLE users ADD COLUMN dormant_at timestamptz;
+CREATE INDEX idx_users_last_login_at ON users (last_login_at);
+
+CREATE TABLE dormant_users (
+    user_id bigint PRIMARY KEY REFERENCES users (id),
+    name varchar(30) NOT NULL,
+    email varchar(60) NOT NULL,
+    encrypted_password varchar(256) NOT NULL,
+    phone varchar(30) NOT NULL,
+    agreed_marketing_opt_in boolean NOT NULL DEFAULT false,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX idx_dormant_users_email ON dormant_users (email);
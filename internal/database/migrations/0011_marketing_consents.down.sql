@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS marketing_confirmed_at;
+DROP TABLE IF EXISTS marketing_consents;
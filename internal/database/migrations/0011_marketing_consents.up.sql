@@ -0,0 +1,24 @@
+CREATE TABLE marketing_consents (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users (id),
+    channel varchar(10) NOT NULL,
+    agreed boolean NOT NULL,
+    source varchar(30) NOT NULL,
+    terms_version varchar(30) NOT NULL,
+    ip_address varchar(45),
+    created_at timestamptz
+);
+CREATE INDEX idx_marketing_consents_user_id ON marketing_consents (user_id);
+
+ALTER TABLE users ADD COLUMN marketing_confirmed_at timestamptz;
+
+-- 기존 동의는 시간과 채널을 알 수 없으므로 가입 시간에 모든 채널에 동의한 것으로 기록한다.
+INSERT INTO marketing_consents (user_id, channel, agreed, source, terms_version, created_at)
+SELECT users.id, channels.channel, true, 'migration', '', users.created_at
+FROM users CROSS JOIN (VALUES ('email'), ('sms'), ('push')) AS channels (channel)
+WHERE users.agreed_marketing_opt_in
+   OR EXISTS (SELECT 1 FROM dormant_users WHERE dormant_users.user_id = users.id AND dormant_users.agreed_marketing_opt_in);
+
+UPDATE users SET marketing_confirmed_at = created_at
+WHERE agreed_marketing_opt_in
+   OR EXISTS (SELECT 1 FROM dormant_users WHERE dormant_users.user_id = users.id AND dormant_users.agreed_marketing_opt_in);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS terms_agreements;
+DROP TABLE IF EXISTS terms;
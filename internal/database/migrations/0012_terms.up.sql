@@ -0,0 +1,30 @@
+CREATE TABLE terms (
+    id bigserial PRIMARY KEY,
+    code varchar(30) NOT NULL,
+    version varchar(30) NOT NULL,
+    title varchar(100) NOT NULL,
+    url varchar(255) NOT NULL,
+    required boolean NOT NULL,
+    display_order integer NOT NULL DEFAULT 0,
+    effective_at timestamptz NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX idx_terms_code_version ON terms (code, version);
+
+CREATE TABLE terms_agreements (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users (id),
+    terms_code varchar(30) NOT NULL,
+    terms_version varchar(30) NOT NULL,
+    ip_address varchar(45),
+    agreed_at timestamptz NOT NULL,
+    FOREIGN KEY (terms_code, terms_version) REFERENCES terms (code, version)
+);
+CREATE UNIQUE INDEX idx_terms_agreements_user_terms ON terms_agreements (user_id, terms_code, terms_version);
+
+-- 첫 버전. 새 버전은 같은 code로 행을 추가하며, url은 프론트엔드의 약관 페이지 경로다.
+-- 기존 사용자의 동의 기록은 없으므로 다음 로그인 때 동의를 다시 받는다.
+INSERT INTO terms (code, version, title, url, required, display_order, effective_at, created_at) VALUES
+    ('age_14', '1', '만 14세 이상입니다', '/terms/age-14', true, 1, now(), now()),
+    ('service', '1', '서비스 이용약관', '/terms/service/1', true, 2, now(), now()),
+    ('privacy', '1', '개인정보 수집 및 이용 동의', '/terms/privacy/1', true, 3, now(), now());
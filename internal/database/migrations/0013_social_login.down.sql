@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS social_login_states;
+DROP TABLE IF EXISTS user_identities;
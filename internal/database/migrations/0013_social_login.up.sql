@@ -0,0 +1,20 @@
+CREATE TABLE user_identities (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users (id),
+    provider varchar(20) NOT NULL,
+    subject varchar(100) NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX idx_user_identities_provider_subject ON user_identities (provider, subject);
+CREATE INDEX idx_user_identities_user_id ON user_identities (user_id);
+
+CREATE TABLE social_login_states (
+    id bigserial PRIMARY KEY,
+    state_hash varchar(64) NOT NULL,
+    provider varchar(20) NOT NULL,
+    code_verifier varchar(128) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX idx_social_login_states_state_hash ON social_login_states (state_hash);
+CREATE INDEX idx_social_login_states_expires_at ON social_login_states (expires_at);
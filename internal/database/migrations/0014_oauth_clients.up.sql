@@ -0,0 +1,25 @@
+CREATE TABLE oauth_clients (
+    id bigserial PRIMARY KEY,
+    client_id varchar(64) NOT NULL,
+    client_secret_hash varchar(64),
+    name varchar(100) NOT NULL,
+    redirect_uris text NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX idx_oauth_clients_client_id ON oauth_clients (client_id);
+
+CREATE TABLE oauth_authorization_codes (
+    id bigserial PRIMARY KEY,
+    code_hash varchar(64) NOT NULL,
+    client_id varchar(64) NOT NULL REFERENCES oauth_clients (client_id),
+    user_id bigint NOT NULL REFERENCES users (id),
+    redirect_uri text NOT NULL,
+    scope varchar(255) NOT NULL,
+    nonce varchar(255),
+    code_challenge varchar(128) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX idx_oauth_authorization_codes_code_hash ON oauth_authorization_codes (code_hash);
+CREATE INDEX idx_oauth_authorization_codes_expires_at ON oauth_authorization_codes (expires_at);
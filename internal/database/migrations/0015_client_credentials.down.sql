@@ -0,0 +1,4 @@
+-- grant 구분이 없어지면 서비스 클라이언트가 인가 코드 클라이언트로 취급되므로 먼저 정리한다.
+DELETE FROM oauth_clients WHERE grant_types NOT LIKE '%authorization_code%';
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS scopes;
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS grant_types;
@@ -0,0 +1,2 @@
+ALTER TABLE oauth_clients ADD COLUMN grant_types varchar(100) NOT NULL DEFAULT 'authorization_code';
+ALTER TABLE oauth_clients ADD COLUMN scopes text NOT NULL DEFAULT '';
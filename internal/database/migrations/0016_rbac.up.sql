@@ -0,0 +1,44 @@
+CREATE TABLE roles (
+    id bigserial PRIMARY KEY,
+    name varchar(50) NOT NULL,
+    description varchar(255) NOT NULL DEFAULT '',
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX idx_roles_name ON roles (name);
+
+CREATE TABLE permissions (
+    id bigserial PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    description varchar(255) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX idx_permissions_name ON permissions (name);
+
+CREATE TABLE role_permissions (
+    role_id bigint NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    permission_id bigint NOT NULL REFERENCES permissions (id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE user_roles (
+    user_id bigint NOT NULL REFERENCES users (id),
+    role_id bigint NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    created_at timestamptz,
+    PRIMARY KEY (user_id, role_id)
+);
+CREATE INDEX idx_user_roles_role_id ON user_roles (role_id);
+
+-- 기본 권한과 역할. 첫 관리자는 "main roles grant -email <email> -role admin"으로 지정한다.
+INSERT INTO permissions (name, description) VALUES
+    ('users:read', '회원 정보 조회'),
+    ('roles:read', '역할과 사용자 역할 조회'),
+    ('roles:write', '사용자 역할 부여와 회수');
+
+INSERT INTO roles (name, description, created_at) VALUES
+    ('admin', '모든 관리 기능', now()),
+    ('support', '고객 지원(회원 정보와 역할 조회)', now());
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p WHERE r.name = 'admin';
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name IN ('users:read', 'roles:read') WHERE r.name = 'support';
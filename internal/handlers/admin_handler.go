@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// AdminHandler는 관리자용 회원 조회와 역할 관리 API다. 라우트마다 middleware.RequirePermission으로 권한을 확인한다.
+type AdminHandler struct {
+	rbacService *services.RBACService
+	userService *services.UserService
+}
+
+func NewAdminHandler(rbacService *services.RBACService, userService *services.UserService) *AdminHandler {
+	return &AdminHandler{
+		rbacService: rbacService,
+		userService: userService,
+	}
+}
+
+// ListRoles godoc
+// @Summary      역할 목록 조회
+// @Description  역할과 역할별 권한 목록. roles:read 권한 필요
+// @Tags         관리자
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200 {array} models.Role "역할 목록"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      403 {object} models.ErrorResponse "권한 없음 또는 서비스 토큰"
+// @Router       /admin/roles [get]
+func (h *AdminHandler) ListRoles(c *gin.Context) {
+	roles, err := h.rbacService.ListRoles()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// GetUser godoc
+// @Summary      회원 정보 조회
+// @Description  회원 ID로 회원 정보 조회. users:read 권한 필요
+// @Tags         관리자
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id path int true "회원 ID"
+// @Success      200 {object} models.User "회원 정보"
+// @Failure      400 {object} models.ErrorResponse "잘못된 회원 ID"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      403 {object} models.ErrorResponse "권한 없음 또는 서비스 토큰"
+// @Failure      404 {object} models.ErrorResponse "사용자 없음"
+// @Router       /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetProfile(userID)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// GetUserRoles godoc
+// @Summary      회원 역할 조회
+// @Description  회원에게 부여된 역할과 권한 목록. roles:read 권한 필요
+// @Tags         관리자
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id path int true "회원 ID"
+// @Success      200 {array} models.Role "부여된 역할 목록"
+// @Failure      400 {object} models.ErrorResponse "잘못된 회원 ID"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      403 {object} models.ErrorResponse "권한 없음 또는 서비스 토큰"
+// @Failure      404 {object} models.ErrorResponse "사용자 없음"
+// @Router       /admin/users/{id}/roles [get]
+func (h *AdminHandler) GetUserRoles(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	roles, err := h.rbacService.GetUserRoles(userID)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// AssignUserRole godoc
+// @Summary      회원 역할 부여
+// @Description  회원에게 역할을 부여하고 부여 후의 역할 목록을 반환. 이미 있는 역할이면 그대로 둠. 회원이 토큰을 갱신하거나 다시 로그인하면 반영됨. roles:write 권한 필요
+// @Tags         관리자
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id path int true "회원 ID"
+// @Param        request body models.AssignRoleRequest true "부여할 역할"
+// @Success      200 {array} models.Role "부여된 역할 목록"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      403 {object} models.ErrorResponse "권한 없음 또는 서비스 토큰"
+// @Failure      404 {object} models.ErrorResponse "사용자 또는 역할 없음"
+// @Router       /admin/users/{id}/roles [post]
+func (h *AdminHandler) AssignUserRole(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	roles, err := h.rbacService.AssignRole(userID, req.Role)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// RemoveUserRole godoc
+// @Summary      회원 역할 회수
+// @Description  회원의 역할을 회수하고 이미 발급된 액세스 토큰을 폐기. 회원은 리프레시 토큰으로 회수된 권한이 빠진 토큰을 다시 받음. roles:write 권한 필요
+// @Tags         관리자
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id path int true "회원 ID"
+// @Param        role path string true "회수할 역할 이름"
+// @Success      204 "회수 완료 (부여되지 않은 역할이어도 204)"
+// @Failure      400 {object} models.ErrorResponse "잘못된 회원 ID"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      403 {object} models.ErrorResponse "권한 없음 또는 서비스 토큰"
+// @Failure      404 {object} models.ErrorResponse "사용자 또는 역할 없음"
+// @Router       /admin/users/{id}/roles/{role} [delete]
+func (h *AdminHandler) RemoveUserRole(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.rbacService.RemoveRole(userID, c.Param("role")); err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// userIDParam은 경로의 회원 ID를 읽는다. 올바르지 않으면 400으로 응답하고 false를 반환한다.
+func userIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid user id",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondAdminError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, services.ErrUserNotFound) || errors.Is(err, services.ErrRoleNotFound) {
+		status = http.StatusNotFound
+	}
+	respondError(c, status, err)
+}
@@ -3,8 +3,11 @@ package handlers
 import (
 	"auth-go-service/internal/models"
 	"auth-go-service/internal/services"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"io"
 	"net/http"
+	"strconv"
 )
 
 type AuthHandler struct {
@@ -19,13 +22,16 @@ func NewAuthHandler(authService *services.AuthService) *AuthHandler {
 
 // Login godoc
 // @Summary      사용자 로그인
-// @Description  이메일과 비밀번호를 통해 사용자 로그인 처리
+// @Description  이메일과 비밀번호를 통해 사용자 로그인 처리. 2단계 인증을 사용하는 계정은 토큰 대신 models.MFAChallengeResponse를 반환하며, /auth/login/mfa로 인증을 완료해야 함. 새 버전이 시행된 필수 약관이 있으면 pendingTerms에 담기며, /users/me/terms로 다시 동의받아야 함
 // @Tags         인증
 // @Accept       json
 // @Produce      json
 // @Param        request body models.LoginRequest true "로그인 요청 정보"
 // @Success      200 {object} models.LoginResponse "로그인 성공"
 // @Failure      400 {object} models.ErrorResponse "잘못된 요청 또는 로그인 실패"
+// @Failure      403 {object} models.DormantAccountResponse "휴면 계정 (이메일로 받은 인증 코드로 /auth/reactivate-account 호출) 또는 탈퇴한 계정 (복구 기간 안이면 /auth/restore-account로 복구 가능, models.ErrorResponse)"
+// @Failure      423 {object} models.ErrorResponse "반복된 실패로 계정이 잠김 (비밀번호 재설정 필요)"
+// @Failure      429 {object} models.ErrorResponse "로그인 시도 횟수 초과 (Retry-After 헤더의 초만큼 대기)"
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
@@ -37,22 +43,85 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	failureCount := h.authService.GetLoginFailureCount(req.Email)
-	if failureCount >= 3 {
+	response, challenge, err := h.authService.Login(req.Email, req.Password, c.ClientIP())
+	if err != nil {
+		respondLoginError(c, err)
+		return
+	}
+
+	if challenge != nil {
+		c.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ReactivateAccount godoc
+// @Summary      휴면 계정 해제
+// @Description  휴면 계정으로 로그인할 때 받은 인증 ID와 이메일로 발송된 인증 코드로 휴면을 해제하고 로그인. 2단계 인증을 사용하는 계정은 models.MFAChallengeResponse를 반환함
+// @Tags         인증
+// @Accept       json
+// @Produce      json
+// @Param        request body models.ReactivateAccountRequest true "휴면 해제 인증 정보"
+// @Success      200 {object} models.LoginResponse "휴면 해제 및 로그인 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청 또는 인증 실패"
+// @Failure      423 {object} models.ErrorResponse "반복된 실패로 계정이 잠김 (비밀번호 재설정 필요)"
+// @Failure      429 {object} models.ErrorResponse "시도 횟수 초과 (Retry-After 헤더의 초만큼 대기)"
+// @Router       /auth/reactivate-account [post]
+func (h *AuthHandler) ReactivateAccount(c *gin.Context) {
+	var req models.ReactivateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Message: "Too many login attempts. Please try again later.",
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
 		})
 		return
 	}
 
-	response, err := h.authService.Login(req.Email, req.Password)
+	response, challenge, err := h.authService.ReactivateAccount(req.Email, req.VerificationCode, req.VerificationID, c.ClientIP())
 	if err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if challenge != nil {
+		c.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// VerifyMFALogin godoc
+// @Summary      2단계 인증 로그인
+// @Description  로그인 시 발급된 임시 토큰과 인증 앱 코드(또는 복구 코드)로 로그인 완료
+// @Tags         인증
+// @Accept       json
+// @Produce      json
+// @Param        request body models.MFALoginRequest true "2단계 인증 정보"
+// @Success      200 {object} models.LoginResponse "로그인 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청"
+// @Failure      401 {object} models.ErrorResponse "인증 코드 또는 임시 토큰 오류"
+// @Failure      423 {object} models.ErrorResponse "반복된 실패로 계정이 잠김 (비밀번호 재설정 필요)"
+// @Failure      429 {object} models.ErrorResponse "인증 시도 횟수 초과 (Retry-After 헤더의 초만큼 대기)"
+// @Router       /auth/login/mfa [post]
+func (h *AuthHandler) VerifyMFALogin(c *gin.Context) {
+	var req models.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Message: err.Error(),
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
 		})
 		return
 	}
 
+	response, err := h.authService.VerifyMFALogin(req.MFAToken, req.Code, c.ClientIP())
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
@@ -63,8 +132,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        request body models.RequestEmailVerificationRequest true "이메일 인증 요청 정보"
+// @Param        Accept-Language header string false "메일 언어 (예: ko, en-US;q=0.8)"
 // @Success      200 {object} models.RequestEmailVerificationResponse "인증 코드 발송 성공"
 // @Failure      400 {object} models.ErrorResponse "잘못된 요청"
+// @Failure      429 {object} models.ErrorResponse "재발송 대기 시간 (Retry-After 헤더의 초만큼 대기)"
 // @Router       /auth/request-email-verification [post]
 func (h *AuthHandler) RequestEmailVerification(c *gin.Context) {
 	var req models.RequestEmailVerificationRequest
@@ -76,11 +147,9 @@ func (h *AuthHandler) RequestEmailVerification(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.RequestEmailVerification(req.Email)
+	response, err := h.authService.RequestEmailVerification(req.Email, c.GetHeader("Accept-Language"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -89,7 +158,7 @@ func (h *AuthHandler) RequestEmailVerification(c *gin.Context) {
 
 // VerifyEmailAccount godoc
 // @Summary      이메일 계정 인증
-// @Description  발송된 인증 코드를 통해 이메일 계정 인증 처리
+// @Description  발송된 인증 코드를 통해 이메일 계정 인증 처리 (인증 요청마다 시도 횟수가 제한되며, 초과하면 코드를 다시 요청해야 함)
 // @Tags         인증
 // @Accept       json
 // @Produce      json
@@ -122,11 +191,12 @@ func (h *AuthHandler) VerifyEmailAccount(c *gin.Context) {
 
 // SignUp godoc
 // @Summary      사용자 회원가입
-// @Description  새로운 사용자 계정 생성
+// @Description  새로운 사용자 계정 생성. GET /terms의 필수 약관(현재 버전)에 모두 동의해야 함
 // @Tags         인증
 // @Accept       json
 // @Produce      json
 // @Param        request body models.SignUpRequest true "회원가입 요청 정보"
+// @Param        Accept-Language header string false "locale이 없을 때 사용할 메일 언어 (예: ko, en-US;q=0.8)"
 // @Success      200 {object} object{message=string} "회원가입 성공"
 // @Failure      400 {object} models.ErrorResponse "잘못된 요청 또는 회원가입 실패"
 // @Router       /auth/sign-up [post]
@@ -140,7 +210,7 @@ func (h *AuthHandler) SignUp(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.SignUp(&req)
+	response, err := h.authService.SignUp(&req, c.GetHeader("Accept-Language"), c.ClientIP())
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Message: err.Error(),
@@ -189,11 +259,12 @@ func (h *AuthHandler) FindMyEmail(c *gin.Context) {
 
 // RequestPasswordReset godoc
 // @Summary      비밀번호 재설정 요청
-// @Description  이메일로 비밀번호 재설정 링크 발송
+// @Description  이메일로 비밀번호 재설정 링크 발송 (이전에 발송한 링크는 무효화됨)
 // @Tags         인증
 // @Accept       json
 // @Produce      json
 // @Param        request body models.RequestPasswordResetRequest true "비밀번호 재설정 요청 정보"
+// @Param        Accept-Language header string false "사용자에 저장된 언어가 없을 때 사용할 메일 언어"
 // @Success      200 {object} object{message=string} "재설정 이메일 발송 성공"
 // @Failure      400 {object} models.ErrorResponse "잘못된 요청"
 // @Router       /auth/reset-password [post]
@@ -207,7 +278,7 @@ func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
 		return
 	}
 
-	err := h.authService.RequestPasswordReset(req.Email)
+	err := h.authService.RequestPasswordReset(req.Email, req.Tenant, c.GetHeader("Accept-Language"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Message: err.Error(),
@@ -222,7 +293,7 @@ func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
 
 // ResetPassword godoc
 // @Summary      비밀번호 재설정
-// @Description  재설정 토큰을 통해 새로운 비밀번호로 변경
+// @Description  재설정 토큰을 통해 새로운 비밀번호로 변경 (토큰은 한 번만 사용할 수 있으며, 성공하면 모든 기기에서 로그아웃됨)
 // @Tags         인증
 // @Accept       json
 // @Produce      json
@@ -253,17 +324,141 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	})
 }
 
+// RestoreAccount godoc
+// @Summary      탈퇴 계정 복구
+// @Description  복구 기간 안의 탈퇴 계정을 이메일과 비밀번호로 복구. 비밀번호 실패는 로그인과 같은 잠금 정책으로 집계되며, 복구 후 다시 로그인해야 함
+// @Tags         인증
+// @Accept       json
+// @Produce      json
+// @Param        request body models.RestoreAccountRequest true "탈퇴한 계정의 이메일과 비밀번호"
+// @Success      200 {object} object{message=string} "복구 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청, 복구할 수 없는 계정 또는 비밀번호 오류"
+// @Failure      423 {object} models.ErrorResponse "반복된 실패로 계정이 잠김 (비밀번호 재설정 필요)"
+// @Failure      429 {object} models.ErrorResponse "시도 횟수 초과 (Retry-After 헤더의 초만큼 대기)"
+// @Router       /auth/restore-account [post]
+func (h *AuthHandler) RestoreAccount(c *gin.Context) {
+	var req models.RestoreAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := h.authService.RestoreAccount(req.Email, req.Password, c.ClientIP()); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Account restored",
+	})
+}
+
+// RefreshToken godoc
+// @Summary      액세스 토큰 재발급
+// @Description  리프레시 토큰으로 새 액세스 토큰과 리프레시 토큰을 발급 (사용한 리프레시 토큰은 폐기되며, 재사용 시 해당 세션 전체가 폐기됨)
+// @Tags         인증
+// @Accept       json
+// @Produce      json
+// @Param        request body models.RefreshTokenRequest true "리프레시 토큰"
+// @Success      200 {object} models.LoginResponse "토큰 재발급 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청"
+// @Failure      401 {object} models.ErrorResponse "유효하지 않거나 재사용된 리프레시 토큰"
+// @Router       /auth/token/refresh [post]
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	response, err := h.authService.RefreshToken(req.RefreshToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // Logout godoc
 // @Summary      사용자 로그아웃
-// @Description  인증된 사용자 로그아웃 처리
+// @Description  현재 액세스 토큰과 세션의 리프레시 토큰을 폐기 (allDevices=true이면 모든 기기에서 로그아웃)
 // @Tags         인증
 // @Accept       json
 // @Produce      json
 // @Security     ApiKeyAuth
+// @Param        request body models.LogoutRequest false "로그아웃 옵션"
 // @Success      200 {object} object{message=string} "로그아웃 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
 // @Router       /auth/logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
+	var req models.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	claims := c.MustGet("claims").(*services.JWTClaims)
+	if err := h.authService.Logout(claims, req.AllDevices); err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to log out. Please try again.",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Logged out successfully",
 	})
 }
+
+// retryAfterError는 잠시 후 다시 시도해야 하는 에러(로그인 잠금, 재발송 대기 등)다.
+type retryAfterError interface {
+	error
+	RetryAfterSeconds() int
+}
+
+// respondLoginError는 휴면 계정이면 휴면 해제에 필요한 인증 ID를 함께 응답하고, 그 밖에는 respondError로 응답한다.
+func respondLoginError(c *gin.Context, err error) {
+	var dormantErr *services.DormantAccountError
+	if errors.As(err, &dormantErr) {
+		c.JSON(http.StatusForbidden, models.DormantAccountResponse{
+			Message:        err.Error(),
+			Dormant:        true,
+			VerificationID: dormantErr.VerificationID,
+		})
+		return
+	}
+	respondError(c, http.StatusBadRequest, err)
+}
+
+// respondError는 영구 로그인 잠금이면 423, 잠시 후 다시 시도해야 하는 에러면 429와 Retry-After 헤더로,
+// 그 밖의 에러는 status로 응답한다.
+func respondError(c *gin.Context, status int, err error) {
+	var lockedErr *services.LoginLockedError
+	var retryErr retryAfterError
+	var deletedErr *services.AccountDeletedError
+	if errors.As(err, &lockedErr) && lockedErr.Permanent {
+		status = http.StatusLocked
+	} else if errors.As(err, &deletedErr) {
+		status = http.StatusForbidden
+	} else if errors.As(err, &retryErr) {
+		status = http.StatusTooManyRequests
+		c.Header("Retry-After", strconv.Itoa(retryErr.RetryAfterSeconds()))
+	}
+
+	c.JSON(status, models.ErrorResponse{
+		Message: err.Error(),
+	})
+}
@@ -3,12 +3,19 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/mailer"
+	"auth-go-service/internal/repository"
+	"auth-go-service/internal/services"
+
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHealthCheck(t *testing.T) {
@@ -131,4 +138,53 @@ func TestLoginValidation(t *testing.T) {
 			assert.Equal(t, tt.expected, rr.Code)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestLoginIPLockoutIgnoresSpoofedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		JWTSecretKey:          "test-secret",
+		JWTExpiresIn:          60 * 15,
+		RefreshTokenExpiresIn: 60 * 60,
+		LockoutThreshold:      100,
+		LockoutWindow:         60 * 15,
+		LockoutDuration:       60,
+		LockoutMaxDuration:    60 * 60,
+		IPLockoutThreshold:    3,
+		IPLockoutWindow:       60 * 15,
+		IPLockoutDuration:     60 * 15,
+		TrustedProxies:        []string{"10.0.0.0/8"},
+	}
+	repos := repository.NewMemoryRepositories()
+	renderer, err := mailer.NewRenderer(mailer.Brand{ProductName: "Momentir", SupportEmail: "support@example.com"}, "ko")
+	require.NoError(t, err)
+	authService := services.NewAuthService(cfg, repos, services.NewEmailService(mailer.NewMemoryMailer(), renderer),
+		services.NewTokenService(cfg, repos, services.NewHMACKeySet(cfg.JWTSecretKey)), services.NewMFAService(repos, "Momentir"), services.NewLockoutService(cfg, repos))
+
+	// cmd/main.go와 같이 설정한 프록시의 X-Forwarded-For만 믿는다.
+	router := gin.New()
+	require.NoError(t, router.SetTrustedProxies(cfg.TrustedProxies))
+	router.POST("/v1/auth/login", NewAuthHandler(authService).Login)
+	login := func(remoteAddr, forwardedFor string, attempt int) int {
+		body, _ := json.Marshal(map[string]string{"email": fmt.Sprintf("user%d@example.com", attempt), "password": "wrong-password"})
+		req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	// 직접 접속한 클라이언트가 헤더를 바꿔도 같은 IP로 잠긴다.
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusBadRequest, login("203.0.113.5:40000", fmt.Sprintf("198.51.100.%d", i), i))
+	}
+	// 기준 횟수에 도달한 실패부터 잠금으로 응답한다.
+	assert.Equal(t, http.StatusTooManyRequests, login("203.0.113.5:40000", "198.51.100.2", 2))
+	assert.Equal(t, http.StatusTooManyRequests, login("203.0.113.5:40000", "198.51.100.99", 99))
+
+	// 로드 밸런서를 거친 요청은 헤더의 클라이언트 주소로 구분한다.
+	assert.Equal(t, http.StatusBadRequest, login("10.0.0.2:40000", "192.0.2.10", 100))
+	assert.Equal(t, http.StatusTooManyRequests, login("10.0.0.2:40000", "203.0.113.5", 101))
+}
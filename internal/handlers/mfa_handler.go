@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type MFAHandler struct {
+	mfaService *services.MFAService
+}
+
+func NewMFAHandler(mfaService *services.MFAService) *MFAHandler {
+	return &MFAHandler{
+		mfaService: mfaService,
+	}
+}
+
+// Enroll godoc
+// @Summary      2단계 인증 등록 시작
+// @Description  인증 앱(Google Authenticator 등)에 등록할 TOTP 비밀키와 otpauth URI 발급. 확인 전까지는 로그인에 적용되지 않음
+// @Tags         2단계 인증
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200 {object} models.MFAEnrollResponse "비밀키 발급 성공"
+// @Failure      400 {object} models.ErrorResponse "이미 2단계 인증 사용 중"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Router       /auth/mfa/enroll [post]
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	response, err := h.mfaService.Enroll(c.GetUint("userID"), c.GetString("email"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Confirm godoc
+// @Summary      2단계 인증 등록 확인
+// @Description  인증 앱의 코드로 등록을 완료하고 1회용 복구 코드 발급 (복구 코드는 이 응답에서만 확인 가능)
+// @Tags         2단계 인증
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body models.MFAConfirmRequest true "인증 코드"
+// @Success      200 {object} models.MFARecoveryCodesResponse "등록 완료"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청 또는 인증 코드 오류"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Router       /auth/mfa/confirm [post]
+func (h *MFAHandler) Confirm(c *gin.Context) {
+	var req models.MFAConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	response, err := h.mfaService.Confirm(c.GetUint("userID"), req.Code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Disable godoc
+// @Summary      2단계 인증 해제
+// @Description  현재 비밀번호와 인증 코드(또는 복구 코드)로 본인 확인 후 2단계 인증 해제
+// @Tags         2단계 인증
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body models.MFADisableRequest true "본인 확인 정보"
+// @Success      200 {object} object{message=string} "해제 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청 또는 본인 확인 실패"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Router       /auth/mfa/disable [post]
+func (h *MFAHandler) Disable(c *gin.Context) {
+	var req models.MFADisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := h.mfaService.Disable(c.GetUint("userID"), req.Password, req.Code); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Two-factor authentication disabled",
+	})
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/services"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"strings"
+)
+
+type OAuthHandler struct {
+	oauthService *services.OAuthService
+}
+
+func NewOAuthHandler(oauthService *services.OAuthService) *OAuthHandler {
+	return &OAuthHandler{
+		oauthService: oauthService,
+	}
+}
+
+// OpenIDConfiguration은 OpenID Connect discovery 문서(/.well-known/openid-configuration)를 반환한다.
+// /v1 경로 밖에 있어 Swagger 문서에는 포함되지 않는다.
+func (h *OAuthHandler) OpenIDConfiguration(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.oauthService.Discovery())
+}
+
+// AuthorizeRedirect godoc
+// @Summary      OpenID Connect 인가 요청
+// @Description  클라이언트 앱이 사용자 브라우저를 보내는 인가 엔드포인트 (인가 코드 + PKCE S256). 요청을 확인한 뒤 같은 쿼리로 프론트엔드 로그인 화면(OIDC_LOGIN_URL)으로 보냄. client_id나 redirect_uri가 잘못되면 400, 그 밖의 오류는 redirect_uri로 error를 돌려줌
+// @Tags         OpenID Connect
+// @Produce      json
+// @Param        response_type query string true "code"
+// @Param        client_id query string true "클라이언트 ID"
+// @Param        redirect_uri query string true "등록된 redirect URI"
+// @Param        scope query string true "openid 필수 (profile, email, phone)"
+// @Param        state query string false "redirect 시 그대로 돌려주는 값"
+// @Param        nonce query string false "ID 토큰에 담을 값"
+// @Param        code_challenge query string true "PKCE code_challenge"
+// @Param        code_challenge_method query string true "S256"
+// @Success      302 "로그인 화면 또는 redirect_uri(오류)로 이동"
+// @Failure      400 {object} models.OAuthErrorResponse "등록되지 않은 client_id 또는 redirect_uri"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /oauth/authorize [get]
+func (h *OAuthHandler) AuthorizeRedirect(c *gin.Context) {
+	var req models.AuthorizeRequest
+	bindErr := c.ShouldBindQuery(&req)
+
+	if _, err := h.oauthService.CheckClient(req.ClientID, req.RedirectURI); err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	if bindErr != nil {
+		h.redirectError(c, &req, &services.OAuthError{Code: "invalid_request", Description: bindErr.Error()})
+		return
+	}
+	if err := h.oauthService.CheckAuthorization(&req); err != nil {
+		var oauthErr *services.OAuthError
+		if errors.As(err, &oauthErr) {
+			h.redirectError(c, &req, oauthErr)
+			return
+		}
+		respondOAuthError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, h.oauthService.LoginRedirectURL(c.Request.URL.Query()))
+}
+
+// Authorize godoc
+// @Summary      OpenID Connect 인가 코드 발급
+// @Description  로그인한 프론트엔드가 인가 요청 값을 그대로 보내 인가 코드를 발급받음. 응답의 redirectUrl로 브라우저를 보내면 클라이언트 앱이 코드를 받음
+// @Tags         OpenID Connect
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body models.AuthorizeRequest true "인가 요청 값"
+// @Success      200 {object} models.AuthorizeResponse "인가 코드를 담은 redirect 주소"
+// @Failure      400 {object} models.OAuthErrorResponse "잘못된 인가 요청"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /oauth/authorize [post]
+func (h *OAuthHandler) Authorize(c *gin.Context) {
+	var req models.AuthorizeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondOAuthBindError(c, err)
+		return
+	}
+
+	redirectURL, err := h.oauthService.Authorize(c.GetUint("userID"), &req)
+	if err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AuthorizeResponse{RedirectURL: redirectURL})
+}
+
+// Token godoc
+// @Summary      OpenID Connect 토큰 발급
+// @Description  authorization_code: 인가 코드와 PKCE code_verifier로 액세스 토큰과 ID 토큰 발급. client_credentials: 서비스 클라이언트에 사용자 없는 서비스 토큰(sub_type=service) 발급. 기밀 클라이언트는 HTTP Basic 또는 client_id/client_secret 폼 값으로 인증. 리프레시 토큰은 발급하지 않음
+// @Tags         OpenID Connect
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        grant_type formData string true "authorization_code 또는 client_credentials"
+// @Param        code formData string false "인가 코드 (authorization_code)"
+// @Param        redirect_uri formData string false "인가 요청의 redirect_uri (authorization_code)"
+// @Param        code_verifier formData string false "PKCE code_verifier (authorization_code)"
+// @Param        scope formData string false "요청할 scope (client_credentials, 생략하면 허용된 scope 전체)"
+// @Param        client_id formData string false "클라이언트 ID (HTTP Basic을 쓰지 않을 때)"
+// @Param        client_secret formData string false "클라이언트 시크릿 (HTTP Basic을 쓰지 않을 때)"
+// @Success      200 {object} models.OAuthTokenResponse "토큰 발급 성공"
+// @Failure      400 {object} models.OAuthErrorResponse "잘못된 요청, 인가 코드 또는 scope"
+// @Failure      401 {object} models.OAuthErrorResponse "클라이언트 인증 실패"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /oauth/token [post]
+func (h *OAuthHandler) Token(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	var req models.OAuthTokenRequest
+	if err := c.ShouldBind(&req); err != nil {
+		respondOAuthBindError(c, err)
+		return
+	}
+
+	basicID, basicSecret, _ := c.Request.BasicAuth()
+	response, err := h.oauthService.Token(&req, basicID, basicSecret)
+	if err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UserInfo godoc
+// @Summary      OpenID Connect 사용자 정보
+// @Description  토큰 엔드포인트에서 받은 액세스 토큰의 scope(profile, email, phone)에 해당하는 사용자 정보
+// @Tags         OpenID Connect
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200 {object} models.UserInfo "사용자 정보"
+// @Failure      401 {object} models.OAuthErrorResponse "잘못되었거나 만료된 액세스 토큰"
+// @Failure      403 {object} models.OAuthErrorResponse "openid scope가 없는 토큰"
+// @Router       /oauth/userinfo [get]
+func (h *OAuthHandler) UserInfo(c *gin.Context) {
+	tokenString, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok {
+		c.Header("WWW-Authenticate", `Bearer realm="userinfo"`)
+		c.JSON(http.StatusUnauthorized, models.OAuthErrorResponse{
+			Error:            "invalid_token",
+			ErrorDescription: "Bearer token is required",
+		})
+		return
+	}
+
+	info, err := h.oauthService.UserInfo(tokenString)
+	if err != nil {
+		var oauthErr *services.OAuthError
+		if errors.As(err, &oauthErr) {
+			c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="%s"`, oauthErr.Code))
+		}
+		respondOAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
+// Introspect godoc
+// @Summary      토큰 확인 (RFC 7662)
+// @Description  액세스 토큰 또는 리프레시 토큰이 지금 사용할 수 있는지와 클레임을 반환. 토큰을 직접 검증할 수 없는 서비스용이며 기밀 클라이언트 인증(HTTP Basic 또는 client_id/client_secret 폼 값)이 필요함. 사용할 수 없는 토큰은 active=false
+// @Tags         OpenID Connect
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        token formData string true "확인할 토큰"
+// @Param        token_type_hint formData string false "access_token 또는 refresh_token"
+// @Param        client_id formData string false "클라이언트 ID (HTTP Basic을 쓰지 않을 때)"
+// @Param        client_secret formData string false "클라이언트 시크릿 (HTTP Basic을 쓰지 않을 때)"
+// @Success      200 {object} models.IntrospectionResponse "토큰 상태"
+// @Failure      400 {object} models.OAuthErrorResponse "잘못된 요청"
+// @Failure      401 {object} models.OAuthErrorResponse "클라이언트 인증 실패"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /oauth/introspect [post]
+func (h *OAuthHandler) Introspect(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+
+	var req models.IntrospectionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		respondOAuthBindError(c, err)
+		return
+	}
+
+	basicID, basicSecret, _ := c.Request.BasicAuth()
+	response, err := h.oauthService.Introspect(&req, basicID, basicSecret)
+	if err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Revoke godoc
+// @Summary      토큰 폐기 (RFC 7009)
+// @Description  액세스 토큰 또는 리프레시 토큰 폐기. 리프레시 토큰은 해당 로그인 세션 전체를 폐기함. 기밀 클라이언트 인증이 필요하며, 다른 클라이언트에 발급된 토큰은 폐기하지 않고 이미 사용할 수 없는 토큰과 마찬가지로 200으로 응답함
+// @Tags         OpenID Connect
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        token formData string true "폐기할 토큰"
+// @Param        token_type_hint formData string false "access_token 또는 refresh_token"
+// @Param        client_id formData string false "클라이언트 ID (HTTP Basic을 쓰지 않을 때)"
+// @Param        client_secret formData string false "클라이언트 시크릿 (HTTP Basic을 쓰지 않을 때)"
+// @Success      200 "폐기 완료"
+// @Failure      400 {object} models.OAuthErrorResponse "잘못된 요청"
+// @Failure      401 {object} models.OAuthErrorResponse "클라이언트 인증 실패"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /oauth/revoke [post]
+func (h *OAuthHandler) Revoke(c *gin.Context) {
+	var req models.RevocationRequest
+	if err := c.ShouldBind(&req); err != nil {
+		respondOAuthBindError(c, err)
+		return
+	}
+
+	basicID, basicSecret, _ := c.Request.BasicAuth()
+	if err := h.oauthService.Revoke(&req, basicID, basicSecret); err != nil {
+		respondOAuthError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *OAuthHandler) redirectError(c *gin.Context, req *models.AuthorizeRequest, err *services.OAuthError) {
+	c.Redirect(http.StatusFound, h.oauthService.ErrorRedirectURL(req, err))
+}
+
+// respondOAuthError는 OAuth 2.0 형식으로 오류를 응답한다.
+func respondOAuthError(c *gin.Context, err error) {
+	var oauthErr *services.OAuthError
+	if errors.As(err, &oauthErr) {
+		c.JSON(oauthErr.Status, models.OAuthErrorResponse{
+			Error:            oauthErr.Code,
+			ErrorDescription: oauthErr.Description,
+		})
+		return
+	}
+	// 내부 에러 내용(DB 등)은 클라이언트에 노출하지 않고 서버 로그에만 남긴다.
+	log.Printf("oauth %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	c.JSON(http.StatusInternalServerError, models.OAuthErrorResponse{
+		Error:            "server_error",
+		ErrorDescription: "internal server error",
+	})
+}
+
+// respondOAuthBindError는 요청 값 검증 실패를 정해진 설명으로 응답하고, 자세한 내용은 서버 로그에만 남긴다.
+func respondOAuthBindError(c *gin.Context, err error) {
+	log.Printf("oauth %s %s: invalid request: %v", c.Request.Method, c.Request.URL.Path, err)
+	c.JSON(http.StatusBadRequest, models.OAuthErrorResponse{
+		Error:            "invalid_request",
+		ErrorDescription: "Invalid request format",
+	})
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"auth-go-service/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthErrorsDoNotExposeInternalDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/oauth/token", func(c *gin.Context) {
+		respondOAuthError(c, errors.New(`pq: relation "oauth_clients" does not exist`))
+	})
+	router.POST("/oauth/revoke", (&OAuthHandler{}).Revoke)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/oauth/token", nil))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	var response models.OAuthErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "server_error", response.Error)
+	assert.Equal(t, "internal server error", response.ErrorDescription)
+
+	// 요청 값 검증 실패도 필드 이름 같은 내부 구조를 알리지 않는다.
+	req := httptest.NewRequest(http.MethodPost, "/oauth/revoke", strings.NewReader("token_type_hint=access_token"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "invalid_request", response.Error)
+	assert.Equal(t, "Invalid request format", response.ErrorDescription)
+}
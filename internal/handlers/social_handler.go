@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type SocialHandler struct {
+	socialLoginService *services.SocialLoginService
+}
+
+func NewSocialHandler(socialLoginService *services.SocialLoginService) *SocialHandler {
+	return &SocialHandler{
+		socialLoginService: socialLoginService,
+	}
+}
+
+// Authorize godoc
+// @Summary      소셜 로그인 시작
+// @Description  제공자(kakao, naver, google) 로그인 화면 주소와 state 발급 (인가 코드 + PKCE). 사용자를 authorizationUrl로 보내고, redirect URL로 돌아온 code와 state를 /callback으로 보내야 함
+// @Tags         소셜 로그인
+// @Produce      json
+// @Param        provider path string true "제공자 (kakao, naver, google)"
+// @Success      200 {object} models.SocialAuthorizeResponse "로그인 화면 주소"
+// @Failure      404 {object} models.ErrorResponse "지원하지 않는(설정되지 않은) 제공자"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /auth/social/{provider}/authorize [post]
+func (h *SocialHandler) Authorize(c *gin.Context) {
+	response, err := h.socialLoginService.Authorize(c.Param("provider"))
+	if err != nil {
+		respondSocialError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Callback godoc
+// @Summary      소셜 로그인 완료
+// @Description  제공자가 돌려준 인가 코드로 로그인. 연결된 계정이 없으면 제공자가 확인한 이메일로 기존 계정과 연결하며, 기존 계정도 없으면 models.SocialSignUpRequiredResponse(가입 전용 토큰)를 반환함. 2단계 인증을 사용하는 계정은 models.MFAChallengeResponse를 반환함
+// @Tags         소셜 로그인
+// @Accept       json
+// @Produce      json
+// @Param        provider path string true "제공자 (kakao, naver, google)"
+// @Param        request body models.SocialCallbackRequest true "인가 코드와 state"
+// @Success      200 {object} models.LoginResponse "로그인 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청, 만료된 state 또는 제공자 오류"
+// @Failure      403 {object} models.DormantAccountResponse "휴면 계정 (이메일로 받은 인증 코드로 /auth/reactivate-account 호출)"
+// @Failure      404 {object} models.ErrorResponse "지원하지 않는(설정되지 않은) 제공자"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /auth/social/{provider}/callback [post]
+func (h *SocialHandler) Callback(c *gin.Context) {
+	var req models.SocialCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	response, challenge, signUp, err := h.socialLoginService.Callback(c.Request.Context(), c.Param("provider"), req.Code, req.State)
+	if err != nil {
+		respondSocialError(c, err)
+		return
+	}
+
+	switch {
+	case challenge != nil:
+		c.JSON(http.StatusOK, challenge)
+	case signUp != nil:
+		c.JSON(http.StatusOK, signUp)
+	default:
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// SignUp godoc
+// @Summary      소셜 계정으로 회원가입
+// @Description  소셜 로그인 완료 시 받은 가입 전용 토큰으로 계정을 만들고 소셜 계정을 연결. 이메일 인증은 제공자가 확인한 이메일로 대신하며, GET /terms의 필수 약관(현재 버전)에 모두 동의해야 함
+// @Tags         소셜 로그인
+// @Accept       json
+// @Produce      json
+// @Param        request body models.SocialSignUpRequest true "회원가입 요청 정보"
+// @Param        Accept-Language header string false "locale이 없을 때 사용할 메일 언어 (예: ko, en-US;q=0.8)"
+// @Success      200 {object} models.LoginResponse "가입 및 로그인 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청 또는 회원가입 실패"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /auth/social/sign-up [post]
+func (h *SocialHandler) SignUp(c *gin.Context) {
+	var req models.SocialSignUpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	response, err := h.socialLoginService.SignUp(&req, c.GetHeader("Accept-Language"), c.ClientIP())
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// respondSocialError는 설정되지 않은 제공자를 404로, 나머지는 로그인 오류와 같이 응답한다.
+func respondSocialError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrUnknownProvider) {
+		respondError(c, http.StatusNotFound, err)
+		return
+	}
+	respondLoginError(c, err)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type TermsHandler struct {
+	termsService *services.TermsService
+}
+
+func NewTermsHandler(termsService *services.TermsService) *TermsHandler {
+	return &TermsHandler{
+		termsService: termsService,
+	}
+}
+
+// ListTerms godoc
+// @Summary      약관 목록 조회
+// @Description  현재 시행 중인 약관(문서 종류별 최신 버전) 목록. 회원가입 시 required인 약관은 모두 동의해야 함
+// @Tags         약관
+// @Produce      json
+// @Success      200 {array} models.Terms "약관 목록"
+// @Router       /terms [get]
+func (h *TermsHandler) ListTerms(c *gin.Context) {
+	terms, err := h.termsService.ListCurrent()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, terms)
+}
+
+// GetMyTerms godoc
+// @Summary      약관 동의 현황 조회
+// @Description  현재 약관별로 마지막으로 동의한 버전과 시간, 다시 동의해야 하는지(pending) 여부 조회
+// @Tags         약관
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200 {array} models.TermsStatus "약관 동의 현황"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Router       /users/me/terms [get]
+func (h *TermsHandler) GetMyTerms(c *gin.Context) {
+	statuses, err := h.termsService.GetStatus(c.GetUint("userID"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
+
+// AcceptTerms godoc
+// @Summary      약관 동의
+// @Description  현재 시행 중인 약관 버전에 동의. 동의 시간과 IP가 기록됨. 현재 버전이 아니면 400
+// @Tags         약관
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body models.AcceptTermsRequest true "동의할 약관"
+// @Success      200 {array} models.TermsStatus "갱신된 약관 동의 현황"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Router       /users/me/terms [post]
+func (h *TermsHandler) AcceptTerms(c *gin.Context) {
+	var req models.AcceptTermsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	statuses, err := h.termsService.Accept(c.GetUint("userID"), req.Terms, c.ClientIP())
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type UserHandler struct {
+	userService *services.UserService
+}
+
+func NewUserHandler(userService *services.UserService) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+	}
+}
+
+// GetMe godoc
+// @Summary      내 회원 정보 조회
+// @Description  액세스 토큰의 사용자 정보 조회. 수정할 때는 응답의 updatedAt을 함께 보내야 함
+// @Tags         회원
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200 {object} models.User "회원 정보"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      404 {object} models.ErrorResponse "사용자 없음"
+// @Router       /users/me [get]
+func (h *UserHandler) GetMe(c *gin.Context) {
+	user, err := h.userService.GetProfile(c.GetUint("userID"))
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateMe godoc
+// @Summary      내 회원 정보 수정
+// @Description  이름, 전화번호, 마케팅 수신 동의 중 보낸 항목만 수정. 마케팅 수신 동의는 모든 채널에 적용되며 동의 이력에 남음. updatedAt이 현재 값과 다르면(다른 곳에서 먼저 수정했으면) 409
+// @Tags         회원
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body models.UpdateProfileRequest true "수정할 회원 정보"
+// @Success      200 {object} models.User "수정된 회원 정보"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      404 {object} models.ErrorResponse "사용자 없음"
+// @Failure      409 {object} models.ErrorResponse "다른 요청에서 먼저 수정됨"
+// @Router       /users/me [patch]
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	var req models.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(c.GetUint("userID"), &req, c.ClientIP())
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// GetMarketingConsents godoc
+// @Summary      마케팅 수신 동의 조회
+// @Description  채널별(email, sms, push) 마케팅 수신 동의 여부와 동의/철회 이력 조회
+// @Tags         회원
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200 {object} models.MarketingConsentResponse "수신 동의 현황"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      404 {object} models.ErrorResponse "사용자 없음"
+// @Router       /users/me/marketing-consents [get]
+func (h *UserHandler) GetMarketingConsents(c *gin.Context) {
+	response, err := h.userService.GetMarketingConsents(c.GetUint("userID"))
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateMarketingConsents godoc
+// @Summary      마케팅 수신 동의 변경
+// @Description  보낸 채널의 마케팅 수신 동의 여부를 변경. 변경 사항은 시간, IP, 약관 버전과 함께 이력에 남음
+// @Tags         회원
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body models.UpdateMarketingConsentRequest true "채널별 수신 동의 여부"
+// @Success      200 {object} models.MarketingConsentResponse "변경된 수신 동의 현황"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      404 {object} models.ErrorResponse "사용자 없음"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /users/me/marketing-consents [put]
+func (h *UserHandler) UpdateMarketingConsents(c *gin.Context) {
+	var req models.UpdateMarketingConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	response, err := h.userService.UpdateMarketingConsents(c.GetUint("userID"), &req, c.ClientIP())
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ChangePassword godoc
+// @Summary      비밀번호 변경
+// @Description  현재 비밀번호 확인 후 비밀번호 변경. 이 요청에 사용한 세션을 제외한 모든 기기에서 로그아웃되며 변경 알림 메일이 발송됨
+// @Tags         회원
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body models.ChangePasswordRequest true "현재 비밀번호와 새 비밀번호"
+// @Param        Accept-Language header string false "알림 메일 언어 (사용자 설정이 없을 때 사용)"
+// @Success      200 {object} object{message=string} "변경 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청, 현재 비밀번호 오류 또는 비밀번호 정책 위반"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      404 {object} models.ErrorResponse "사용자 없음"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /users/me/password [put]
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	var req models.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	claims := c.MustGet("claims").(*services.JWTClaims)
+	err := h.userService.ChangePassword(claims.UserID, claims.SessionID, req.CurrentPassword, req.NewPassword, c.ClientIP(), c.GetHeader("Accept-Language"))
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Password changed successfully",
+	})
+}
+
+// DeleteMe godoc
+// @Summary      회원 탈퇴
+// @Description  현재 비밀번호 확인 후 탈퇴 처리. 모든 기기에서 로그아웃되며, restoreUntil까지는 /auth/restore-account로 복구할 수 있고 이후 개인정보가 삭제됨
+// @Tags         회원
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body models.DeleteAccountRequest true "현재 비밀번호"
+// @Param        Accept-Language header string false "알림 메일 언어 (사용자 설정이 없을 때 사용)"
+// @Success      200 {object} models.DeleteAccountResponse "탈퇴 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청 또는 비밀번호 오류"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      404 {object} models.ErrorResponse "사용자 없음"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /users/me [delete]
+func (h *UserHandler) DeleteMe(c *gin.Context) {
+	var req models.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	claims := c.MustGet("claims").(*services.JWTClaims)
+	restoreUntil, err := h.userService.DeleteAccount(claims.UserID, req.Password, c.GetHeader("Accept-Language"))
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.DeleteAccountResponse{
+		Message:      "Account deleted",
+		RestoreUntil: restoreUntil,
+	})
+}
+
+// RequestEmailChange godoc
+// @Summary      이메일 변경 요청
+// @Description  현재 비밀번호 확인 후 새 이메일 주소로 인증 코드 발송. 인증을 마치기 전까지 이메일은 바뀌지 않음
+// @Tags         회원
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body models.RequestEmailChangeRequest true "새 이메일 주소와 현재 비밀번호"
+// @Param        Accept-Language header string false "메일 언어 (사용자 설정이 없을 때 사용)"
+// @Success      200 {object} models.RequestEmailVerificationResponse "인증 코드 발송 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청 또는 현재 비밀번호 오류"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      409 {object} models.ErrorResponse "이미 사용 중인 이메일"
+// @Failure      429 {object} models.ErrorResponse "재발송 대기 시간 또는 요청 횟수 초과 (Retry-After 헤더의 초만큼 대기)"
+// @Router       /users/me/email [post]
+func (h *UserHandler) RequestEmailChange(c *gin.Context) {
+	var req models.RequestEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	response, err := h.userService.RequestEmailChange(c.GetUint("userID"), req.Password, req.NewEmail, c.GetHeader("Accept-Language"))
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ConfirmEmailChange godoc
+// @Summary      이메일 변경 확인
+// @Description  새 이메일로 받은 인증 코드로 이메일 변경 완료. 기존 토큰은 모두 폐기되고 새 토큰이 발급되며, 이전 주소로 되돌리기 링크가 발송됨
+// @Tags         회원
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request body models.ConfirmEmailChangeRequest true "인증 정보"
+// @Param        Accept-Language header string false "알림 메일 언어 (사용자 설정이 없을 때 사용)"
+// @Success      200 {object} models.LoginResponse "변경 성공 (새 토큰)"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청 또는 인증 코드 오류"
+// @Failure      401 {object} models.ErrorResponse "인증 실패"
+// @Failure      409 {object} models.ErrorResponse "이미 사용 중인 이메일"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /users/me/email/verify [post]
+func (h *UserHandler) ConfirmEmailChange(c *gin.Context) {
+	var req models.ConfirmEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	response, err := h.userService.ConfirmEmailChange(c.GetUint("userID"), req.NewEmail, req.VerificationCode, req.VerificationID, c.GetHeader("Accept-Language"))
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UndoEmailChange godoc
+// @Summary      이메일 변경 되돌리기
+// @Description  이전 이메일로 받은 링크의 토큰으로 이메일을 이전 주소로 되돌림. 모든 기기에서 로그아웃되므로 비밀번호 재설정을 권장
+// @Tags         인증
+// @Accept       json
+// @Produce      json
+// @Param        request body models.UndoEmailChangeRequest true "되돌리기 토큰"
+// @Success      200 {object} object{message=string} "되돌리기 성공"
+// @Failure      400 {object} models.ErrorResponse "잘못된 요청 또는 만료/사용된 링크"
+// @Failure      409 {object} models.ErrorResponse "이전 이메일을 다른 계정이 사용 중"
+// @Failure      429 {object} models.ErrorResponse "요청 횟수 초과"
+// @Router       /auth/email-change/undo [post]
+func (h *UserHandler) UndoEmailChange(c *gin.Context) {
+	var req models.UndoEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Invalid request format",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := h.userService.UndoEmailChange(req.Token); err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email change has been undone. Please reset your password.",
+	})
+}
+
+// respondUserError는 회원 API 오류를 상태 코드로 구분해 응답한다.
+func respondUserError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrProfileConflict), errors.Is(err, services.ErrEmailInUse):
+		status = http.StatusConflict
+	}
+	respondError(c, status, err)
+}
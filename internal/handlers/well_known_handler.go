@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"auth-go-service/internal/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type WellKnownHandler struct {
+	tokenService *services.TokenService
+}
+
+func NewWellKnownHandler(tokenService *services.TokenService) *WellKnownHandler {
+	return &WellKnownHandler{
+		tokenService: tokenService,
+	}
+}
+
+// JWKS는 액세스 토큰 서명 검증에 사용하는 공개키 목록(/.well-known/jwks.json)을 반환한다.
+// /v1 경로 밖에 있어 Swagger 문서에는 포함되지 않는다.
+func (h *WellKnownHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.tokenService.JWKS())
+}
@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"auth-go-service/internal/services"
+	"context"
+	"log"
+	"time"
+)
+
+// AccountPurge는 보관 기간이 지난 탈퇴 계정의 개인정보를 지우는 작업이다.
+func AccountPurge(userService *services.UserService, interval time.Duration) Job {
+	return Job{
+		Name:     "account-purge",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			purged, err := userService.PurgeDeletedAccounts(ctx, time.Now())
+			if purged > 0 {
+				log.Printf("Anonymized %d deleted accounts", purged)
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"auth-go-service/internal/services"
+	"context"
+	"log"
+	"time"
+)
+
+// DormantAccounts는 휴면 전환 예정 안내 메일을 보내고, 안내 후에도 로그인하지 않은 계정을 휴면 전환하는 작업이다.
+func DormantAccounts(dormantService *services.DormantService, interval time.Duration) Job {
+	return Job{
+		Name:     "dormant-accounts",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			now := time.Now()
+			notified, err := dormantService.SendDormancyNotices(ctx, now)
+			if notified > 0 {
+				log.Printf("Sent dormancy notices to %d accounts", notified)
+			}
+			if err != nil {
+				return err
+			}
+
+			moved, err := dormantService.MoveInactiveAccounts(ctx, now)
+			if moved > 0 {
+				log.Printf("Moved %d inactive accounts to dormant", moved)
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job은 서버 프로세스 안에서 주기적으로 실행되는 백그라운드 작업이다.
+// 여러 태스크에서 동시에 실행될 수 있으므로 Run은 여러 번 실행해도 결과가 같아야 한다.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Start는 job을 바로 한 번 실행한 뒤 ctx가 끝날 때까지 Interval마다 실행한다. Interval이 0 이하이면 실행하지 않는다.
+func Start(ctx context.Context, job Job) {
+	if job.Interval <= 0 {
+		log.Printf("Job %s is disabled", job.Name)
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(job.Interval)
+		defer ticker.Stop()
+
+		for {
+			runOnce(ctx, job)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func runOnce(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	if err := job.Run(ctx); err != nil {
+		log.Printf("Job %s failed: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"auth-go-service/internal/services"
+	"context"
+	"log"
+	"time"
+)
+
+// MarketingReconfirmation은 마케팅 수신 동의 후 재확인 주기(2년)가 지난 사용자에게 동의 현황을 안내하는 작업이다.
+func MarketingReconfirmation(userService *services.UserService, interval time.Duration) Job {
+	return Job{
+		Name:     "marketing-reconfirmation",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			sent, err := userService.SendMarketingReconfirmations(ctx, time.Now())
+			if sent > 0 {
+				log.Printf("Sent marketing re-confirmation notices to %d users", sent)
+			}
+			return err
+		},
+	}
+}
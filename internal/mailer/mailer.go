@@ -0,0 +1,35 @@
+package mailer
+
+import (
+	"auth-go-service/internal/config"
+	"fmt"
+)
+
+// Message는 발송할 이메일 한 통이다. HTMLBody와 TextBody 중 하나 이상이 있어야 한다.
+type Message struct {
+	To       string
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// Mailer는 이메일 발송 방식(SES, SMTP, 파일, 메모리)을 추상화한다.
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New는 MAIL_DRIVER 설정에 맞는 Mailer를 만든다.
+func New(cfg *config.Config) (Mailer, error) {
+	switch cfg.MailDriver {
+	case "ses":
+		return NewSESMailer(cfg.AWSRegion, cfg.AWSSESAccessKey, cfg.AWSSESSecretAccessKey, cfg.MailFrom)
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom), nil
+	case "file":
+		return NewFileMailer(cfg.MailFileDir, cfg.MailFrom)
+	case "memory":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown MAIL_DRIVER: %q (ses, smtp, file, memory)", cfg.MailDriver)
+	}
+}
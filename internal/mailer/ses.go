@@ -0,0 +1,54 @@
+package mailer
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ses"
+)
+
+type SESMailer struct {
+	client *ses.SES
+	from   string
+}
+
+func NewSESMailer(region, accessKey, secretAccessKey, from string) (*SESMailer, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(region),
+		Credentials: credentials.NewStaticCredentials(
+			accessKey,
+			secretAccessKey,
+			"",
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SESMailer{
+		client: ses.New(sess),
+		from:   from,
+	}, nil
+}
+
+func (m *SESMailer) Send(msg Message) error {
+	body := &ses.Body{}
+	if msg.HTMLBody != "" {
+		body.Html = &ses.Content{Charset: aws.String("UTF-8"), Data: aws.String(msg.HTMLBody)}
+	}
+	if msg.TextBody != "" {
+		body.Text = &ses.Content{Charset: aws.String("UTF-8"), Data: aws.String(msg.TextBody)}
+	}
+
+	_, err := m.client.SendEmail(&ses.SendEmailInput{
+		Source: aws.String(m.from),
+		Destination: &ses.Destination{
+			ToAddresses: []*string{aws.String(msg.To)},
+		},
+		Message: &ses.Message{
+			Subject: &ses.Content{Charset: aws.String("UTF-8"), Data: aws.String(msg.Subject)},
+			Body:    body,
+		},
+	})
+	return err
+}
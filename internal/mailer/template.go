@@ -0,0 +1,186 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// Brand는 모든 메일 템플릿에서 {{.Brand.*}}로 사용할 수 있는 브랜드 정보다.
+type Brand struct {
+	ProductName  string
+	SupportEmail string
+	WebsiteURL   string
+}
+
+type templateSet struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// Renderer는 templates/<locale>/<name>.html, <name>.txt 템플릿으로 메일 본문을 만든다.
+// 각 템플릿은 "subject"와 "content"를 정의하고, 같은 로케일의 layout 템플릿 안에 렌더링된다.
+type Renderer struct {
+	brand         Brand
+	defaultLocale string
+	sets          map[string]map[string]templateSet
+}
+
+// NewRenderer는 내장된 템플릿을 모두 파싱한다. defaultLocale은 반드시 지원하는 로케일이어야 한다.
+func NewRenderer(brand Brand, defaultLocale string) (*Renderer, error) {
+	r := &Renderer{
+		brand:         brand,
+		defaultLocale: defaultLocale,
+		sets:          map[string]map[string]templateSet{},
+	}
+
+	locales, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+	for _, locale := range locales {
+		if !locale.IsDir() {
+			continue
+		}
+		if err := r.loadLocale(locale.Name()); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := r.sets[defaultLocale]; !ok {
+		return nil, fmt.Errorf("unsupported default mail locale: %q", defaultLocale)
+	}
+	return r, nil
+}
+
+func (r *Renderer) loadLocale(locale string) error {
+	dir := "templates/" + locale
+	entries, err := fs.ReadDir(templateFS, dir)
+	if err != nil {
+		return err
+	}
+
+	r.sets[locale] = map[string]templateSet{}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".html")
+		if !ok || name == "layout" {
+			continue
+		}
+
+		html, err := htmltemplate.ParseFS(templateFS, dir+"/layout.html", dir+"/"+name+".html")
+		if err != nil {
+			return fmt.Errorf("parse mail template %s/%s.html: %w", locale, name, err)
+		}
+		text, err := texttemplate.ParseFS(templateFS, dir+"/layout.txt", dir+"/"+name+".txt")
+		if err != nil {
+			return fmt.Errorf("parse mail template %s/%s.txt: %w", locale, name, err)
+		}
+		r.sets[locale][name] = templateSet{html: html, text: text}
+	}
+	return nil
+}
+
+// Locales는 지원하는 로케일 목록을 반환한다.
+func (r *Renderer) Locales() []string {
+	locales := make([]string, 0, len(r.sets))
+	for locale := range r.sets {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// ResolveLocale은 후보 중 처음으로 지원되는 로케일을 고른다.
+// 후보는 사용자에 저장된 로케일("ko")이나 Accept-Language 헤더 값("en-US,en;q=0.9")일 수 있으며,
+// 지원되는 값이 없으면 기본 로케일을 반환한다.
+func (r *Renderer) ResolveLocale(candidates ...string) string {
+	for _, candidate := range candidates {
+		for _, tag := range parseAcceptLanguage(candidate) {
+			if _, ok := r.sets[tag]; ok {
+				return tag
+			}
+		}
+	}
+	return r.defaultLocale
+}
+
+// Render는 지정한 로케일의 템플릿으로 제목과 HTML/텍스트 본문을 만든다. 수신자(To)는 호출하는 쪽에서 채운다.
+func (r *Renderer) Render(locale, name string, data map[string]interface{}) (Message, error) {
+	set, ok := r.sets[r.ResolveLocale(locale)][name]
+	if !ok {
+		return Message{}, fmt.Errorf("mail template not found: %s/%s", locale, name)
+	}
+
+	vars := map[string]interface{}{"Brand": r.brand}
+	for key, value := range data {
+		vars[key] = value
+	}
+
+	var subject, html, text bytes.Buffer
+	if err := set.text.ExecuteTemplate(&subject, "subject", vars); err != nil {
+		return Message{}, err
+	}
+	if err := set.html.ExecuteTemplate(&html, "layout.html", vars); err != nil {
+		return Message{}, err
+	}
+	if err := set.text.ExecuteTemplate(&text, "layout.txt", vars); err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Subject:  strings.TrimSpace(subject.String()),
+		HTMLBody: html.String(),
+		TextBody: strings.TrimSpace(text.String()) + "\n",
+	}, nil
+}
+
+// parseAcceptLanguage는 언어 태그를 q 값이 높은 순으로, 기본 언어 부분("en-US" → "en")만 소문자로 반환한다.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+		if i := strings.IndexAny(tag, "-_"); i > 0 {
+			tag = tag[:i]
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
@@ -37,6 +37,15 @@ func AuthRequired(authService *services.AuthService) gin.HandlerFunc {
 			return
 		}
 
+		subjectType := services.SubjectTypeUser
+		if claims.IsService() {
+			subjectType = services.SubjectTypeService
+		}
+
+		c.Set("claims", claims)
+		c.Set("subjectType", subjectType)
+		c.Set("clientID", claims.ClientID)
+		c.Set("scope", claims.Scope)
 		c.Set("userID", claims.UserID)
 		c.Set("email", claims.Email)
 		c.Set("name", claims.Name)
@@ -44,10 +53,56 @@ func AuthRequired(authService *services.AuthService) gin.HandlerFunc {
 	}
 }
 
+// IsService는 AuthRequired를 통과한 호출자가 서비스 클라이언트(client_credentials 토큰)인지 여부다.
+func IsService(c *gin.Context) bool {
+	return c.GetString("subjectType") == services.SubjectTypeService
+}
+
+// UserRequired는 AuthRequired 뒤에 두어 사용자 토큰만 통과시킨다.
+// 서비스 토큰에는 사용자가 없으므로 회원 정보, 2단계 인증 같은 사용자 API를 호출할 수 없다.
+func UserRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsService(c) {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Message: "User token is required",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePermission은 AuthRequired 뒤에 두어 사용자에게 역할로 받은 permission(예: "users:read")이 있는지 확인한다.
+// 서비스 토큰의 scope는 권한으로 보지 않으므로 어느 라우트 그룹에 붙여도 서비스 토큰은 통과하지 못한다.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return requireClaims(func(claims *services.JWTClaims) bool { return claims.HasPermission(permission) }, "Permission denied")
+}
+
+// RequireScope는 AuthRequired 뒤에 두어 서비스 토큰에 허용된 scope가 있는지 확인한다. 사용자 토큰은 통과하지 못한다.
+func RequireScope(scope string) gin.HandlerFunc {
+	return requireClaims(func(claims *services.JWTClaims) bool { return claims.IsService() && claims.HasScope(scope) }, "Insufficient scope")
+}
+
+func requireClaims(allowed func(*services.JWTClaims) bool, message string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, _ := c.Get("claims")
+		claims, ok := value.(*services.JWTClaims)
+		if !ok || !allowed(claims) {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Message: message,
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
 func CORS() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
 
 		if c.Request.Method == "OPTIONS" {
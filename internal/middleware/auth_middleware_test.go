@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"auth-go-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(claims *services.JWTClaims) int {
+		router := gin.New()
+		router.GET("/admin/users/1", func(c *gin.Context) {
+			if claims != nil {
+				c.Set("claims", claims)
+			}
+		}, RequirePermission("users:read"), func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		})
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/users/1", nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, serve(nil))
+	assert.Equal(t, http.StatusForbidden, serve(&services.JWTClaims{Roles: []string{"support"}}))
+	assert.Equal(t, http.StatusNoContent, serve(&services.JWTClaims{Roles: []string{"support"}, Permissions: []string{"roles:read", "users:read"}}))
+	// 서비스 토큰의 scope는 권한이 아니다.
+	assert.Equal(t, http.StatusForbidden, serve(&services.JWTClaims{SubjectType: services.SubjectTypeService, Scope: "users:read"}))
+}
+
+func TestRequireScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(claims *services.JWTClaims) int {
+		router := gin.New()
+		router.GET("/internal/users/1", func(c *gin.Context) {
+			c.Set("claims", claims)
+		}, RequireScope("users:read"), func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		})
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/internal/users/1", nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, serve(&services.JWTClaims{SubjectType: services.SubjectTypeService, Scope: "users:read users:write"}))
+	assert.Equal(t, http.StatusForbidden, serve(&services.JWTClaims{SubjectType: services.SubjectTypeService, Scope: "users:write"}))
+	// 사용자 토큰은 역할의 권한이 있어도 scope 확인을 통과하지 못한다.
+	assert.Equal(t, http.StatusForbidden, serve(&services.JWTClaims{Permissions: []string{"users:read"}}))
+}
+
+func TestUserRequiredRejectsServiceTokensWithAdminScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(subjectType string, claims *services.JWTClaims) int {
+		router := gin.New()
+		// 관리자 API와 같이 AuthRequired 뒤에 UserRequired, RequirePermission을 둔다.
+		router.POST("/admin/users/1/roles", func(c *gin.Context) {
+			c.Set("claims", claims)
+			c.Set("subjectType", subjectType)
+		}, UserRequired(), RequirePermission("roles:write"), func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		})
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/users/1/roles", nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, serve(services.SubjectTypeService, &services.JWTClaims{SubjectType: services.SubjectTypeService, Scope: "roles:write"}))
+	assert.Equal(t, http.StatusNoContent, serve(services.SubjectTypeUser, &services.JWTClaims{Permissions: []string{"roles:write"}}))
+}
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"auth-go-service/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 요청을 구분하는 기준
+const (
+	RateLimitKeyIP    = "ip"
+	RateLimitKeyEmail = "email"
+	RateLimitKeyUser  = "user"
+)
+
+// RateLimitStore는 토큰 버킷 상태를 보관한다.
+// Take는 key의 버킷(용량 limit, period마다 limit개 충전)에서 토큰 하나를 꺼내고, 실패하면 다시 시도할 수 있을 때까지의 시간을 반환한다.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit int, period time.Duration) (bool, time.Duration, error)
+}
+
+// RateLimit은 하나의 버킷 규칙이다. 예: "3/10m:email"은 이메일마다 10분에 3회.
+type RateLimit struct {
+	Limit  int
+	Period time.Duration
+	Key    string
+}
+
+// RateLimiter는 설정된 라우트별 규칙(RATE_LIMITS)으로 gin 미들웨어를 만든다.
+type RateLimiter struct {
+	store RateLimitStore
+	rules map[string][]RateLimit
+}
+
+// NewRateLimiter는 "이름 → 규칙" 설정을 파싱한다.
+// 규칙은 "limit/period[:key]"를 "+"로 이어 여러 개 지정할 수 있으며(모두 통과해야 허용), "off"이면 제한하지 않는다.
+func NewRateLimiter(store RateLimitStore, specs map[string]string) (*RateLimiter, error) {
+	rules := map[string][]RateLimit{}
+	for name, spec := range specs {
+		limits, err := ParseRateLimits(spec)
+		if err != nil {
+			return nil, fmt.Errorf("rate limit %q: %w", name, err)
+		}
+		rules[name] = limits
+	}
+	return &RateLimiter{store: store, rules: rules}, nil
+}
+
+// ParseRateLimits는 "20/10m:ip+3/10m:email" 형식의 규칙을 파싱한다. key를 생략하면 ip 기준이다.
+func ParseRateLimits(spec string) ([]RateLimit, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" || spec == "off" {
+		return nil, nil
+	}
+
+	var limits []RateLimit
+	for _, part := range strings.Split(spec, "+") {
+		rate, key, hasKey := strings.Cut(strings.TrimSpace(part), ":")
+		if !hasKey {
+			key = RateLimitKeyIP
+		}
+		switch key {
+		case RateLimitKeyIP, RateLimitKeyEmail, RateLimitKeyUser:
+		default:
+			return nil, fmt.Errorf("unknown key %q (ip, email, user)", key)
+		}
+
+		count, period, ok := strings.Cut(rate, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate %q (expected limit/period, e.g. 5/10m)", rate)
+		}
+		limit, err := strconv.Atoi(count)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid limit %q", count)
+		}
+		duration, err := time.ParseDuration(period)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid period %q", period)
+		}
+
+		limits = append(limits, RateLimit{Limit: limit, Period: duration, Key: key})
+	}
+	return limits, nil
+}
+
+// Limit은 name 규칙을 적용하는 미들웨어를 반환한다. 규칙이 "off"이면 아무것도 하지 않는다.
+// user 기준 규칙은 AuthRequired 뒤에 사용해야 하며, 기준 값을 알 수 없는 요청은 IP 기준으로 제한한다.
+func (r *RateLimiter) Limit(name string) gin.HandlerFunc {
+	limits, ok := r.rules[name]
+	if !ok {
+		panic(fmt.Sprintf("rate limit rule %q is not configured", name))
+	}
+
+	return func(c *gin.Context) {
+		var retryAfter time.Duration
+		for _, limit := range limits {
+			key := "ratelimit:" + name + ":" + limit.Key + ":" + rateLimitIdentity(c, limit.Key)
+			allowed, wait, err := r.store.Take(c.Request.Context(), key, limit.Limit, limit.Period)
+			if err != nil {
+				// 저장소 장애로 서비스 전체가 멈추지 않도록 제한 없이 통과시킨다.
+				log.Printf("Rate limit store error: %v", err)
+				continue
+			}
+			if !allowed && wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+
+		if retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, models.ErrorResponse{
+				Message: "Too many requests. Please try again later.",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func rateLimitIdentity(c *gin.Context, key string) string {
+	switch key {
+	case RateLimitKeyUser:
+		if userID := c.GetUint("userID"); userID != 0 {
+			return "user:" + strconv.FormatUint(uint64(userID), 10)
+		}
+	case RateLimitKeyEmail:
+		if email := requestEmail(c); email != "" {
+			return "email:" + email
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// 이메일 키를 찾기 위해 읽는 본문의 최대 크기
+const maxEmailBodyBytes = 64 << 10
+
+// requestEmail은 쿼리 또는 JSON 본문의 email 값을 읽는다. 핸들러가 다시 읽을 수 있도록 본문은 복원한다.
+// 본문이 maxEmailBodyBytes보다 크면 빈 문자열을 반환해 IP 키를 쓰게 한다.
+func requestEmail(c *gin.Context) string {
+	if email := c.Query("email"); email != "" {
+		return strings.ToLower(strings.TrimSpace(email))
+	}
+	if c.Request.Body == nil {
+		return ""
+	}
+
+	original := c.Request.Body
+	body, err := io.ReadAll(io.LimitReader(original, maxEmailBodyBytes+1))
+	c.Request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), original), original}
+	if err != nil || len(body) > maxEmailBodyBytes {
+		return ""
+	}
+
+	var payload struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(payload.Email))
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"math"
+	"sync"
+	"time"
+)
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	period    time.Duration
+}
+
+// MemoryRateLimitStore는 프로세스 메모리에 버킷을 보관한다. 서버가 여러 대이면 서버마다 따로 제한된다.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: map[string]*tokenBucket{},
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, limit int, period time.Duration) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	rate := float64(limit) / period.Seconds()
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit), updatedAt: now, period: period}
+		s.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(float64(limit), bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*rate)
+	bucket.updatedAt = now
+	bucket.period = period
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0, nil
+	}
+	return false, time.Duration((1 - bucket.tokens) / rate * float64(time.Second)), nil
+}
+
+// sweep은 가득 찰 만큼 오래 사용되지 않은 버킷을 1분마다 정리한다.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.updatedAt) > bucket.period {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// redisTokenBucketScript는 토큰 버킷 계산을 Redis 안에서 원자적으로 수행한다.
+// 여러 ECS 태스크의 시계 차이에 영향을 받지 않도록 Redis 서버 시간을 사용한다.
+var redisTokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local period_ms = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local rate = capacity / period_ms
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+local retry_ms = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry_ms = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], period_ms)
+return {allowed, retry_ms}
+`)
+
+// RedisRateLimitStore는 Redis에 버킷을 보관해 여러 서버가 같은 제한을 공유한다.
+type RedisRateLimitStore struct {
+	client redis.Scripter
+}
+
+func NewRedisRateLimitStore(client redis.Scripter) *RedisRateLimitStore {
+	return &RedisRateLimitStore{client: client}
+}
+
+func (s *RedisRateLimitStore) Take(ctx context.Context, key string, limit int, period time.Duration) (bool, time.Duration, error) {
+	result, err := redisTokenBucketScript.Run(ctx, s.client, []string{key}, limit, period.Milliseconds()).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := ParseRateLimits("20/10m+3/1h:email")
+	require.NoError(t, err)
+	assert.Equal(t, []RateLimit{
+		{Limit: 20, Period: 10 * time.Minute, Key: RateLimitKeyIP},
+		{Limit: 3, Period: time.Hour, Key: RateLimitKeyEmail},
+	}, limits)
+
+	limits, err = ParseRateLimits("off")
+	require.NoError(t, err)
+	assert.Empty(t, limits)
+
+	for _, spec := range []string{"10", "0/1m", "10/forever", "10/1m:phone"} {
+		_, err := ParseRateLimits(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestMemoryRateLimitStoreRefillsTokens(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := store.Take(context.Background(), "key", 2, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, retryAfter, err := store.Take(context.Background(), "key", 2, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	now = now.Add(30 * time.Second)
+	allowed, _, err = store.Take(context.Background(), "key", 2, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestRateLimitByEmailKeepsBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter, err := NewRateLimiter(NewMemoryRateLimitStore(), map[string]string{"reset": "1/1h:email"})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/reset", limiter.Limit("reset"), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+
+	send := func(email string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		body := `{"email":"` + email + `"}`
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/reset", strings.NewReader(body)))
+		return rr
+	}
+
+	rr := send("user@example.com")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"email":"user@example.com"}`, rr.Body.String())
+
+	rr = send("USER@example.com")
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "3600", rr.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, send("other@example.com").Code)
+}
+
+func TestRateLimitByEmailFallsBackToIPForLargeBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter, err := NewRateLimiter(NewMemoryRateLimitStore(), map[string]string{"reset": "1/1h:email"})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/reset", limiter.Limit("reset"), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, strconv.Itoa(len(body)))
+	})
+
+	padding := strings.Repeat(" ", maxEmailBodyBytes)
+	send := func(email string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		body := `{"email":"` + email + `"}` + padding
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/reset", strings.NewReader(body)))
+		return rr
+	}
+
+	rr := send("user@example.com")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, strconv.Itoa(len(`{"email":"user@example.com"}`)+len(padding)), rr.Body.String())
+
+	// 본문이 너무 크면 이메일이 달라도 같은 IP 버킷을 쓴다
+	assert.Equal(t, http.StatusTooManyRequests, send("other@example.com").Code)
+}
+
+func TestRateLimitByIPIgnoresSpoofedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter, err := NewRateLimiter(NewMemoryRateLimitStore(), map[string]string{"login": "1/1h"})
+	require.NoError(t, err)
+
+	router := gin.New()
+	require.NoError(t, router.SetTrustedProxies([]string{"10.0.0.0/8"}))
+	router.POST("/login", limiter.Limit("login"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	send := func(remoteAddr, forwardedFor string) int {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		router.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send("203.0.113.5:1234", "192.0.2.1"))
+	assert.Equal(t, http.StatusTooManyRequests, send("203.0.113.5:1234", "192.0.2.2"))
+
+	// 신뢰하는 프록시를 거치면 X-Forwarded-For의 클라이언트 IP를 쓴다
+	assert.Equal(t, http.StatusOK, send("10.0.0.2:1234", "192.0.2.3"))
+	assert.Equal(t, http.StatusTooManyRequests, send("10.0.0.2:1234", "203.0.113.5"))
+}
@@ -1,13 +1,51 @@
 package models
 
+import "time"
+
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email" example:"user@example.com"` // 사용자 이메일 주소
 	Password string `json:"password" binding:"required" example:"password123"`         // 사용자 비밀번호
 }
 
 type LoginResponse struct {
-	Token     string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`  // JWT 인증 토큰
-	ExpiresIn int    `json:"expiresIn" example:"3600"`                              // 토큰 만료 시간(초)
+	Token            string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`  // JWT 인증 토큰
+	ExpiresIn        int    `json:"expiresIn" example:"900"`                               // 토큰 만료 시간(초)
+	RefreshToken     string `json:"refreshToken" example:"hR3f...Qk"`                      // 액세스 토큰 재발급용 리프레시 토큰
+	RefreshExpiresIn int    `json:"refreshExpiresIn" example:"1209600"`                    // 리프레시 토큰 만료 시간(초)
+	PendingTerms     []Terms `json:"pendingTerms,omitempty"`                               // 다시 동의해야 하는 필수 약관 (새 버전 시행 시. 동의 전까지 /users/me/terms로 동의 요청 필요)
+}
+
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfaRequired" example:"true"`                             // 2단계 인증 필요 여부
+	MFAToken    string `json:"mfaToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // 2단계 인증용 임시 토큰
+	ExpiresIn   int    `json:"expiresIn" example:"300"`                                // 임시 토큰 만료 시간(초)
+}
+
+type MFALoginRequest struct {
+	MFAToken string `json:"mfaToken" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // 로그인 시 발급받은 임시 토큰
+	Code     string `json:"code" binding:"required" example:"123456"`                                   // 인증 앱의 6자리 코드 또는 복구 코드
+}
+
+type MFAEnrollResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`                                      // 인증 앱 수동 등록용 비밀키
+	OTPAuthURI string `json:"otpauthUri" example:"otpauth://totp/Momentir:user@example.com?secret=JBSWY3DPEHPK3PXP"` // QR 코드 생성용 URI
+}
+
+type MFAConfirmRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"` // 인증 앱의 6자리 코드
+}
+
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes" example:"a1b2c-d3e4f"` // 1회용 복구 코드 (다시 조회할 수 없음)
+}
+
+type MFADisableRequest struct {
+	Password string `json:"password" binding:"required" example:"password123"` // 현재 비밀번호
+	Code     string `json:"code" binding:"required" example:"123456"`          // 인증 앱의 6자리 코드 또는 복구 코드
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required" example:"hR3f...Qk"` // 로그인 시 발급받은 리프레시 토큰
 }
 
 type SignUpRequest struct {
@@ -16,6 +54,118 @@ type SignUpRequest struct {
 	Phone    string `json:"phone" binding:"required" example:"010-1234-5678"`              // 전화번호
 	Password string `json:"password" binding:"required,min=8" example:"password123"`       // 비밀번호 (8자 이상)
 	AgreedMarketingOptIn bool `json:"agreedMarketingOptIn" example:"true"`               // 마케팅 수신 동의
+	Locale   string `json:"locale" example:"ko"`                                            // 안내 메일 언어 (ko, en). 생략하면 Accept-Language 헤더 기준
+	Terms    []AcceptedTerms `json:"terms" binding:"dive"`                                 // 동의한 약관 (필수 약관의 현재 버전은 모두 포함해야 함)
+}
+
+type AcceptedTerms struct {
+	Code    string `json:"code" binding:"required" example:"service"` // 약관 종류
+	Version string `json:"version" binding:"required" example:"1"`    // 동의한 약관 버전 (GET /terms의 현재 버전)
+}
+
+type AcceptTermsRequest struct {
+	Terms []AcceptedTerms `json:"terms" binding:"required,min=1,dive"` // 동의할 약관
+}
+
+type TermsStatus struct {
+	Terms
+	AgreedVersion string     `json:"agreedVersion" example:"1"`                   // 마지막으로 동의한 버전 (동의한 적 없으면 빈 문자열)
+	AgreedAt      *time.Time `json:"agreedAt" example:"2024-01-01T00:00:00Z"`   // 마지막으로 동의한 시간
+	Pending       bool       `json:"pending" example:"false"`                    // 현재 버전에 다시 동의해야 하는 필수 약관인지 여부
+}
+
+type SocialAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorizationUrl" example:"https://kauth.kakao.com/oauth/authorize?response_type=code&client_id=..."` // 사용자를 보낼 제공자 로그인 화면 주소
+	State            string `json:"state" example:"hR3f...Qk"`                                                                       // 콜백에서 돌려받은 state와 비교하고 /callback에 그대로 보낼 값
+	ExpiresIn        int    `json:"expiresIn" example:"600"`                                                                         // state 유효 시간(초)
+}
+
+type SocialCallbackRequest struct {
+	Code  string `json:"code" binding:"required" example:"SplxlOBeZQQYbYS6WxSbIA"` // 제공자가 redirect URL로 돌려준 인가 코드
+	State string `json:"state" binding:"required" example:"hR3f...Qk"`             // 제공자가 redirect URL로 돌려준 state
+}
+
+type SocialSignUpRequiredResponse struct {
+	SignUpRequired bool   `json:"signUpRequired" example:"true"`                             // 연결된 계정이 없어 가입이 필요한지 여부
+	SignUpToken    string `json:"signUpToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // /auth/social/sign-up에 보낼 가입 전용 토큰
+	Email          string `json:"email" example:"user@example.com"`                           // 제공자가 확인한 이메일 주소 (가입 시 사용)
+	Name           string `json:"name" example:"홍길동"`                                          // 제공자의 사용자 이름 (가입 화면 기본값)
+	ExpiresIn      int    `json:"expiresIn" example:"600"`                                    // 가입 전용 토큰 만료 시간(초)
+}
+
+type SocialSignUpRequest struct {
+	SignUpToken          string          `json:"signUpToken" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // 콜백에서 받은 가입 전용 토큰
+	Name                 string          `json:"name" binding:"required" example:"홍길동"`                                        // 사용자 이름
+	Phone                string          `json:"phone" binding:"required" example:"010-1234-5678"`                           // 전화번호
+	AgreedMarketingOptIn bool            `json:"agreedMarketingOptIn" example:"true"`                                         // 마케팅 수신 동의
+	Locale               string          `json:"locale" example:"ko"`                                                         // 안내 메일 언어 (ko, en). 생략하면 Accept-Language 헤더 기준
+	Terms                []AcceptedTerms `json:"terms" binding:"dive"`                                                        // 동의한 약관 (필수 약관의 현재 버전은 모두 포함해야 함)
+}
+
+type UpdateProfileRequest struct {
+	Name                 *string   `json:"name" binding:"omitempty,min=1,max=30" example:"홍길동"`          // 사용자 이름 (생략하면 변경하지 않음)
+	Phone                *string   `json:"phone" binding:"omitempty,max=30" example:"010-1234-5678"`     // 전화번호 (생략하면 변경하지 않음)
+	AgreedMarketingOptIn *bool     `json:"agreedMarketingOptIn" example:"false"`                        // 마케팅 수신 동의 (생략하면 변경하지 않음)
+	UpdatedAt            time.Time `json:"updatedAt" binding:"required" example:"2026-01-01T09:00:00Z"` // 조회한 회원 정보의 updatedAt (다른 곳에서 변경되었으면 409)
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" binding:"required" example:"password123"`      // 현재 비밀번호
+	NewPassword     string `json:"newPassword" binding:"required,min=8" example:"newpass123"` // 새 비밀번호 (8자 이상, 영문자와 숫자 포함)
+}
+
+type RequestEmailChangeRequest struct {
+	NewEmail string `json:"newEmail" binding:"required,email" example:"new@example.com"` // 변경할 이메일 주소 (인증 코드 발송)
+	Password string `json:"password" binding:"required" example:"password123"`          // 현재 비밀번호
+}
+
+type ConfirmEmailChangeRequest struct {
+	NewEmail         string `json:"newEmail" binding:"required,email" example:"new@example.com"` // 변경할 이메일 주소
+	VerificationCode string `json:"verificationCode" binding:"required" example:"123456"`       // 새 이메일로 받은 인증 코드
+	VerificationID   uint   `json:"verificationId" binding:"required" example:"12345"`         // 이메일 변경 요청 시 받은 인증 ID
+}
+
+type UndoEmailChangeRequest struct {
+	Token string `json:"token" binding:"required" example:"undo_token_abc123"` // 이전 이메일로 받은 되돌리기 토큰 (한 번만 사용 가능)
+}
+
+type MarketingConsentStatus struct {
+	Channel   string     `json:"channel" example:"email"`                   // 채널 (email, sms, push)
+	Agreed    bool       `json:"agreed" example:"true"`                     // 수신 동의 여부
+	UpdatedAt *time.Time `json:"updatedAt" example:"2024-01-01T00:00:00Z"` // 마지막으로 동의 또는 철회한 시간 (이력이 없으면 null)
+}
+
+type MarketingConsentResponse struct {
+	Consents    []MarketingConsentStatus `json:"consents"`                                    // 채널별 수신 동의 여부
+	ConfirmedAt *time.Time               `json:"confirmedAt" example:"2024-01-01T00:00:00Z"` // 마지막 동의 또는 재확인 안내 시간 (2년마다 재확인 안내)
+	History     []MarketingConsent       `json:"history"`                                     // 동의/철회 이력 (최신순)
+}
+
+type MarketingConsentChange struct {
+	Channel string `json:"channel" binding:"required,oneof=email sms push" example:"email"` // 채널 (email, sms, push)
+	Agreed  *bool  `json:"agreed" binding:"required" example:"true"`                     // 수신 동의 여부
+}
+
+type UpdateMarketingConsentRequest struct {
+	Consents []MarketingConsentChange `json:"consents" binding:"required,min=1,dive"` // 변경할 채널 (보내지 않은 채널은 그대로)
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required" example:"password123"` // 본인 확인용 현재 비밀번호
+}
+
+type DeleteAccountResponse struct {
+	Message      string    `json:"message" example:"Account deleted"`             // 응답 메시지
+	RestoreUntil time.Time `json:"restoreUntil" example:"2024-02-01T00:00:00Z"` // 이 시각까지 /auth/restore-account로 복구 가능
+}
+
+type RestoreAccountRequest struct {
+	Email    string `json:"email" binding:"required,email" example:"user@example.com"` // 탈퇴한 계정의 이메일 주소
+	Password string `json:"password" binding:"required" example:"password123"`         // 탈퇴 전 비밀번호
+}
+
+type LogoutRequest struct {
+	AllDevices bool `json:"allDevices" example:"false"` // true이면 모든 기기의 로그인 세션을 종료
 }
 
 type RequestEmailVerificationRequest struct {
@@ -33,12 +183,25 @@ type VerifyEmailRequest struct {
 	VerificationID   uint   `json:"verificationId" binding:"required" example:"12345"`          // 인증 요청 ID
 }
 
+type DormantAccountResponse struct {
+	Message        string `json:"message" example:"휴면 상태인 계정입니다."` // 응답 메시지
+	Dormant        bool   `json:"dormant" example:"true"`            // 휴면 계정 여부
+	VerificationID uint   `json:"verificationId" example:"12345"`    // 휴면 해제 인증 ID (인증 코드는 이메일로 발송)
+}
+
+type ReactivateAccountRequest struct {
+	Email            string `json:"email" binding:"required,email" example:"user@example.com"` // 휴면 계정의 이메일 주소
+	VerificationCode string `json:"verificationCode" binding:"required" example:"123456"`       // 이메일로 받은 인증 코드
+	VerificationID   uint   `json:"verificationId" binding:"required" example:"12345"`         // 로그인 시 받은 휴면 해제 인증 ID
+}
+
 type RequestPasswordResetRequest struct {
-	Email string `json:"email" binding:"required,email" example:"user@example.com"` // 비밀번호를 재설정할 이메일 주소
+	Email  string `json:"email" binding:"required,email" example:"user@example.com"` // 비밀번호를 재설정할 이메일 주소
+	Tenant string `json:"tenant" example:"momentir"`                                 // 재설정 링크를 받을 프론트엔드 (생략하면 기본 주소)
 }
 
 type ResetPasswordRequest struct {
-	Token       string `json:"token" binding:"required" example:"reset_token_abc123"`     // 이메일로 받은 재설정 토큰
+	Token       string `json:"token" binding:"required" example:"reset_token_abc123"`     // 이메일로 받은 재설정 토큰 (한 번만 사용 가능)
 	NewPassword string `json:"newPassword" binding:"required,min=8" example:"newpass123"` // 새로운 비밀번호 (8자 이상)
 }
 
@@ -51,6 +214,123 @@ type FindMyEmailResponse struct {
 	MaskedEmail string `json:"maskedEmail" example:"us***@example.com"` // 마스킹된 이메일 주소
 }
 
+type JSONWebKey struct {
+	KeyType   string `json:"kty" example:"RSA"`                // 키 종류 (RSA, EC)
+	KeyID     string `json:"kid" example:"2026-10"`            // 키 ID (JWT 헤더의 kid)
+	Use       string `json:"use" example:"sig"`                // 키 용도
+	Algorithm string `json:"alg" example:"RS256"`              // 서명 알고리즘
+	N         string `json:"n,omitempty" example:"0vx7agoebG"` // RSA modulus
+	E         string `json:"e,omitempty" example:"AQAB"`       // RSA exponent
+	Curve     string `json:"crv,omitempty" example:"P-256"`    // EC 곡선
+	X         string `json:"x,omitempty" example:"f83OJ3D2xF"` // EC x 좌표
+	Y         string `json:"y,omitempty" example:"x_FEzRu9m3"` // EC y 좌표
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"` // 토큰 검증용 공개키 목록
+}
+
+type OpenIDConfiguration struct {
+	Issuer                            string   `json:"issuer" example:"https://auth.example.com"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint" example:"https://auth.example.com/v1/oauth/authorize"`
+	TokenEndpoint                     string   `json:"token_endpoint" example:"https://auth.example.com/v1/oauth/token"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint" example:"https://auth.example.com/v1/oauth/userinfo"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint" example:"https://auth.example.com/v1/oauth/introspect"`
+	RevocationEndpoint                string   `json:"revocation_endpoint" example:"https://auth.example.com/v1/oauth/revoke"`
+	JWKSURI                           string   `json:"jwks_uri" example:"https://auth.example.com/.well-known/jwks.json"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+}
+
+type AuthorizeRequest struct {
+	ResponseType        string `json:"response_type" form:"response_type" binding:"required" example:"code"`                          // code만 지원
+	ClientID            string `json:"client_id" form:"client_id" binding:"required" example:"app_3f9a..."`                          // 등록된 클라이언트 ID
+	RedirectURI         string `json:"redirect_uri" form:"redirect_uri" binding:"required" example:"https://app.example.com/callback"` // 등록된 redirect URI와 정확히 일치해야 함
+	Scope               string `json:"scope" form:"scope" binding:"required" example:"openid profile email"`                         // openid 필수 (profile, email, phone)
+	State               string `json:"state" form:"state" example:"af0ifjsldkj"`                                                    // redirect 시 그대로 돌려주는 값
+	Nonce               string `json:"nonce" form:"nonce" example:"n-0S6_WzA2Mj"`                                                   // ID 토큰에 그대로 담는 값
+	CodeChallenge       string `json:"code_challenge" form:"code_challenge" binding:"required" example:"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"` // PKCE code_challenge
+	CodeChallengeMethod string `json:"code_challenge_method" form:"code_challenge_method" binding:"required" example:"S256"`       // S256만 지원
+}
+
+type AuthorizeResponse struct {
+	RedirectURL string `json:"redirectUrl" example:"https://app.example.com/callback?code=...&state=af0ifjsldkj"` // 인가 코드를 담은 클라이언트 redirect 주소 (브라우저를 이 주소로 보냄)
+}
+
+type OAuthTokenRequest struct {
+	GrantType    string `form:"grant_type" binding:"required" example:"authorization_code"` // authorization_code, client_credentials
+	Code         string `form:"code" example:"SplxlOBeZQQYbYS6WxSbIA"`                     // 인가 코드
+	RedirectURI  string `form:"redirect_uri" example:"https://app.example.com/callback"`   // 인가 요청의 redirect_uri
+	CodeVerifier string `form:"code_verifier" example:"dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"` // PKCE code_verifier
+	Scope        string `form:"scope" example:"users:read"`                                // client_credentials로 요청할 scope (생략하면 허용된 scope 전체)
+	ClientID     string `form:"client_id" example:"app_3f9a..."`                           // client_secret_post 또는 공개 클라이언트
+	ClientSecret string `form:"client_secret" example:"secret"`                           // client_secret_post
+}
+
+type OAuthTokenResponse struct {
+	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // 액세스 토큰 (userinfo 호출용, client_credentials는 서비스 토큰)
+	TokenType   string `json:"token_type" example:"Bearer"`
+	ExpiresIn   int    `json:"expires_in" example:"900"`                                     // 액세스 토큰 만료 시간(초)
+	IDToken     string `json:"id_token,omitempty" example:"eyJhbGciOiJSUzI1NiIsImtpZCI6IjIwMjYtMTAifQ..."` // ID 토큰
+	Scope       string `json:"scope" example:"openid profile email"`                       // 허용된 scope
+}
+
+type IntrospectionRequest struct {
+	Token         string `form:"token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // 확인할 액세스 토큰 또는 리프레시 토큰
+	TokenTypeHint string `form:"token_type_hint" example:"access_token"`                                   // access_token, refresh_token (무시해도 됨)
+	ClientID      string `form:"client_id" example:"app_3f9a..."`                                          // client_secret_post
+	ClientSecret  string `form:"client_secret" example:"secret"`                                          // client_secret_post
+}
+
+type IntrospectionResponse struct {
+	Active      bool   `json:"active" example:"true"`                                         // 사용할 수 있는 토큰인지 여부 (false이면 다른 값은 없음)
+	TokenType   string `json:"token_type,omitempty" example:"access_token"`                   // access_token, refresh_token
+	Scope       string `json:"scope,omitempty" example:"users:read"`                          // 클라이언트에 발급한 토큰의 scope
+	ClientID    string `json:"client_id,omitempty" example:"app_3f9a..."`                     // 토큰을 발급받은 클라이언트 (이 서비스에서 직접 로그인한 토큰은 비어 있음)
+	Subject     string `json:"sub,omitempty" example:"42"`                                    // 사용자 ID 또는 서비스 클라이언트의 client_id
+	SubjectType string `json:"sub_type,omitempty" example:"user"`                             // user, service
+	Email       string `json:"email,omitempty" example:"user@example.com"`                    // 액세스 토큰에 담긴 이메일
+	Name        string `json:"name,omitempty" example:"홍길동"`                                  // 액세스 토큰에 담긴 이름
+	Roles       []string `json:"roles,omitempty" example:"support"`                           // 액세스 토큰에 담긴 역할
+	Permissions []string `json:"permissions,omitempty" example:"users:read"`                  // 액세스 토큰에 담긴 권한
+	SessionID   string `json:"sid,omitempty" example:"0b6e3c1e-5f1a-4c0e-9a4b-3f0d2c9e7a11"` // 로그인 세션 ID
+	JTI         string `json:"jti,omitempty" example:"7d2f0c9e-1b4a-4e8f-a3c6-5e9b1d2f4a80"` // 액세스 토큰 ID
+	IssuedAt    int64  `json:"iat,omitempty" example:"1760000000"`                            // 발급 시간 (Unix)
+	ExpiresAt   int64  `json:"exp,omitempty" example:"1760000900"`                            // 만료 시간 (Unix)
+}
+
+type RevocationRequest struct {
+	Token         string `form:"token" binding:"required" example:"hR3f...Qk"` // 폐기할 액세스 토큰 또는 리프레시 토큰
+	TokenTypeHint string `form:"token_type_hint" example:"refresh_token"`     // access_token, refresh_token (무시해도 됨)
+	ClientID      string `form:"client_id" example:"app_3f9a..."`             // client_secret_post
+	ClientSecret  string `form:"client_secret" example:"secret"`             // client_secret_post
+}
+
+type UserInfo struct {
+	Subject       string `json:"sub" example:"42"`                                   // 사용자 ID
+	Name          string `json:"name,omitempty" example:"홍길동"`                        // profile scope
+	Locale        string `json:"locale,omitempty" example:"ko"`                       // profile scope
+	UpdatedAt     int64  `json:"updated_at,omitempty" example:"1704067200"`          // profile scope
+	Email         string `json:"email,omitempty" example:"user@example.com"`          // email scope
+	EmailVerified *bool  `json:"email_verified,omitempty" example:"true"`            // email scope
+	PhoneNumber   string `json:"phone_number,omitempty" example:"010-1234-5678"`     // phone scope
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required" example:"support"` // 부여할 역할 이름
+}
+
+type OAuthErrorResponse struct {
+	Error            string `json:"error" example:"invalid_grant"`                                    // OAuth 2.0 오류 코드
+	ErrorDescription string `json:"error_description,omitempty" example:"Invalid authorization code"` // 오류 설명
+}
+
 type ErrorResponse struct {
 	Message string   `json:"message" example:"요청 처리 중 오류가 발생했습니다."`     // 오류 메시지
 	Errors  []string `json:"errors,omitempty" example:"[\"필드 검증 실패\"]"`   // 상세 오류 목록
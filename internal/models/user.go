@@ -1,10 +1,37 @@
 package models
 
 import (
+	"strings"
 	"time"
 	"gorm.io/gorm"
 )
 
+const (
+	SignUpStatusInProgress = "IN_PROGRESS"
+	SignUpStatusCompleted  = "COMPLETED"
+
+	EmailVerificationPurposeSignUp       = "sign_up"
+	EmailVerificationPurposeEmailChange  = "email_change"
+	EmailVerificationPurposeReactivation = "reactivation"
+
+	MarketingChannelEmail = "email"
+	MarketingChannelSMS   = "sms"
+	MarketingChannelPush  = "push"
+
+	MarketingSourceSignUp         = "sign_up"
+	MarketingSourceProfile        = "profile"
+	MarketingSourceSettings       = "settings"
+	MarketingSourceReconfirmation = "reconfirmation_notice"
+
+	LockoutScopeAccount = "account"
+	LockoutScopeIP      = "ip"
+
+	// 0016_rbac 마이그레이션이 만드는 기본 권한. 라우트에서 middleware.RequirePermission으로 확인한다.
+	PermissionUsersRead  = "users:read"
+	PermissionRolesRead  = "roles:read"
+	PermissionRolesWrite = "roles:write"
+)
+
 type User struct {
 	ID                     uint      `json:"id" gorm:"primaryKey"`
 	Name                   string    `json:"name" gorm:"size:30;not null"`
@@ -15,33 +42,256 @@ type User struct {
 	ResetPasswordToken     string    `json:"-" gorm:"size:256"`
 	AgreedMarketingOptIn   bool      `json:"agreedMarketingOptIn" gorm:"default:false"`
 	SignUpStatus           string    `json:"signUpStatus" gorm:"size:20;default:IN_PROGRESS"`
+	Locale                 string    `json:"locale" gorm:"size:10"`
 	CreatedAt              time.Time `json:"createdAt"`
 	UpdatedAt              time.Time `json:"updatedAt"`
-	DeletedAt              gorm.DeletedAt `json:"-" gorm:"index"`
+	DeletedAt              gorm.DeletedAt `json:"-" gorm:"index"` // 탈퇴 요청 시간 (복구 기간 동안은 복구 가능)
+	AnonymizedAt           *time.Time `json:"-"`                  // 보관 기간이 지나 개인정보를 지운 시간
+	LastLoginAt            time.Time  `json:"-" gorm:"not null;default:now();index"` // 마지막 로그인(토큰 발급) 시간
+	DormantNotifiedAt      *time.Time `json:"-"`                  // 휴면 전환 사전 안내 메일을 보낸 시간
+	DormantAt              *time.Time `json:"-"`                  // 휴면 전환 시간 (개인정보는 dormant_users로 분리 보관)
+	MarketingConfirmedAt   *time.Time `json:"-"`                  // 마케팅 수신 동의(또는 재확인 안내) 시간, 2년마다 재확인 안내
 }
 
-type EmailVerification struct {
-	ID               uint      `json:"id" gorm:"primaryKey"`
-	Email            string    `json:"email" gorm:"size:60;not null;index"`
-	VerificationCode string    `json:"verificationCode" gorm:"size:10;not null"`
-	ExpiresAt        time.Time `json:"expiresAt" gorm:"not null"`
-	VerifiedAt       *time.Time `json:"verifiedAt"`
+// MarketingChannels는 마케팅 수신 동의를 받는 채널이다.
+var MarketingChannels = []string{MarketingChannelEmail, MarketingChannelSMS, MarketingChannelPush}
+
+// MarketingConsent는 마케팅 수신 동의/철회 이력이다. 변경할 때마다 행을 추가하며 수정하지 않는다.
+type MarketingConsent struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	UserID       uint      `json:"-" gorm:"not null;index"`
+	Channel      string    `json:"channel" gorm:"size:10;not null"`      // email, sms, push
+	Agreed       bool      `json:"agreed" gorm:"not null"`
+	Source       string    `json:"source" gorm:"size:30;not null"`       // sign_up, profile, settings, reconfirmation_notice (기존 동의는 migration)
+	TermsVersion string    `json:"termsVersion" gorm:"size:30;not null"` // 동의 당시 마케팅 수신 약관 버전
+	IPAddress    string    `json:"ipAddress" gorm:"size:45"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// DormantUser는 휴면 전환된 사용자의 개인정보다. 활성 사용자 테이블(users)과 분리해 보관한다.
+type DormantUser struct {
+	UserID               uint      `json:"userId" gorm:"primaryKey;autoIncrement:false"`
+	Name                 string    `json:"name" gorm:"size:30;not null"`
+	Email                string    `json:"email" gorm:"size:60;not null;uniqueIndex"`
+	EncryptedPassword    string    `json:"-" gorm:"size:256;not null"`
+	Phone                string    `json:"phone" gorm:"size:30;not null"`
+	AgreedMarketingOptIn bool      `json:"agreedMarketingOptIn" gorm:"not null;default:false"`
+	CreatedAt            time.Time `json:"createdAt"`
+}
+
+// Terms는 약관 문서(이용약관, 개인정보 처리방침, 연령 확인 등)의 한 버전이다.
+// 같은 code 중 effective_at이 지난 가장 최근 버전이 현재 버전이다.
+type Terms struct {
+	ID           uint      `json:"-" gorm:"primaryKey"`
+	Code         string    `json:"code" gorm:"size:30;not null;uniqueIndex:idx_terms_code_version"`    // 문서 종류 (service, privacy, age_14 등)
+	Version      string    `json:"version" gorm:"size:30;not null;uniqueIndex:idx_terms_code_version"` // 문서 버전
+	Title        string    `json:"title" gorm:"size:100;not null"`
+	URL          string    `json:"url" gorm:"size:255;not null"`                                       // 문서 본문 주소
+	Required     bool      `json:"required" gorm:"not null"`                                           // 가입과 이용에 동의가 필요한지 여부
+	DisplayOrder int       `json:"displayOrder" gorm:"not null;default:0"`
+	EffectiveAt  time.Time `json:"effectiveAt" gorm:"not null"`
+	CreatedAt    time.Time `json:"-"`
+}
+
+// TermsAgreement는 사용자가 약관의 특정 버전에 동의한 기록이다.
+type TermsAgreement struct {
+	ID           uint      `json:"-" gorm:"primaryKey"`
+	UserID       uint      `json:"-" gorm:"not null;uniqueIndex:idx_terms_agreements_user_terms"`
+	TermsCode    string    `json:"code" gorm:"size:30;not null;uniqueIndex:idx_terms_agreements_user_terms"`
+	TermsVersion string    `json:"version" gorm:"size:30;not null;uniqueIndex:idx_terms_agreements_user_terms"`
+	IPAddress    string    `json:"-" gorm:"size:45"`
+	AgreedAt     time.Time `json:"agreedAt" gorm:"not null"`
+}
+
+// UserIdentity는 사용자와 연결된 소셜 로그인 계정(카카오, 네이버, 구글)이다.
+type UserIdentity struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	UserID    uint      `json:"-" gorm:"not null;index"`
+	Provider  string    `json:"provider" gorm:"size:20;not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Subject   string    `json:"-" gorm:"size:100;not null;uniqueIndex:idx_user_identities_provider_subject"` // 제공자의 사용자 ID
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Role은 사용자에게 부여하는 권한 묶음이다(예: admin). 사용자는 여러 역할을 가질 수 있다.
+type Role struct {
+	ID          uint         `json:"-" gorm:"primaryKey"`
+	Name        string       `json:"name" gorm:"size:50;not null;uniqueIndex"`
+	Description string       `json:"description" gorm:"size:255;not null;default:''"`
+	Permissions []Permission `json:"permissions" gorm:"many2many:role_permissions"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
+
+// Permission은 "리소스:동작" 형식의 권한이다(예: users:read).
+type Permission struct {
+	ID          uint   `json:"-" gorm:"primaryKey"`
+	Name        string `json:"name" gorm:"size:100;not null;uniqueIndex"`
+	Description string `json:"description" gorm:"size:255;not null;default:''"`
+}
+
+// UserRole은 사용자에게 부여된 역할이다.
+type UserRole struct {
+	UserID    uint      `gorm:"primaryKey"`
+	RoleID    uint      `gorm:"primaryKey;index"`
+	CreatedAt time.Time
+}
+
+// OAuthClient는 이 서비스에 로그인을 위임하는(OpenID Connect) 등록된 앱이다.
+type OAuthClient struct {
+	ID               uint      `json:"-" gorm:"primaryKey"`
+	ClientID         string    `json:"clientId" gorm:"size:64;not null;uniqueIndex"`
+	ClientSecretHash string    `json:"-" gorm:"size:64"`                 // 클라이언트 시크릿의 SHA-256 해시 (공개 클라이언트는 비어 있음)
+	Name             string    `json:"name" gorm:"size:100;not null"`
+	RedirectURIs     string    `json:"redirectUris" gorm:"type:text;not null"` // 허용하는 redirect URI (공백으로 구분, 정확히 일치해야 함)
+	GrantTypes       string    `json:"grantTypes" gorm:"size:100;not null;default:authorization_code"` // 허용하는 grant_type (공백으로 구분)
+	Scopes           string    `json:"scopes" gorm:"type:text;not null;default:''"` // client_credentials로 받을 수 있는 scope (공백으로 구분)
 	CreatedAt        time.Time `json:"createdAt"`
 	UpdatedAt        time.Time `json:"updatedAt"`
 }
 
+// IsPublic은 시크릿 없이 PKCE만으로 토큰을 받는 공개 클라이언트(SPA, 모바일 앱)인지 여부다.
+func (c *OAuthClient) IsPublic() bool {
+	return c.ClientSecretHash == ""
+}
+
+// AllowsRedirectURI는 uri가 등록된 redirect URI 중 하나와 정확히 일치하는지 확인한다.
+func (c *OAuthClient) AllowsRedirectURI(uri string) bool {
+	for _, allowed := range strings.Fields(c.RedirectURIs) {
+		if allowed == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsGrantType은 클라이언트에 grantType이 허용되어 있는지 확인한다.
+func (c *OAuthClient) AllowsGrantType(grantType string) bool {
+	for _, allowed := range strings.Fields(c.GrantTypes) {
+		if allowed == grantType {
+			return true
+		}
+	}
+	return false
+}
+
+// OAuthAuthorizationCode는 /oauth/authorize에서 발급한 인가 코드다. 토큰 발급에 한 번만 사용한다.
+type OAuthAuthorizationCode struct {
+	ID            uint      `gorm:"primaryKey"`
+	CodeHash      string    `gorm:"size:64;not null;uniqueIndex"`
+	ClientID      string    `gorm:"size:64;not null"`
+	UserID        uint      `gorm:"not null"`
+	RedirectURI   string    `gorm:"type:text;not null"`
+	Scope         string    `gorm:"size:255;not null"`
+	Nonce         string    `gorm:"size:255"`
+	CodeChallenge string    `gorm:"size:128;not null"` // PKCE S256 code_challenge
+	ExpiresAt     time.Time `gorm:"not null;index"`
+	CreatedAt     time.Time
+}
+
+// SocialLoginState는 소셜 로그인 시작 시 발급한 state와 PKCE code_verifier다. 콜백에서 한 번만 사용한다.
+type SocialLoginState struct {
+	ID           uint      `gorm:"primaryKey"`
+	StateHash    string    `gorm:"size:64;not null;uniqueIndex"`
+	Provider     string    `gorm:"size:20;not null"`
+	CodeVerifier string    `gorm:"size:128;not null"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
+
+type EmailVerification struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	Email          string     `json:"email" gorm:"size:60;not null;index"`
+	Purpose        string     `json:"purpose" gorm:"size:20;not null;default:sign_up"` // sign_up, email_change 또는 reactivation
+	UserID         *uint      `json:"userId" gorm:"index"`                             // 이메일 변경을 요청한 사용자
+	CodeHash       string     `json:"-" gorm:"column:verification_code;size:60;not null"` // 인증 코드의 bcrypt 해시
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	ExpiresAt      time.Time  `json:"expiresAt" gorm:"not null"`
+	VerifiedAt     *time.Time `json:"verifiedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// EmailChange는 이메일 변경 이력이다. 이전 주소로 보낸 되돌리기 링크의 토큰 해시를 함께 보관한다.
+type EmailChange struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	UserID        uint       `json:"userId" gorm:"not null;index"`
+	OldEmail      string     `json:"oldEmail" gorm:"size:60;not null"`
+	NewEmail      string     `json:"newEmail" gorm:"size:60;not null"`
+	UndoTokenHash string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	UndoExpiresAt time.Time  `json:"undoExpiresAt" gorm:"not null"`
+	UndoneAt      *time.Time `json:"undoneAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
 type LoginFailure struct {
 	ID            uint      `json:"id" gorm:"primaryKey"`
 	Email         string    `json:"email" gorm:"size:60;not null;index"`
 	FailureReason string    `json:"failureReason" gorm:"size:50;not null"`
+	IPAddress     string    `json:"ipAddress" gorm:"size:45"`
 	CreatedAt     time.Time `json:"createdAt"`
 }
 
+// LoginLockout은 계정(이메일) 또는 IP 단위의 로그인 실패 횟수와 잠금 상태다.
+type LoginLockout struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	Scope           string     `json:"scope" gorm:"size:10;not null;uniqueIndex:idx_login_lockouts_scope_identifier"`
+	Identifier      string     `json:"identifier" gorm:"size:100;not null;uniqueIndex:idx_login_lockouts_scope_identifier"`
+	FailureCount    int        `json:"failureCount" gorm:"not null;default:0"`
+	WindowStartedAt time.Time  `json:"windowStartedAt"`
+	LockoutCount    int        `json:"lockoutCount" gorm:"not null;default:0"`
+	LockedUntil     *time.Time `json:"lockedUntil"`
+	Permanent       bool       `json:"permanent" gorm:"not null;default:false"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
 type PasswordResetToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"userId" gorm:"not null"`
+	User      User       `json:"user" gorm:"foreignKey:UserID"`
+	TokenHash string     `json:"-" gorm:"column:token;size:256;not null;index"` // 메일로 보낸 토큰의 SHA-256 해시
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"not null"`
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+type RefreshToken struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	UserID       uint       `json:"userId" gorm:"not null;index"`
+	TokenHash    string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	FamilyID     string     `json:"familyId" gorm:"size:36;not null;index"`
+	ReplacedByID *uint      `json:"replacedById"`
+	ExpiresAt    time.Time  `json:"expiresAt" gorm:"not null"`
+	RevokedAt    *time.Time `json:"revokedAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+type RevokedToken struct {
 	ID        uint      `json:"id" gorm:"primaryKey"`
-	UserID    uint      `json:"userId" gorm:"not null"`
-	User      User      `json:"user" gorm:"foreignKey:UserID"`
-	Token     string    `json:"token" gorm:"size:256;not null"`
-	ExpiresAt time.Time `json:"expiresAt" gorm:"not null"`
+	JTI       string    `json:"jti" gorm:"column:jti;size:36;not null;uniqueIndex"`
+	UserID    uint      `json:"userId" gorm:"not null;index"`
+	ExpiresAt time.Time `json:"expiresAt" gorm:"not null;index"`
 	CreatedAt time.Time `json:"createdAt"`
-}
\ No newline at end of file
+}
+
+type UserTokenRevocation struct {
+	UserID          uint      `json:"userId" gorm:"primaryKey;autoIncrement:false"`
+	RevokedBefore   time.Time `json:"revokedBefore" gorm:"not null"`
+	ExceptSessionID string    `json:"exceptSessionId" gorm:"size:36"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+type UserMFA struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	UserID       uint       `json:"userId" gorm:"not null;uniqueIndex"`
+	Secret       string     `json:"-" gorm:"size:64;not null"`
+	LastUsedStep int64      `json:"-" gorm:"not null;default:0"`
+	ConfirmedAt  *time.Time `json:"confirmedAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+type MFARecoveryCode struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"userId" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"size:64;not null"`
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
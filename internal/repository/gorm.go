@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// NewGormRepositories는 PostgreSQL(GORM) 기반 저장소 묶음을 만든다.
+func NewGormRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		Users:               &gormUserRepository{db: db},
+		DormantUsers:        &gormDormantUserRepository{db: db},
+		UserIdentities:      &gormUserIdentityRepository{db: db},
+		SocialLoginStates:   &gormSocialLoginStateRepository{db: db},
+		OAuthClients:        &gormOAuthClientRepository{db: db},
+		OAuthCodes:          &gormOAuthAuthorizationCodeRepository{db: db},
+		Roles:               &gormRoleRepository{db: db},
+		Terms:               &gormTermsRepository{db: db},
+		MarketingConsents:   &gormMarketingConsentRepository{db: db},
+		EmailVerifications:  &gormEmailVerificationRepository{db: db},
+		EmailChanges:        &gormEmailChangeRepository{db: db},
+		LoginFailures:       &gormLoginFailureRepository{db: db},
+		LoginLockouts:       &gormLoginLockoutRepository{db: db},
+		PasswordResetTokens: &gormPasswordResetTokenRepository{db: db},
+		RefreshTokens:       &gormRefreshTokenRepository{db: db},
+		TokenRevocations:    &gormTokenRevocationRepository{db: db},
+		MFA:                 &gormMFARepository{db: db},
+	}
+}
+
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+type gormUserRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormUserRepository) Create(user *models.User) error {
+	return r.db.Create(user).Error
+}
+
+func (r *gormUserRepository) CreateWithRecords(user *models.User, records SignUpRecords) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		if len(records.TermsAgreements) > 0 {
+			for i := range records.TermsAgreements {
+				records.TermsAgreements[i].UserID = user.ID
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&records.TermsAgreements).Error; err != nil {
+				return err
+			}
+		}
+		if len(records.MarketingConsents) > 0 {
+			for i := range records.MarketingConsents {
+				records.MarketingConsents[i].UserID = user.ID
+			}
+			if err := tx.Create(&records.MarketingConsents).Error; err != nil {
+				return err
+			}
+		}
+		if records.Identity != nil {
+			records.Identity.UserID = user.ID
+			if err := tx.Create(records.Identity).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *gormUserRepository) Save(user *models.User) error {
+	return r.db.Save(user).Error
+}
+
+func (r *gormUserRepository) Delete(user *models.User) error {
+	return r.db.Delete(user).Error
+}
+
+func (r *gormUserRepository) FindByID(id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) FindByEmail(email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) FindCompletedByNameAndPhone(name, phone string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("name = ? AND phone = ? AND sign_up_status = ?", name, phone, models.SignUpStatusCompleted).
+		First(&user).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) UpdateProfile(user *models.User, unmodifiedSince time.Time) (bool, error) {
+	// 클라이언트가 받은 값과 그대로 비교할 수 있도록 PostgreSQL 정밀도(마이크로초)에 맞춘다.
+	now := time.Now().Truncate(time.Microsecond)
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND updated_at = ?", user.ID, unmodifiedSince).
+		Updates(map[string]interface{}{
+			"name":                    user.Name,
+			"phone":                   user.Phone,
+			"agreed_marketing_opt_in": user.AgreedMarketingOptIn,
+			"updated_at":              now,
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	user.UpdatedAt = now
+	return true, nil
+}
+
+func (r *gormUserRepository) DeletePermanently(user *models.User) error {
+	return r.db.Unscoped().Delete(user).Error
+}
+
+func (r *gormUserRepository) FindDeletedByEmail(email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Unscoped().
+		Where("email = ? AND deleted_at IS NOT NULL AND anonymized_at IS NULL", email).
+		First(&user).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) Restore(id uint) error {
+	return r.db.Unscoped().Model(&models.User{}).
+		Where("id = ? AND anonymized_at IS NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now().Truncate(time.Microsecond)}).Error
+}
+
+func (r *gormUserRepository) FindDeletedBefore(before time.Time, limit int) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND anonymized_at IS NULL", before).
+		Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+func (r *gormUserRepository) Anonymize(id uint, at time.Time) error {
+	return r.db.Unscoped().Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"name":                    "",
+			"email":                   anonymizedEmail(id),
+			"phone":                   "",
+			"encrypted_password":      "",
+			"sign_up_token":           "",
+			"reset_password_token":    "",
+			"agreed_marketing_opt_in": false,
+			"anonymized_at":           at,
+			"updated_at":              at,
+		}).Error
+}
+
+func (r *gormUserRepository) RecordLogin(id uint, at time.Time) error {
+	return r.db.Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"last_login_at": at, "dormant_notified_at": nil}).Error
+}
+
+func (r *gormUserRepository) FindInactiveSince(before time.Time, limit int) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("sign_up_status = ? AND last_login_at < ? AND dormant_notified_at IS NULL AND dormant_at IS NULL",
+		models.SignUpStatusCompleted, before).
+		Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+func (r *gormUserRepository) ClaimDormantNotice(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND dormant_notified_at IS NULL", id).
+		Update("dormant_notified_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *gormUserRepository) ReleaseDormantNotice(id uint) error {
+	return r.db.Model(&models.User{}).Where("id = ?", id).Update("dormant_notified_at", nil).Error
+}
+
+func (r *gormUserRepository) FindDormantCandidates(inactiveBefore, notifiedBefore time.Time, limit int) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("last_login_at < ? AND dormant_notified_at < ? AND dormant_at IS NULL", inactiveBefore, notifiedBefore).
+		Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+func (r *gormUserRepository) UpdateMarketingConsent(id uint, agreed bool, confirmedAt *time.Time) error {
+	return r.db.Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"agreed_marketing_opt_in": agreed,
+			"marketing_confirmed_at":  confirmedAt,
+			"updated_at":              time.Now().Truncate(time.Microsecond),
+		}).Error
+}
+
+func (r *gormUserRepository) FindMarketingReconfirmationDue(before time.Time, limit int) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("agreed_marketing_opt_in AND marketing_confirmed_at < ? AND dormant_at IS NULL", before).
+		Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+func (r *gormUserRepository) SwapMarketingConfirmedAt(id uint, from, to time.Time) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND marketing_confirmed_at = ?", id, from).
+		Update("marketing_confirmed_at", to)
+	return result.RowsAffected > 0, result.Error
+}
+
+type gormMarketingConsentRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormMarketingConsentRepository) Create(consents []models.MarketingConsent) error {
+	if len(consents) == 0 {
+		return nil
+	}
+	return r.db.Create(&consents).Error
+}
+
+func (r *gormMarketingConsentRepository) FindByUserID(userID uint) ([]models.MarketingConsent, error) {
+	var consents []models.MarketingConsent
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&consents).Error
+	return consents, err
+}
+
+func (r *gormMarketingConsentRepository) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.MarketingConsent{}).Error
+}
+
+type gormEmailVerificationRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormEmailVerificationRepository) Create(verification *models.EmailVerification) error {
+	return r.db.Create(verification).Error
+}
+
+func (r *gormEmailVerificationRepository) Save(verification *models.EmailVerification) error {
+	return r.db.Save(verification).Error
+}
+
+func (r *gormEmailVerificationRepository) FindByIDAndEmail(id uint, email, purpose string) (*models.EmailVerification, error) {
+	var verification models.EmailVerification
+	if err := r.db.Where("id = ? AND email = ? AND purpose = ?", id, email, purpose).First(&verification).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &verification, nil
+}
+
+func (r *gormEmailVerificationRepository) FindLatestByEmail(email, purpose string) (*models.EmailVerification, error) {
+	var verification models.EmailVerification
+	if err := r.db.Where("email = ? AND purpose = ?", email, purpose).Order("created_at DESC").First(&verification).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &verification, nil
+}
+
+func (r *gormEmailVerificationRepository) ReserveAttempt(id uint, maxAttempts int) (bool, error) {
+	result := r.db.Model(&models.EmailVerification{}).
+		Where("id = ? AND attempts < ?", id, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *gormEmailVerificationRepository) DeleteByEmail(email string) error {
+	return r.db.Where("email = ?", email).Delete(&models.EmailVerification{}).Error
+}
+
+func (r *gormEmailVerificationRepository) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.EmailVerification{}).Error
+}
+
+type gormEmailChangeRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormEmailChangeRepository) Create(change *models.EmailChange) error {
+	return r.db.Create(change).Error
+}
+
+func (r *gormEmailChangeRepository) FindByUndoTokenHash(tokenHash string) (*models.EmailChange, error) {
+	var change models.EmailChange
+	if err := r.db.Where("undo_token_hash = ?", tokenHash).First(&change).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &change, nil
+}
+
+func (r *gormEmailChangeRepository) UndoIfActive(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.EmailChange{}).
+		Where("id = ? AND undone_at IS NULL AND undo_expires_at > ?", id, at).
+		Update("undone_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *gormEmailChangeRepository) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.EmailChange{}).Error
+}
+
+type gormLoginFailureRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormLoginFailureRepository) Create(failure *models.LoginFailure) error {
+	return r.db.Create(failure).Error
+}
+
+func (r *gormLoginFailureRepository) DeleteByEmail(email string) error {
+	return r.db.Where("email = ?", email).Delete(&models.LoginFailure{}).Error
+}
+
+type gormPasswordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormPasswordResetTokenRepository) Create(token *models.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *gormPasswordResetTokenRepository) FindByHash(tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	if err := r.db.Where("token = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &token, nil
+}
+
+func (r *gormPasswordResetTokenRepository) ConsumeIfActive(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > ?", id, at).
+		Update("used_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *gormPasswordResetTokenRepository) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.PasswordResetToken{}).Error
+}
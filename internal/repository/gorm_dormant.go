@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type gormDormantUserRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormDormantUserRepository) FindByEmail(email string) (*models.DormantUser, error) {
+	var dormant models.DormantUser
+	if err := r.db.Where("email = ?", email).First(&dormant).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &dormant, nil
+}
+
+func (r *gormDormantUserRepository) FindByUserID(userID uint) (*models.DormantUser, error) {
+	var dormant models.DormantUser
+	if err := r.db.Where("user_id = ?", userID).First(&dormant).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &dormant, nil
+}
+
+func (r *gormDormantUserRepository) Move(user *models.User, at time.Time) (bool, error) {
+	moved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND last_login_at = ? AND dormant_at IS NULL", user.ID, user.LastLoginAt).
+			Updates(map[string]interface{}{
+				"name":                    "",
+				"email":                   dormantEmail(user.ID),
+				"phone":                   "",
+				"encrypted_password":      "",
+				"agreed_marketing_opt_in": false,
+				"dormant_at":              at,
+				"updated_at":              at,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		dormant := models.DormantUser{
+			UserID:               user.ID,
+			Name:                 user.Name,
+			Email:                user.Email,
+			EncryptedPassword:    user.EncryptedPassword,
+			Phone:                user.Phone,
+			AgreedMarketingOptIn: user.AgreedMarketingOptIn,
+			CreatedAt:            at,
+		}
+		if err := tx.Create(&dormant).Error; err != nil {
+			return err
+		}
+
+		// 폐기 시각은 at(작업 기준 시각)이 아닌 실제 시각이어야 다시 활성화한 뒤 발급한 토큰이 폐기되지 않는다.
+		revokedAt := time.Now()
+		if err := (&gormPasswordResetTokenRepository{db: tx}).DeleteByUserID(user.ID); err != nil {
+			return err
+		}
+		if err := (&gormTokenRevocationRepository{db: tx}).RevokeAllForUser(user.ID, revokedAt, ""); err != nil {
+			return err
+		}
+		if err := (&gormRefreshTokenRepository{db: tx}).RevokeAllForUser(user.ID, "", revokedAt); err != nil {
+			return err
+		}
+		moved = true
+		return nil
+	})
+	return moved, err
+}
+
+func (r *gormDormantUserRepository) Reactivate(userID uint, at time.Time) (bool, error) {
+	reactivated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var dormant models.DormantUser
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", userID).
+			First(&dormant).Error
+		if err != nil {
+			return translateError(err)
+		}
+
+		err = tx.Model(&models.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{
+				"name":                    dormant.Name,
+				"email":                   dormant.Email,
+				"phone":                   dormant.Phone,
+				"encrypted_password":      dormant.EncryptedPassword,
+				"agreed_marketing_opt_in": dormant.AgreedMarketingOptIn,
+				"dormant_at":              nil,
+				"dormant_notified_at":     nil,
+				"last_login_at":           at,
+				"updated_at":              at,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&dormant).Error; err != nil {
+			return err
+		}
+		reactivated = true
+		return nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return reactivated, err
+}
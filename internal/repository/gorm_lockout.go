@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormLoginLockoutRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormLoginLockoutRepository) Find(scope, identifier string) (*models.LoginLockout, error) {
+	var lockout models.LoginLockout
+	if err := r.db.Where("scope = ? AND identifier = ?", scope, identifier).First(&lockout).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &lockout, nil
+}
+
+func (r *gormLoginLockoutRepository) Update(scope, identifier string, update func(lockout *models.LoginLockout)) (*models.LoginLockout, error) {
+	var lockout models.LoginLockout
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// 처음 실패한 경우에도 행 잠금을 걸 수 있도록 빈 상태를 먼저 만들어 둔다.
+		initial := models.LoginLockout{Scope: scope, Identifier: identifier}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&initial).Error; err != nil {
+			return err
+		}
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("scope = ? AND identifier = ?", scope, identifier).
+			First(&lockout).Error
+		if err != nil {
+			return err
+		}
+
+		update(&lockout)
+		return tx.Save(&lockout).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lockout, nil
+}
+
+func (r *gormLoginLockoutRepository) Delete(scope, identifier string) error {
+	return r.db.Where("scope = ? AND identifier = ?", scope, identifier).Delete(&models.LoginLockout{}).Error
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+type gormMFARepository struct {
+	db *gorm.DB
+}
+
+func (r *gormMFARepository) FindByUserID(userID uint) (*models.UserMFA, error) {
+	var mfa models.UserMFA
+	if err := r.db.Where("user_id = ?", userID).First(&mfa).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &mfa, nil
+}
+
+func (r *gormMFARepository) Save(mfa *models.UserMFA) error {
+	return r.db.Save(mfa).Error
+}
+
+func (r *gormMFARepository) AdvanceLastUsedStep(id uint, step int64) (bool, error) {
+	result := r.db.Model(&models.UserMFA{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Update("last_used_step", step)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *gormMFARepository) DeleteByUserID(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.UserMFA{}).Error
+	})
+}
+
+func (r *gormMFARepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		for _, codeHash := range codeHashes {
+			code := models.MFARecoveryCode{UserID: userID, CodeHash: codeHash}
+			if err := tx.Create(&code).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *gormMFARepository) ConsumeRecoveryCode(userID uint, codeHash string, at time.Time) (bool, error) {
+	result := r.db.Model(&models.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", at)
+	return result.RowsAffected > 0, result.Error
+}
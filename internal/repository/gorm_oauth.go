@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type gormOAuthClientRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormOAuthClientRepository) Create(client *models.OAuthClient) error {
+	return r.db.Create(client).Error
+}
+
+func (r *gormOAuthClientRepository) FindByClientID(clientID string) (*models.OAuthClient, error) {
+	var client models.OAuthClient
+	if err := r.db.Where("client_id = ?", clientID).First(&client).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &client, nil
+}
+
+func (r *gormOAuthClientRepository) FindAll() ([]models.OAuthClient, error) {
+	var clients []models.OAuthClient
+	err := r.db.Order("id").Find(&clients).Error
+	return clients, err
+}
+
+type gormOAuthAuthorizationCodeRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormOAuthAuthorizationCodeRepository) Create(code *models.OAuthAuthorizationCode) error {
+	return r.db.Create(code).Error
+}
+
+func (r *gormOAuthAuthorizationCodeRepository) Consume(codeHash string) (*models.OAuthAuthorizationCode, error) {
+	var codes []models.OAuthAuthorizationCode
+	err := r.db.Clauses(clause.Returning{}).
+		Where("code_hash = ?", codeHash).
+		Delete(&codes).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) == 0 {
+		return nil, ErrNotFound
+	}
+	return &codes[0], nil
+}
+
+func (r *gormOAuthAuthorizationCodeRepository) DeleteExpired(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&models.OAuthAuthorizationCode{}).Error
+}
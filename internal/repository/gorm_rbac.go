@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type gormRoleRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormRoleRepository) FindAll() ([]models.Role, error) {
+	var roles []models.Role
+	err := r.db.Preload("Permissions", orderByName).Order("name").Find(&roles).Error
+	return roles, err
+}
+
+func (r *gormRoleRepository) FindByName(name string) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.Preload("Permissions", orderByName).Where("name = ?", name).First(&role).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &role, nil
+}
+
+func (r *gormRoleRepository) FindByUserID(userID uint) ([]models.Role, error) {
+	var roles []models.Role
+	err := r.db.Preload("Permissions", orderByName).
+		Where("id IN (?)", r.db.Model(&models.UserRole{}).Select("role_id").Where("user_id = ?", userID)).
+		Order("name").Find(&roles).Error
+	return roles, err
+}
+
+func (r *gormRoleRepository) AssignToUser(userID, roleID uint) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.UserRole{UserID: userID, RoleID: roleID, CreatedAt: time.Now()}).Error
+}
+
+func (r *gormRoleRepository) RemoveFromUser(userID, roleID uint) (bool, error) {
+	result := r.db.Where("user_id = ? AND role_id = ?", userID, roleID).Delete(&models.UserRole{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *gormRoleRepository) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.UserRole{}).Error
+}
+
+func orderByName(db *gorm.DB) *gorm.DB {
+	return db.Order("name")
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type gormUserIdentityRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormUserIdentityRepository) Create(identity *models.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+func (r *gormUserIdentityRepository) FindByProviderSubject(provider, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	if err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &identity, nil
+}
+
+func (r *gormUserIdentityRepository) FindByUserID(userID uint) ([]models.UserIdentity, error) {
+	var identities []models.UserIdentity
+	err := r.db.Where("user_id = ?", userID).Order("id").Find(&identities).Error
+	return identities, err
+}
+
+func (r *gormUserIdentityRepository) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.UserIdentity{}).Error
+}
+
+type gormSocialLoginStateRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormSocialLoginStateRepository) Create(state *models.SocialLoginState) error {
+	return r.db.Create(state).Error
+}
+
+func (r *gormSocialLoginStateRepository) Consume(stateHash string) (*models.SocialLoginState, error) {
+	var states []models.SocialLoginState
+	err := r.db.Clauses(clause.Returning{}).
+		Where("state_hash = ?", stateHash).
+		Delete(&states).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(states) == 0 {
+		return nil, ErrNotFound
+	}
+	return &states[0], nil
+}
+
+func (r *gormSocialLoginStateRepository) DeleteExpired(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&models.SocialLoginState{}).Error
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"time"
+)
+
+type gormTermsRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormTermsRepository) Create(terms *models.Terms) error {
+	return r.db.Create(terms).Error
+}
+
+func (r *gormTermsRepository) FindCurrent(at time.Time) ([]models.Terms, error) {
+	var terms []models.Terms
+	err := r.db.Raw(`SELECT DISTINCT ON (code) * FROM terms
+WHERE effective_at <= ?
+ORDER BY code, effective_at DESC, id DESC`, at).Scan(&terms).Error
+	if err != nil {
+		return nil, err
+	}
+	sortTerms(terms)
+	return terms, nil
+}
+
+func (r *gormTermsRepository) FindAgreementsByUserID(userID uint) ([]models.TermsAgreement, error) {
+	var agreements []models.TermsAgreement
+	err := r.db.Where("user_id = ?", userID).Order("agreed_at DESC, id DESC").Find(&agreements).Error
+	return agreements, err
+}
+
+func (r *gormTermsRepository) CreateAgreements(agreements []models.TermsAgreement) error {
+	if len(agreements) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&agreements).Error
+}
+
+func (r *gormTermsRepository) DeleteAgreementsByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.TermsAgreement{}).Error
+}
+
+// sortTerms는 약관을 표시 순서, 문서 종류 순으로 정렬한다.
+func sortTerms(terms []models.Terms) {
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].DisplayOrder != terms[j].DisplayOrder {
+			return terms[i].DisplayOrder < terms[j].DisplayOrder
+		}
+		return terms[i].Code < terms[j].Code
+	})
+}
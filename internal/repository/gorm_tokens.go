@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type gormRefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormRefreshTokenRepository) Create(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *gormRefreshTokenRepository) FindByHash(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &token, nil
+}
+
+func (r *gormRefreshTokenRepository) RevokeIfActive(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *gormRefreshTokenRepository) SetReplacedBy(id, replacedByID uint) error {
+	return r.db.Model(&models.RefreshToken{}).Where("id = ?", id).Update("replaced_by_id", replacedByID).Error
+}
+
+func (r *gormRefreshTokenRepository) RevokeFamily(familyID string, at time.Time) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", at).Error
+}
+
+func (r *gormRefreshTokenRepository) RevokeUserFamily(userID uint, familyID string, at time.Time) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND family_id = ? AND revoked_at IS NULL", userID, familyID).
+		Update("revoked_at", at).Error
+}
+
+func (r *gormRefreshTokenRepository) RevokeAllForUser(userID uint, exceptFamilyID string, at time.Time) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND family_id <> ? AND revoked_at IS NULL", userID, exceptFamilyID).
+		Update("revoked_at", at).Error
+}
+
+type gormTokenRevocationRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormTokenRevocationRepository) RevokeToken(jti string, userID uint, expiresAt time.Time) error {
+	// 이미 만료된 토큰은 검증 단계에서 거부되므로 폐기 목록에 남겨둘 필요가 없다.
+	r.db.Where("expires_at < ?", time.Now()).Delete(&models.RevokedToken{})
+
+	revoked := models.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&revoked).Error
+}
+
+func (r *gormTokenRevocationRepository) IsTokenRevoked(jti string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *gormTokenRevocationRepository) RevokeAllForUser(userID uint, before time.Time, exceptSessionID string) error {
+	revocation := models.UserTokenRevocation{
+		UserID:          userID,
+		RevokedBefore:   before,
+		ExceptSessionID: exceptSessionID,
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revoked_before", "except_session_id", "updated_at"}),
+	}).Create(&revocation).Error
+}
+
+func (r *gormTokenRevocationRepository) GetUserRevocation(userID uint) (*models.UserTokenRevocation, error) {
+	var revocation models.UserTokenRevocation
+	if err := r.db.Where("user_id = ?", userID).First(&revocation).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &revocation, nil
+}
@@ -0,0 +1,680 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"errors"
+	"gorm.io/gorm"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrDuplicateKey는 메모리 저장소에서 유니크 제약을 위반했을 때 반환된다.
+var ErrDuplicateKey = errors.New("duplicate key value violates unique constraint")
+
+// NewMemoryRepositories는 테스트와 로컬 실행을 위한 메모리 기반 저장소 묶음을 만든다.
+// 조회 결과는 복사본이므로 GORM 구현과 마찬가지로 Save 전까지 저장된 값에 영향을 주지 않는다.
+func NewMemoryRepositories() *Repositories {
+	terms := &memoryTermsRepository{}
+	marketingConsents := &memoryMarketingConsentRepository{}
+	identities := &memoryUserIdentityRepository{}
+	users := &memoryUserRepository{users: map[uint]models.User{}, terms: terms, marketingConsents: marketingConsents, identities: identities}
+	passwordResetTokens := &memoryPasswordResetTokenRepository{tokens: map[uint]models.PasswordResetToken{}}
+	refreshTokens := &memoryRefreshTokenRepository{tokens: map[uint]models.RefreshToken{}}
+	tokenRevocations := &memoryTokenRevocationRepository{
+		revokedTokens:   map[string]models.RevokedToken{},
+		userRevocations: map[uint]models.UserTokenRevocation{},
+	}
+	return &Repositories{
+		Users: users,
+		DormantUsers: &memoryDormantUserRepository{
+			users:               users,
+			dormant:             map[uint]models.DormantUser{},
+			passwordResetTokens: passwordResetTokens,
+			refreshTokens:       refreshTokens,
+			tokenRevocations:    tokenRevocations,
+		},
+		UserIdentities:      identities,
+		SocialLoginStates:   &memorySocialLoginStateRepository{states: map[string]models.SocialLoginState{}},
+		OAuthClients:        &memoryOAuthClientRepository{clients: map[string]models.OAuthClient{}},
+		OAuthCodes:          &memoryOAuthAuthorizationCodeRepository{codes: map[string]models.OAuthAuthorizationCode{}},
+		Roles:               newMemoryRoleRepository(),
+		Terms:               terms,
+		MarketingConsents:   marketingConsents,
+		EmailVerifications:  &memoryEmailVerificationRepository{verifications: map[uint]models.EmailVerification{}},
+		EmailChanges:        &memoryEmailChangeRepository{changes: map[uint]models.EmailChange{}},
+		LoginFailures:       &memoryLoginFailureRepository{},
+		LoginLockouts:       &memoryLoginLockoutRepository{lockouts: map[string]models.LoginLockout{}},
+		PasswordResetTokens: passwordResetTokens,
+		RefreshTokens:       refreshTokens,
+		TokenRevocations:    tokenRevocations,
+		MFA:                 &memoryMFARepository{mfas: map[uint]models.UserMFA{}},
+	}
+}
+
+type memoryUserRepository struct {
+	mu     sync.Mutex
+	users  map[uint]models.User
+	nextID uint
+
+	// CreateWithRecords가 가입 기록을 함께 저장하는 저장소
+	terms             *memoryTermsRepository
+	marketingConsents *memoryMarketingConsentRepository
+	identities        *memoryUserIdentityRepository
+}
+
+func (r *memoryUserRepository) Create(user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			return ErrDuplicateKey
+		}
+	}
+
+	r.nextID++
+	now := time.Now()
+	user.ID = r.nextID
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	if user.LastLoginAt.IsZero() {
+		user.LastLoginAt = now
+	}
+	r.users[user.ID] = *user
+	return nil
+}
+
+// CreateWithRecords는 기록 저장이 실패하면 저장한 사용자와 기록을 지워 되돌린다.
+func (r *memoryUserRepository) CreateWithRecords(user *models.User, records SignUpRecords) error {
+	if err := r.Create(user); err != nil {
+		return err
+	}
+
+	for i := range records.TermsAgreements {
+		records.TermsAgreements[i].UserID = user.ID
+	}
+	for i := range records.MarketingConsents {
+		records.MarketingConsents[i].UserID = user.ID
+	}
+	err := r.terms.CreateAgreements(records.TermsAgreements)
+	if err == nil {
+		err = r.marketingConsents.Create(records.MarketingConsents)
+	}
+	if err == nil && records.Identity != nil {
+		records.Identity.UserID = user.ID
+		err = r.identities.Create(records.Identity)
+	}
+	if err != nil {
+		r.terms.DeleteAgreementsByUserID(user.ID)
+		r.marketingConsents.DeleteByUserID(user.ID)
+		r.identities.DeleteByUserID(user.ID)
+		r.DeletePermanently(user)
+		return err
+	}
+	return nil
+}
+
+func (r *memoryUserRepository) Save(user *models.User) error {
+	if user.ID == 0 {
+		return r.Create(user)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, existing := range r.users {
+		if id != user.ID && existing.Email == user.Email {
+			return ErrDuplicateKey
+		}
+	}
+
+	user.UpdatedAt = time.Now()
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r *memoryUserRepository) Delete(user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[user.ID]
+	if !ok {
+		return nil
+	}
+	stored.DeletedAt.Time = time.Now()
+	stored.DeletedAt.Valid = true
+	r.users[user.ID] = stored
+	return nil
+}
+
+func (r *memoryUserRepository) FindByID(id uint) (*models.User, error) {
+	return r.findFirst(func(user models.User) bool {
+		return user.ID == id
+	})
+}
+
+func (r *memoryUserRepository) FindByEmail(email string) (*models.User, error) {
+	return r.findFirst(func(user models.User) bool {
+		return user.Email == email
+	})
+}
+
+func (r *memoryUserRepository) FindCompletedByNameAndPhone(name, phone string) (*models.User, error) {
+	return r.findFirst(func(user models.User) bool {
+		return user.Name == name && user.Phone == phone && user.SignUpStatus == models.SignUpStatusCompleted
+	})
+}
+
+func (r *memoryUserRepository) UpdateProfile(user *models.User, unmodifiedSince time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[user.ID]
+	if !ok || stored.DeletedAt.Valid || !stored.UpdatedAt.Equal(unmodifiedSince) {
+		return false, nil
+	}
+	stored.Name = user.Name
+	stored.Phone = user.Phone
+	stored.AgreedMarketingOptIn = user.AgreedMarketingOptIn
+	stored.UpdatedAt = time.Now().Truncate(time.Microsecond)
+	r.users[user.ID] = stored
+	user.UpdatedAt = stored.UpdatedAt
+	return true, nil
+}
+
+func (r *memoryUserRepository) DeletePermanently(user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.users, user.ID)
+	return nil
+}
+
+func (r *memoryUserRepository) FindDeletedByEmail(email string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.users {
+		if user.Email == email && user.DeletedAt.Valid && user.AnonymizedAt == nil {
+			return &user, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryUserRepository) Restore(id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.AnonymizedAt != nil {
+		return nil
+	}
+	user.DeletedAt = gorm.DeletedAt{}
+	user.UpdatedAt = time.Now().Truncate(time.Microsecond)
+	r.users[id] = user
+	return nil
+}
+
+func (r *memoryUserRepository) FindDeletedBefore(before time.Time, limit int) ([]models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var users []models.User
+	for _, user := range r.users {
+		if user.DeletedAt.Valid && user.DeletedAt.Time.Before(before) && user.AnonymizedAt == nil {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+func (r *memoryUserRepository) Anonymize(id uint, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil
+	}
+	user.Name = ""
+	user.Email = anonymizedEmail(id)
+	user.Phone = ""
+	user.EncryptedPassword = ""
+	user.SignUpToken = ""
+	user.ResetPasswordToken = ""
+	user.AgreedMarketingOptIn = false
+	user.AnonymizedAt = &at
+	user.UpdatedAt = at
+	r.users[id] = user
+	return nil
+}
+
+func (r *memoryUserRepository) RecordLogin(id uint, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil
+	}
+	user.LastLoginAt = at
+	user.DormantNotifiedAt = nil
+	r.users[id] = user
+	return nil
+}
+
+func (r *memoryUserRepository) FindInactiveSince(before time.Time, limit int) ([]models.User, error) {
+	return r.findAll(limit, func(user models.User) bool {
+		return user.SignUpStatus == models.SignUpStatusCompleted && user.LastLoginAt.Before(before) &&
+			user.DormantNotifiedAt == nil && user.DormantAt == nil
+	})
+}
+
+func (r *memoryUserRepository) ClaimDormantNotice(id uint, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DormantNotifiedAt != nil {
+		return false, nil
+	}
+	user.DormantNotifiedAt = &at
+	r.users[id] = user
+	return true, nil
+}
+
+func (r *memoryUserRepository) ReleaseDormantNotice(id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil
+	}
+	user.DormantNotifiedAt = nil
+	r.users[id] = user
+	return nil
+}
+
+func (r *memoryUserRepository) FindDormantCandidates(inactiveBefore, notifiedBefore time.Time, limit int) ([]models.User, error) {
+	return r.findAll(limit, func(user models.User) bool {
+		return user.LastLoginAt.Before(inactiveBefore) && user.DormantNotifiedAt != nil &&
+			user.DormantNotifiedAt.Before(notifiedBefore) && user.DormantAt == nil
+	})
+}
+
+func (r *memoryUserRepository) UpdateMarketingConsent(id uint, agreed bool, confirmedAt *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil
+	}
+	user.AgreedMarketingOptIn = agreed
+	user.MarketingConfirmedAt = confirmedAt
+	user.UpdatedAt = time.Now().Truncate(time.Microsecond)
+	r.users[id] = user
+	return nil
+}
+
+func (r *memoryUserRepository) FindMarketingReconfirmationDue(before time.Time, limit int) ([]models.User, error) {
+	return r.findAll(limit, func(user models.User) bool {
+		return user.AgreedMarketingOptIn && user.MarketingConfirmedAt != nil &&
+			user.MarketingConfirmedAt.Before(before) && user.DormantAt == nil
+	})
+}
+
+func (r *memoryUserRepository) SwapMarketingConfirmedAt(id uint, from, to time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.MarketingConfirmedAt == nil || !user.MarketingConfirmedAt.Equal(from) {
+		return false, nil
+	}
+	user.MarketingConfirmedAt = &to
+	r.users[id] = user
+	return true, nil
+}
+
+// findAll은 삭제되지 않은 사용자 중 조건에 맞는 사용자를 ID 순으로 limit명까지 반환한다.
+func (r *memoryUserRepository) findAll(limit int, match func(models.User) bool) ([]models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var users []models.User
+	for _, user := range r.users {
+		if !user.DeletedAt.Valid && match(user) {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+// findFirst는 삭제되지 않은 사용자 중 조건에 맞는 가장 작은 ID의 사용자를 반환한다.
+func (r *memoryUserRepository) findFirst(match func(models.User) bool) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var found *models.User
+	for _, user := range r.users {
+		if user.DeletedAt.Valid || !match(user) {
+			continue
+		}
+		if found == nil || user.ID < found.ID {
+			u := user
+			found = &u
+		}
+	}
+	if found == nil {
+		return nil, ErrNotFound
+	}
+	return found, nil
+}
+
+type memoryMarketingConsentRepository struct {
+	mu       sync.Mutex
+	consents []models.MarketingConsent
+	nextID   uint
+}
+
+func (r *memoryMarketingConsentRepository) Create(consents []models.MarketingConsent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range consents {
+		r.nextID++
+		consents[i].ID = r.nextID
+		if consents[i].CreatedAt.IsZero() {
+			consents[i].CreatedAt = time.Now()
+		}
+		r.consents = append(r.consents, consents[i])
+	}
+	return nil
+}
+
+func (r *memoryMarketingConsentRepository) FindByUserID(userID uint) ([]models.MarketingConsent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var consents []models.MarketingConsent
+	for i := len(r.consents) - 1; i >= 0; i-- {
+		if r.consents[i].UserID == userID {
+			consents = append(consents, r.consents[i])
+		}
+	}
+	return consents, nil
+}
+
+func (r *memoryMarketingConsentRepository) DeleteByUserID(userID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.consents[:0]
+	for _, consent := range r.consents {
+		if consent.UserID != userID {
+			kept = append(kept, consent)
+		}
+	}
+	r.consents = kept
+	return nil
+}
+
+type memoryEmailVerificationRepository struct {
+	mu            sync.Mutex
+	verifications map[uint]models.EmailVerification
+	nextID        uint
+}
+
+func (r *memoryEmailVerificationRepository) Create(verification *models.EmailVerification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	now := time.Now()
+	verification.ID = r.nextID
+	if verification.Purpose == "" {
+		verification.Purpose = models.EmailVerificationPurposeSignUp
+	}
+	verification.CreatedAt = now
+	verification.UpdatedAt = now
+	r.verifications[verification.ID] = *verification
+	return nil
+}
+
+func (r *memoryEmailVerificationRepository) Save(verification *models.EmailVerification) error {
+	if verification.ID == 0 {
+		return r.Create(verification)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	verification.UpdatedAt = time.Now()
+	r.verifications[verification.ID] = *verification
+	return nil
+}
+
+func (r *memoryEmailVerificationRepository) FindByIDAndEmail(id uint, email, purpose string) (*models.EmailVerification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	verification, ok := r.verifications[id]
+	if !ok || verification.Email != email || verification.Purpose != purpose {
+		return nil, ErrNotFound
+	}
+	return &verification, nil
+}
+
+func (r *memoryEmailVerificationRepository) FindLatestByEmail(email, purpose string) (*models.EmailVerification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var latest *models.EmailVerification
+	for _, verification := range r.verifications {
+		if verification.Email != email || verification.Purpose != purpose {
+			continue
+		}
+		if latest == nil || verification.ID > latest.ID {
+			v := verification
+			latest = &v
+		}
+	}
+	if latest == nil {
+		return nil, ErrNotFound
+	}
+	return latest, nil
+}
+
+func (r *memoryEmailVerificationRepository) ReserveAttempt(id uint, maxAttempts int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	verification, ok := r.verifications[id]
+	if !ok || verification.Attempts >= maxAttempts {
+		return false, nil
+	}
+	verification.Attempts++
+	r.verifications[id] = verification
+	return true, nil
+}
+
+func (r *memoryEmailVerificationRepository) DeleteByEmail(email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, verification := range r.verifications {
+		if verification.Email == email {
+			delete(r.verifications, id)
+		}
+	}
+	return nil
+}
+
+func (r *memoryEmailVerificationRepository) DeleteByUserID(userID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, verification := range r.verifications {
+		if verification.UserID != nil && *verification.UserID == userID {
+			delete(r.verifications, id)
+		}
+	}
+	return nil
+}
+
+type memoryEmailChangeRepository struct {
+	mu      sync.Mutex
+	changes map[uint]models.EmailChange
+	nextID  uint
+}
+
+func (r *memoryEmailChangeRepository) Create(change *models.EmailChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.changes {
+		if existing.UndoTokenHash == change.UndoTokenHash {
+			return ErrDuplicateKey
+		}
+	}
+
+	r.nextID++
+	change.ID = r.nextID
+	change.CreatedAt = time.Now()
+	r.changes[change.ID] = *change
+	return nil
+}
+
+func (r *memoryEmailChangeRepository) FindByUndoTokenHash(tokenHash string) (*models.EmailChange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, change := range r.changes {
+		if change.UndoTokenHash == tokenHash {
+			return &change, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryEmailChangeRepository) UndoIfActive(id uint, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	change, ok := r.changes[id]
+	if !ok || change.UndoneAt != nil || !change.UndoExpiresAt.After(at) {
+		return false, nil
+	}
+	change.UndoneAt = &at
+	r.changes[id] = change
+	return true, nil
+}
+
+func (r *memoryEmailChangeRepository) DeleteByUserID(userID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, change := range r.changes {
+		if change.UserID == userID {
+			delete(r.changes, id)
+		}
+	}
+	return nil
+}
+
+type memoryLoginFailureRepository struct {
+	mu       sync.Mutex
+	failures []models.LoginFailure
+	nextID   uint
+}
+
+func (r *memoryLoginFailureRepository) Create(failure *models.LoginFailure) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	failure.ID = r.nextID
+	failure.CreatedAt = time.Now()
+	r.failures = append(r.failures, *failure)
+	return nil
+}
+
+func (r *memoryLoginFailureRepository) DeleteByEmail(email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.failures[:0]
+	for _, failure := range r.failures {
+		if failure.Email != email {
+			kept = append(kept, failure)
+		}
+	}
+	r.failures = kept
+	return nil
+}
+
+type memoryPasswordResetTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[uint]models.PasswordResetToken
+	nextID uint
+}
+
+func (r *memoryPasswordResetTokenRepository) Create(token *models.PasswordResetToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	token.ID = r.nextID
+	token.CreatedAt = time.Now()
+	r.tokens[token.ID] = *token
+	return nil
+}
+
+func (r *memoryPasswordResetTokenRepository) FindByHash(tokenHash string) (*models.PasswordResetToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			return &token, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryPasswordResetTokenRepository) ConsumeIfActive(id uint, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[id]
+	if !ok || token.UsedAt != nil || !token.ExpiresAt.After(at) {
+		return false, nil
+	}
+	token.UsedAt = &at
+	r.tokens[id] = token
+	return true, nil
+}
+
+func (r *memoryPasswordResetTokenRepository) DeleteByUserID(userID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.UserID == userID {
+			delete(r.tokens, id)
+		}
+	}
+	return nil
+}
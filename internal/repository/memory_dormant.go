@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"time"
+)
+
+// memoryDormantUserRepository는 users와 한 번에 옮길 수 있도록 사용자 저장소의 잠금을 함께 사용한다.
+type memoryDormantUserRepository struct {
+	users   *memoryUserRepository
+	dormant map[uint]models.DormantUser
+
+	// Move가 함께 폐기하는 토큰 저장소
+	passwordResetTokens *memoryPasswordResetTokenRepository
+	refreshTokens       *memoryRefreshTokenRepository
+	tokenRevocations    *memoryTokenRevocationRepository
+}
+
+func (r *memoryDormantUserRepository) FindByEmail(email string) (*models.DormantUser, error) {
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+
+	for _, dormant := range r.dormant {
+		if dormant.Email == email {
+			return &dormant, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryDormantUserRepository) FindByUserID(userID uint) (*models.DormantUser, error) {
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+
+	dormant, ok := r.dormant[userID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &dormant, nil
+}
+
+func (r *memoryDormantUserRepository) Move(user *models.User, at time.Time) (bool, error) {
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+
+	stored, ok := r.users.users[user.ID]
+	if !ok || stored.DormantAt != nil || !stored.LastLoginAt.Equal(user.LastLoginAt) {
+		return false, nil
+	}
+
+	r.dormant[user.ID] = models.DormantUser{
+		UserID:               stored.ID,
+		Name:                 stored.Name,
+		Email:                stored.Email,
+		EncryptedPassword:    stored.EncryptedPassword,
+		Phone:                stored.Phone,
+		AgreedMarketingOptIn: stored.AgreedMarketingOptIn,
+		CreatedAt:            at,
+	}
+
+	stored.Name = ""
+	stored.Email = dormantEmail(stored.ID)
+	stored.Phone = ""
+	stored.EncryptedPassword = ""
+	stored.AgreedMarketingOptIn = false
+	stored.DormantAt = &at
+	stored.UpdatedAt = at
+	r.users.users[user.ID] = stored
+
+	revokedAt := time.Now()
+	r.passwordResetTokens.DeleteByUserID(user.ID)
+	r.tokenRevocations.RevokeAllForUser(user.ID, revokedAt, "")
+	r.refreshTokens.RevokeAllForUser(user.ID, "", revokedAt)
+	return true, nil
+}
+
+func (r *memoryDormantUserRepository) Reactivate(userID uint, at time.Time) (bool, error) {
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+
+	dormant, ok := r.dormant[userID]
+	if !ok {
+		return false, nil
+	}
+	stored, ok := r.users.users[userID]
+	if !ok {
+		return false, nil
+	}
+
+	stored.Name = dormant.Name
+	stored.Email = dormant.Email
+	stored.Phone = dormant.Phone
+	stored.EncryptedPassword = dormant.EncryptedPassword
+	stored.AgreedMarketingOptIn = dormant.AgreedMarketingOptIn
+	stored.DormantAt = nil
+	stored.DormantNotifiedAt = nil
+	stored.LastLoginAt = at
+	stored.UpdatedAt = at
+	r.users.users[userID] = stored
+	delete(r.dormant, userID)
+	return true, nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"sync"
+	"time"
+)
+
+type memoryLoginLockoutRepository struct {
+	mu       sync.Mutex
+	lockouts map[string]models.LoginLockout
+	nextID   uint
+}
+
+func lockoutKey(scope, identifier string) string {
+	return scope + ":" + identifier
+}
+
+func (r *memoryLoginLockoutRepository) Find(scope, identifier string) (*models.LoginLockout, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lockout, ok := r.lockouts[lockoutKey(scope, identifier)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &lockout, nil
+}
+
+func (r *memoryLoginLockoutRepository) Update(scope, identifier string, update func(lockout *models.LoginLockout)) (*models.LoginLockout, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := lockoutKey(scope, identifier)
+	lockout, ok := r.lockouts[key]
+	if !ok {
+		r.nextID++
+		lockout = models.LoginLockout{ID: r.nextID, Scope: scope, Identifier: identifier}
+	}
+
+	update(&lockout)
+	lockout.UpdatedAt = time.Now()
+	r.lockouts[key] = lockout
+	return &lockout, nil
+}
+
+func (r *memoryLoginLockoutRepository) Delete(scope, identifier string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.lockouts, lockoutKey(scope, identifier))
+	return nil
+}
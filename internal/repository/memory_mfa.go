@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"sync"
+	"time"
+)
+
+type memoryMFARepository struct {
+	mu            sync.Mutex
+	mfas          map[uint]models.UserMFA
+	recoveryCodes []models.MFARecoveryCode
+	nextID        uint
+	nextCodeID    uint
+}
+
+func (r *memoryMFARepository) FindByUserID(userID uint) (*models.UserMFA, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, mfa := range r.mfas {
+		if mfa.UserID == userID {
+			return &mfa, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryMFARepository) Save(mfa *models.UserMFA) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if mfa.ID == 0 {
+		for _, existing := range r.mfas {
+			if existing.UserID == mfa.UserID {
+				return ErrDuplicateKey
+			}
+		}
+		r.nextID++
+		mfa.ID = r.nextID
+		mfa.CreatedAt = now
+	}
+	mfa.UpdatedAt = now
+	r.mfas[mfa.ID] = *mfa
+	return nil
+}
+
+func (r *memoryMFARepository) AdvanceLastUsedStep(id uint, step int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mfa, ok := r.mfas[id]
+	if !ok || mfa.LastUsedStep >= step {
+		return false, nil
+	}
+	mfa.LastUsedStep = step
+	r.mfas[id] = mfa
+	return true, nil
+}
+
+func (r *memoryMFARepository) DeleteByUserID(userID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, mfa := range r.mfas {
+		if mfa.UserID == userID {
+			delete(r.mfas, id)
+		}
+	}
+	r.deleteRecoveryCodes(userID)
+	return nil
+}
+
+func (r *memoryMFARepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteRecoveryCodes(userID)
+	for _, codeHash := range codeHashes {
+		r.nextCodeID++
+		r.recoveryCodes = append(r.recoveryCodes, models.MFARecoveryCode{
+			ID:        r.nextCodeID,
+			UserID:    userID,
+			CodeHash:  codeHash,
+			CreatedAt: time.Now(),
+		})
+	}
+	return nil
+}
+
+func (r *memoryMFARepository) ConsumeRecoveryCode(userID uint, codeHash string, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, code := range r.recoveryCodes {
+		if code.UserID == userID && code.CodeHash == codeHash && code.UsedAt == nil {
+			usedAt := at
+			r.recoveryCodes[i].UsedAt = &usedAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryMFARepository) deleteRecoveryCodes(userID uint) {
+	kept := r.recoveryCodes[:0]
+	for _, code := range r.recoveryCodes {
+		if code.UserID != userID {
+			kept = append(kept, code)
+		}
+	}
+	r.recoveryCodes = kept
+}
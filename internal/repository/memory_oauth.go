@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryOAuthClientRepository struct {
+	mu      sync.Mutex
+	clients map[string]models.OAuthClient
+	nextID  uint
+}
+
+func (r *memoryOAuthClientRepository) Create(client *models.OAuthClient) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.clients[client.ClientID]; ok {
+		return ErrDuplicateKey
+	}
+	r.nextID++
+	client.ID = r.nextID
+	client.CreatedAt = time.Now()
+	client.UpdatedAt = client.CreatedAt
+	r.clients[client.ClientID] = *client
+	return nil
+}
+
+func (r *memoryOAuthClientRepository) FindByClientID(clientID string) (*models.OAuthClient, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client, ok := r.clients[clientID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &client, nil
+}
+
+func (r *memoryOAuthClientRepository) FindAll() ([]models.OAuthClient, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clients := make([]models.OAuthClient, 0, len(r.clients))
+	for _, client := range r.clients {
+		clients = append(clients, client)
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].ID < clients[j].ID })
+	return clients, nil
+}
+
+type memoryOAuthAuthorizationCodeRepository struct {
+	mu     sync.Mutex
+	codes  map[string]models.OAuthAuthorizationCode
+	nextID uint
+}
+
+func (r *memoryOAuthAuthorizationCodeRepository) Create(code *models.OAuthAuthorizationCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.codes[code.CodeHash]; ok {
+		return ErrDuplicateKey
+	}
+	r.nextID++
+	code.ID = r.nextID
+	code.CreatedAt = time.Now()
+	r.codes[code.CodeHash] = *code
+	return nil
+}
+
+func (r *memoryOAuthAuthorizationCodeRepository) Consume(codeHash string) (*models.OAuthAuthorizationCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	code, ok := r.codes[codeHash]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	delete(r.codes, codeHash)
+	return &code, nil
+}
+
+func (r *memoryOAuthAuthorizationCodeRepository) DeleteExpired(before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash, code := range r.codes {
+		if code.ExpiresAt.Before(before) {
+			delete(r.codes, hash)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryRoleRepository struct {
+	mu        sync.Mutex
+	roles     map[uint]models.Role
+	userRoles []models.UserRole
+}
+
+// newMemoryRoleRepository는 0016_rbac 마이그레이션과 같은 기본 역할과 권한으로 채운 저장소를 만든다.
+func newMemoryRoleRepository() *memoryRoleRepository {
+	usersRead := models.Permission{ID: 1, Name: models.PermissionUsersRead, Description: "회원 정보 조회"}
+	rolesRead := models.Permission{ID: 2, Name: models.PermissionRolesRead, Description: "역할과 사용자 역할 조회"}
+	rolesWrite := models.Permission{ID: 3, Name: models.PermissionRolesWrite, Description: "사용자 역할 부여와 회수"}
+
+	now := time.Now()
+	return &memoryRoleRepository{roles: map[uint]models.Role{
+		1: {ID: 1, Name: "admin", Description: "모든 관리 기능", CreatedAt: now,
+			Permissions: []models.Permission{rolesRead, rolesWrite, usersRead}},
+		2: {ID: 2, Name: "support", Description: "고객 지원(회원 정보와 역할 조회)", CreatedAt: now,
+			Permissions: []models.Permission{rolesRead, usersRead}},
+	}}
+}
+
+func (r *memoryRoleRepository) FindAll() ([]models.Role, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.sortedRoles(func(models.Role) bool { return true }), nil
+}
+
+func (r *memoryRoleRepository) FindByName(name string) (*models.Role, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, role := range r.roles {
+		if role.Name == name {
+			return &role, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryRoleRepository) FindByUserID(userID uint) ([]models.Role, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	assigned := map[uint]bool{}
+	for _, userRole := range r.userRoles {
+		if userRole.UserID == userID {
+			assigned[userRole.RoleID] = true
+		}
+	}
+	return r.sortedRoles(func(role models.Role) bool { return assigned[role.ID] }), nil
+}
+
+func (r *memoryRoleRepository) AssignToUser(userID, roleID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, userRole := range r.userRoles {
+		if userRole.UserID == userID && userRole.RoleID == roleID {
+			return nil
+		}
+	}
+	r.userRoles = append(r.userRoles, models.UserRole{UserID: userID, RoleID: roleID, CreatedAt: time.Now()})
+	return nil
+}
+
+func (r *memoryRoleRepository) RemoveFromUser(userID, roleID uint) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, userRole := range r.userRoles {
+		if userRole.UserID == userID && userRole.RoleID == roleID {
+			r.userRoles = append(r.userRoles[:i], r.userRoles[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryRoleRepository) DeleteByUserID(userID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.userRoles[:0]
+	for _, userRole := range r.userRoles {
+		if userRole.UserID != userID {
+			kept = append(kept, userRole)
+		}
+	}
+	r.userRoles = kept
+	return nil
+}
+
+// sortedRoles는 조건에 맞는 역할을 이름순으로 반환한다. 권한 목록은 저장된 값과 공유하지 않도록 복사한다.
+func (r *memoryRoleRepository) sortedRoles(match func(models.Role) bool) []models.Role {
+	var roles []models.Role
+	for _, role := range r.roles {
+		if match(role) {
+			role.Permissions = append([]models.Permission(nil), role.Permissions...)
+			roles = append(roles, role)
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return roles
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"sync"
+	"time"
+)
+
+type memoryUserIdentityRepository struct {
+	mu         sync.Mutex
+	identities []models.UserIdentity
+	nextID     uint
+}
+
+func (r *memoryUserIdentityRepository) Create(identity *models.UserIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.identities {
+		if existing.Provider == identity.Provider && existing.Subject == identity.Subject {
+			return ErrDuplicateKey
+		}
+	}
+	r.nextID++
+	identity.ID = r.nextID
+	identity.CreatedAt = time.Now()
+	r.identities = append(r.identities, *identity)
+	return nil
+}
+
+func (r *memoryUserIdentityRepository) FindByProviderSubject(provider, subject string) (*models.UserIdentity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return &identity, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryUserIdentityRepository) FindByUserID(userID uint) ([]models.UserIdentity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var identities []models.UserIdentity
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+func (r *memoryUserIdentityRepository) DeleteByUserID(userID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.identities[:0]
+	for _, identity := range r.identities {
+		if identity.UserID != userID {
+			kept = append(kept, identity)
+		}
+	}
+	r.identities = kept
+	return nil
+}
+
+type memorySocialLoginStateRepository struct {
+	mu     sync.Mutex
+	states map[string]models.SocialLoginState
+	nextID uint
+}
+
+func (r *memorySocialLoginStateRepository) Create(state *models.SocialLoginState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.states[state.StateHash]; ok {
+		return ErrDuplicateKey
+	}
+	r.nextID++
+	state.ID = r.nextID
+	state.CreatedAt = time.Now()
+	r.states[state.StateHash] = *state
+	return nil
+}
+
+func (r *memorySocialLoginStateRepository) Consume(stateHash string) (*models.SocialLoginState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, ok := r.states[stateHash]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	delete(r.states, stateHash)
+	return &state, nil
+}
+
+func (r *memorySocialLoginStateRepository) DeleteExpired(before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash, state := range r.states {
+		if state.ExpiresAt.Before(before) {
+			delete(r.states, hash)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"sync"
+	"time"
+)
+
+type memoryTermsRepository struct {
+	mu         sync.Mutex
+	terms      []models.Terms
+	agreements []models.TermsAgreement
+	nextID     uint
+}
+
+func (r *memoryTermsRepository) Create(terms *models.Terms) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.terms {
+		if existing.Code == terms.Code && existing.Version == terms.Version {
+			return ErrDuplicateKey
+		}
+	}
+	r.nextID++
+	terms.ID = r.nextID
+	terms.CreatedAt = time.Now()
+	r.terms = append(r.terms, *terms)
+	return nil
+}
+
+func (r *memoryTermsRepository) FindCurrent(at time.Time) ([]models.Terms, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	latest := map[string]models.Terms{}
+	for _, terms := range r.terms {
+		if terms.EffectiveAt.After(at) {
+			continue
+		}
+		if current, ok := latest[terms.Code]; !ok || terms.EffectiveAt.After(current.EffectiveAt) ||
+			(terms.EffectiveAt.Equal(current.EffectiveAt) && terms.ID > current.ID) {
+			latest[terms.Code] = terms
+		}
+	}
+
+	result := make([]models.Terms, 0, len(latest))
+	for _, terms := range latest {
+		result = append(result, terms)
+	}
+	sortTerms(result)
+	return result, nil
+}
+
+func (r *memoryTermsRepository) FindAgreementsByUserID(userID uint) ([]models.TermsAgreement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var agreements []models.TermsAgreement
+	for i := len(r.agreements) - 1; i >= 0; i-- {
+		if r.agreements[i].UserID == userID {
+			agreements = append(agreements, r.agreements[i])
+		}
+	}
+	return agreements, nil
+}
+
+func (r *memoryTermsRepository) CreateAgreements(agreements []models.TermsAgreement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, agreement := range agreements {
+		duplicate := false
+		for _, existing := range r.agreements {
+			if existing.UserID == agreement.UserID && existing.TermsCode == agreement.TermsCode && existing.TermsVersion == agreement.TermsVersion {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			r.nextID++
+			agreement.ID = r.nextID
+			r.agreements = append(r.agreements, agreement)
+		}
+	}
+	return nil
+}
+
+func (r *memoryTermsRepository) DeleteAgreementsByUserID(userID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.agreements[:0]
+	for _, agreement := range r.agreements {
+		if agreement.UserID != userID {
+			kept = append(kept, agreement)
+		}
+	}
+	r.agreements = kept
+	return nil
+}
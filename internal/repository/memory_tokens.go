@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"sync"
+	"time"
+)
+
+type memoryRefreshTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[uint]models.RefreshToken
+	nextID uint
+}
+
+func (r *memoryRefreshTokenRepository) Create(token *models.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.tokens {
+		if existing.TokenHash == token.TokenHash {
+			return ErrDuplicateKey
+		}
+	}
+
+	r.nextID++
+	token.ID = r.nextID
+	token.CreatedAt = time.Now()
+	r.tokens[token.ID] = *token
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) FindByHash(tokenHash string) (*models.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			return &token, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryRefreshTokenRepository) RevokeIfActive(id uint, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[id]
+	if !ok || token.RevokedAt != nil {
+		return false, nil
+	}
+	token.RevokedAt = &at
+	r.tokens[id] = token
+	return true, nil
+}
+
+func (r *memoryRefreshTokenRepository) SetReplacedBy(id, replacedByID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if token, ok := r.tokens[id]; ok {
+		token.ReplacedByID = &replacedByID
+		r.tokens[id] = token
+	}
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeFamily(familyID string, at time.Time) error {
+	r.revokeWhere(at, func(token models.RefreshToken) bool {
+		return token.FamilyID == familyID
+	})
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeUserFamily(userID uint, familyID string, at time.Time) error {
+	r.revokeWhere(at, func(token models.RefreshToken) bool {
+		return token.UserID == userID && token.FamilyID == familyID
+	})
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeAllForUser(userID uint, exceptFamilyID string, at time.Time) error {
+	r.revokeWhere(at, func(token models.RefreshToken) bool {
+		return token.UserID == userID && token.FamilyID != exceptFamilyID
+	})
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) revokeWhere(at time.Time, match func(models.RefreshToken) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.RevokedAt == nil && match(token) {
+			revokedAt := at
+			token.RevokedAt = &revokedAt
+			r.tokens[id] = token
+		}
+	}
+}
+
+type memoryTokenRevocationRepository struct {
+	mu              sync.Mutex
+	revokedTokens   map[string]models.RevokedToken
+	userRevocations map[uint]models.UserTokenRevocation
+}
+
+func (r *memoryTokenRevocationRepository) RevokeToken(jti string, userID uint, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for id, revoked := range r.revokedTokens {
+		if revoked.ExpiresAt.Before(now) {
+			delete(r.revokedTokens, id)
+		}
+	}
+
+	if _, ok := r.revokedTokens[jti]; !ok {
+		r.revokedTokens[jti] = models.RevokedToken{
+			JTI:       jti,
+			UserID:    userID,
+			ExpiresAt: expiresAt,
+			CreatedAt: now,
+		}
+	}
+	return nil
+}
+
+func (r *memoryTokenRevocationRepository) IsTokenRevoked(jti string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.revokedTokens[jti]
+	return ok, nil
+}
+
+func (r *memoryTokenRevocationRepository) RevokeAllForUser(userID uint, before time.Time, exceptSessionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.userRevocations[userID] = models.UserTokenRevocation{
+		UserID:          userID,
+		RevokedBefore:   before,
+		ExceptSessionID: exceptSessionID,
+		UpdatedAt:       time.Now(),
+	}
+	return nil
+}
+
+func (r *memoryTokenRevocationRepository) GetUserRevocation(userID uint) (*models.UserTokenRevocation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revocation, ok := r.userRevocations[userID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &revocation, nil
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"auth-go-service/internal/models"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotFound는 조회 대상이 없을 때 모든 저장소 구현체가 반환하는 오류다.
+var ErrNotFound = errors.New("record not found")
+
+// anonymizedEmail은 개인정보를 지운 사용자의 이메일이다. 유니크 인덱스를 지키기 위해 ID를 넣는다.
+func anonymizedEmail(id uint) string {
+	return fmt.Sprintf("deleted-%d@deleted.invalid", id)
+}
+
+// dormantEmail은 휴면 전환된 사용자 행에 남기는 이메일이다. 실제 이메일은 dormant_users에 보관한다.
+func dormantEmail(id uint) string {
+	return fmt.Sprintf("dormant-%d@dormant.invalid", id)
+}
+
+// SignUpRecords는 가입하는 사용자와 같은 트랜잭션으로 저장할 기록이다. UserID는 사용자를 저장한 뒤 채운다.
+type SignUpRecords struct {
+	TermsAgreements   []models.TermsAgreement
+	MarketingConsents []models.MarketingConsent
+	Identity          *models.UserIdentity // 소셜 가입으로 연결할 계정
+}
+
+type UserRepository interface {
+	Create(user *models.User) error
+	// CreateWithRecords는 사용자와 가입 기록을 한 트랜잭션으로 저장한다. 하나라도 실패하면 아무것도 남기지 않는다.
+	CreateWithRecords(user *models.User, records SignUpRecords) error
+	Save(user *models.User) error
+	Delete(user *models.User) error
+	FindByID(id uint) (*models.User, error)
+	FindByEmail(email string) (*models.User, error)
+	// FindCompletedByNameAndPhone은 가입을 완료한 사용자 중 이름과 전화번호가 일치하는 사용자를 찾는다.
+	FindCompletedByNameAndPhone(name, phone string) (*models.User, error)
+	// UpdateProfile은 updated_at이 unmodifiedSince와 같을 때만 이름, 전화번호, 마케팅 수신 동의를 저장하고, 저장했는지 여부를 반환한다.
+	UpdateProfile(user *models.User, unmodifiedSince time.Time) (bool, error)
+	// DeletePermanently는 소프트 삭제하지 않고 행을 지운다. 참조하는 데이터가 없는 미완료 가입 행에만 사용한다.
+	DeletePermanently(user *models.User) error
+	// FindDeletedByEmail은 탈퇴(소프트 삭제)했지만 아직 개인정보를 지우지 않은 사용자를 찾는다.
+	FindDeletedByEmail(email string) (*models.User, error)
+	Restore(id uint) error
+	// FindDeletedBefore는 before 이전에 탈퇴했고 아직 개인정보를 지우지 않은 사용자를 limit명까지 찾는다.
+	FindDeletedBefore(before time.Time, limit int) ([]models.User, error)
+	// Anonymize는 탈퇴한 사용자의 이름, 이메일, 전화번호 등을 지우고 행은 남겨 둔다(다른 테이블의 참조 유지).
+	Anonymize(id uint, at time.Time) error
+	// RecordLogin은 마지막 로그인 시간을 기록하고 휴면 전환 안내 기록을 지운다.
+	RecordLogin(id uint, at time.Time) error
+	// FindInactiveSince는 before 이후 로그인하지 않았고 아직 휴면 안내를 받지 않은 가입 완료 사용자를 limit명까지 찾는다.
+	FindInactiveSince(before time.Time, limit int) ([]models.User, error)
+	// ClaimDormantNotice는 휴면 안내를 보내지 않은 사용자만 안내 시간을 기록하고, 기록했는지(안내를 보낼 차례인지) 여부를 반환한다.
+	ClaimDormantNotice(id uint, at time.Time) (bool, error)
+	// ReleaseDormantNotice는 안내 메일 발송에 실패했을 때 안내 기록을 지워 다음 실행에서 다시 보내게 한다.
+	ReleaseDormantNotice(id uint) error
+	// FindDormantCandidates는 inactiveBefore 이후 로그인하지 않았고 notifiedBefore 이전에 휴면 안내를 받은 사용자를 limit명까지 찾는다.
+	FindDormantCandidates(inactiveBefore, notifiedBefore time.Time, limit int) ([]models.User, error)
+	// UpdateMarketingConsent는 마케팅 수신 동의 여부와 동의(재확인) 시간을 저장한다.
+	UpdateMarketingConsent(id uint, agreed bool, confirmedAt *time.Time) error
+	// FindMarketingReconfirmationDue는 마케팅 수신에 동의했고 before 이전에 동의(재확인)한 사용자를 limit명까지 찾는다.
+	FindMarketingReconfirmationDue(before time.Time, limit int) ([]models.User, error)
+	// SwapMarketingConfirmedAt은 동의(재확인) 시간이 from일 때만 to로 바꾸고, 바꿨는지 여부를 반환한다.
+	SwapMarketingConfirmedAt(id uint, from, to time.Time) (bool, error)
+}
+
+type UserIdentityRepository interface {
+	Create(identity *models.UserIdentity) error
+	FindByProviderSubject(provider, subject string) (*models.UserIdentity, error)
+	FindByUserID(userID uint) ([]models.UserIdentity, error)
+	DeleteByUserID(userID uint) error
+}
+
+type SocialLoginStateRepository interface {
+	Create(state *models.SocialLoginState) error
+	// Consume은 state를 찾아 지우고 반환한다. 동시에 같은 state로 요청해도 한 번만 반환된다.
+	Consume(stateHash string) (*models.SocialLoginState, error)
+	DeleteExpired(before time.Time) error
+}
+
+type OAuthClientRepository interface {
+	Create(client *models.OAuthClient) error
+	FindByClientID(clientID string) (*models.OAuthClient, error)
+	FindAll() ([]models.OAuthClient, error)
+}
+
+type OAuthAuthorizationCodeRepository interface {
+	Create(code *models.OAuthAuthorizationCode) error
+	// Consume은 인가 코드를 찾아 지우고 반환한다. 동시에 같은 코드로 요청해도 한 번만 반환된다.
+	Consume(codeHash string) (*models.OAuthAuthorizationCode, error)
+	DeleteExpired(before time.Time) error
+}
+
+type RoleRepository interface {
+	// FindAll은 모든 역할을 권한과 함께 이름순으로 반환한다.
+	FindAll() ([]models.Role, error)
+	FindByName(name string) (*models.Role, error)
+	// FindByUserID는 사용자에게 부여된 역할을 권한과 함께 이름순으로 반환한다.
+	FindByUserID(userID uint) ([]models.Role, error)
+	// AssignToUser는 사용자에게 역할을 부여한다. 이미 부여된 역할이면 아무것도 하지 않는다.
+	AssignToUser(userID, roleID uint) error
+	// RemoveFromUser는 사용자의 역할을 회수하며, 실제로 회수했는지 여부를 반환한다.
+	RemoveFromUser(userID, roleID uint) (bool, error)
+	DeleteByUserID(userID uint) error
+}
+
+type TermsRepository interface {
+	Create(terms *models.Terms) error
+	// FindCurrent는 문서 종류별로 at 시점에 시행 중인 가장 최근 버전을 표시 순서대로 반환한다.
+	FindCurrent(at time.Time) ([]models.Terms, error)
+	FindAgreementsByUserID(userID uint) ([]models.TermsAgreement, error)
+	// CreateAgreements는 동의 기록을 추가한다. 이미 동의한 버전은 건너뛴다.
+	CreateAgreements(agreements []models.TermsAgreement) error
+	DeleteAgreementsByUserID(userID uint) error
+}
+
+type MarketingConsentRepository interface {
+	Create(consents []models.MarketingConsent) error
+	// FindByUserID는 사용자의 동의 이력을 최신순으로 반환한다.
+	FindByUserID(userID uint) ([]models.MarketingConsent, error)
+	DeleteByUserID(userID uint) error
+}
+
+type DormantUserRepository interface {
+	FindByEmail(email string) (*models.DormantUser, error)
+	FindByUserID(userID uint) (*models.DormantUser, error)
+	// Move는 사용자의 개인정보를 dormant_users로 옮기고 users 행에서는 지운다.
+	// 같은 트랜잭션으로 모든 세션의 토큰과 남은 비밀번호 재설정 링크도 폐기한다.
+	// 조회한 뒤 다시 로그인했거나 이미 휴면 전환된 경우에는 아무것도 하지 않고 false를 반환한다.
+	Move(user *models.User, at time.Time) (bool, error)
+	// Reactivate는 보관한 개인정보를 users 행으로 되돌리고 휴면 기록을 지우며, 되돌렸는지 여부를 반환한다.
+	Reactivate(userID uint, at time.Time) (bool, error)
+}
+
+type EmailVerificationRepository interface {
+	Create(verification *models.EmailVerification) error
+	Save(verification *models.EmailVerification) error
+	// FindByIDAndEmail, FindLatestByEmail은 purpose(회원가입, 이메일 변경)가 같은 인증 요청만 찾는다.
+	FindByIDAndEmail(id uint, email, purpose string) (*models.EmailVerification, error)
+	FindLatestByEmail(email, purpose string) (*models.EmailVerification, error)
+	// ReserveAttempt는 시도 횟수가 maxAttempts 미만일 때만 1 늘리고, 늘렸는지(시도할 수 있는지) 여부를 반환한다.
+	ReserveAttempt(id uint, maxAttempts int) (bool, error)
+	DeleteByEmail(email string) error
+	DeleteByUserID(userID uint) error
+}
+
+type EmailChangeRepository interface {
+	Create(change *models.EmailChange) error
+	FindByUndoTokenHash(tokenHash string) (*models.EmailChange, error)
+	// UndoIfActive는 아직 되돌리지 않았고 되돌리기 기한이 지나지 않은 변경만 되돌림 처리하고, 처리했는지 여부를 반환한다.
+	UndoIfActive(id uint, at time.Time) (bool, error)
+	DeleteByUserID(userID uint) error
+}
+
+type LoginFailureRepository interface {
+	Create(failure *models.LoginFailure) error
+	DeleteByEmail(email string) error
+}
+
+type LoginLockoutRepository interface {
+	Find(scope, identifier string) (*models.LoginLockout, error)
+	// Update는 잠금 상태를 잠근(SELECT ... FOR UPDATE) 채로 update를 적용하고 저장한다. 상태가 없으면 새로 만든다.
+	Update(scope, identifier string, update func(lockout *models.LoginLockout)) (*models.LoginLockout, error)
+	Delete(scope, identifier string) error
+}
+
+type PasswordResetTokenRepository interface {
+	Create(token *models.PasswordResetToken) error
+	FindByHash(tokenHash string) (*models.PasswordResetToken, error)
+	// ConsumeIfActive는 아직 사용되지 않았고 만료되지 않은 토큰만 사용 처리하고, 처리했는지 여부를 반환한다.
+	ConsumeIfActive(id uint, at time.Time) (bool, error)
+	DeleteByUserID(userID uint) error
+}
+
+type RefreshTokenRepository interface {
+	Create(token *models.RefreshToken) error
+	FindByHash(tokenHash string) (*models.RefreshToken, error)
+	// RevokeIfActive는 아직 폐기되지 않은 토큰만 폐기하며, 실제로 폐기했는지 여부를 반환한다.
+	RevokeIfActive(id uint, at time.Time) (bool, error)
+	SetReplacedBy(id, replacedByID uint) error
+	RevokeFamily(familyID string, at time.Time) error
+	RevokeUserFamily(userID uint, familyID string, at time.Time) error
+	// RevokeAllForUser는 exceptFamilyID 계열을 제외한 사용자의 모든 리프레시 토큰을 폐기한다.
+	RevokeAllForUser(userID uint, exceptFamilyID string, at time.Time) error
+}
+
+type TokenRevocationRepository interface {
+	RevokeToken(jti string, userID uint, expiresAt time.Time) error
+	IsTokenRevoked(jti string) (bool, error)
+	RevokeAllForUser(userID uint, before time.Time, exceptSessionID string) error
+	GetUserRevocation(userID uint) (*models.UserTokenRevocation, error)
+}
+
+type MFARepository interface {
+	FindByUserID(userID uint) (*models.UserMFA, error)
+	Save(mfa *models.UserMFA) error
+	// AdvanceLastUsedStep은 저장된 값보다 큰 step일 때만 갱신하며, 갱신 여부를 반환한다.
+	AdvanceLastUsedStep(id uint, step int64) (bool, error)
+	// DeleteByUserID는 사용자의 2단계 인증 설정과 복구 코드를 모두 삭제한다.
+	DeleteByUserID(userID uint) error
+	ReplaceRecoveryCodes(userID uint, codeHashes []string) error
+	// ConsumeRecoveryCode는 사용하지 않은 복구 코드를 사용 처리하며, 처리 여부를 반환한다.
+	ConsumeRecoveryCode(userID uint, codeHash string, at time.Time) (bool, error)
+}
+
+// Repositories는 서비스 계층에 주입되는 저장소 묶음이다.
+type Repositories struct {
+	Users               UserRepository
+	DormantUsers        DormantUserRepository
+	UserIdentities      UserIdentityRepository
+	SocialLoginStates   SocialLoginStateRepository
+	OAuthClients        OAuthClientRepository
+	OAuthCodes          OAuthAuthorizationCodeRepository
+	Roles               RoleRepository
+	Terms               TermsRepository
+	MarketingConsents   MarketingConsentRepository
+	EmailVerifications  EmailVerificationRepository
+	EmailChanges        EmailChangeRepository
+	LoginFailures       LoginFailureRepository
+	LoginLockouts       LoginLockoutRepository
+	PasswordResetTokens PasswordResetTokenRepository
+	RefreshTokens       RefreshTokenRepository
+	TokenRevocations    TokenRevocationRepository
+	MFA                 MFARepository
+}
@@ -1,102 +1,287 @@
 package services
 
 import (
-	"auth-go-service/internal/database"
+	"auth-go-service/internal/config"
 	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"crypto/rand"
 	"errors"
 	"fmt"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
-	"math/rand"
+	"math"
+	"math/big"
+	"net/url"
 	"strings"
 	"time"
 )
 
-type AuthService struct {
-	emailService *EmailService
-	jwtSecret    string
-	jwtExpiresIn int
+var (
+	ErrInvalidResetToken            = errors.New("비밀번호 재설정 링크가 만료되었거나 이미 사용되었습니다. 다시 요청해주세요.")
+	ErrUnknownTenant                = errors.New("Unknown tenant")
+	ErrVerificationAttemptsExceeded = errors.New("Too many invalid attempts. Please request a new verification code.")
+	ErrAccountDormant               = errors.New("휴면 상태인 계정의 이메일 주소입니다. 로그인하여 계정을 다시 활성화해주세요.")
+	ErrAccountPendingDeletion       = errors.New("탈퇴 처리 중인 이메일 주소입니다. 계정을 복구하거나 탈퇴 처리가 끝난 뒤 다시 가입해주세요.")
+)
+
+// VerificationCooldownError는 인증 코드를 재발송 대기 시간 안에 다시 요청했을 때 반환된다.
+type VerificationCooldownError struct {
+	RetryAfter time.Duration
+}
+
+func (e *VerificationCooldownError) Error() string {
+	return fmt.Sprintf("Verification email was sent recently. Please try again in %d seconds.", e.RetryAfterSeconds())
+}
+
+// RetryAfterSeconds는 Retry-After 헤더에 넣을 초 단위 대기 시간이다(올림).
+func (e *VerificationCooldownError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// AccountDeletedError는 탈퇴한 계정으로 로그인했을 때 반환된다. RestoreUntil까지는 계정을 복구할 수 있다.
+type AccountDeletedError struct {
+	RestoreUntil time.Time
 }
 
-type JWTClaims struct {
-	UserID uint   `json:"userId"`
-	Email  string `json:"email"`
-	Name   string `json:"name"`
-	jwt.RegisteredClaims
+func (e *AccountDeletedError) Error() string {
+	return fmt.Sprintf("탈퇴한 계정입니다. %s까지 계정을 복구할 수 있습니다.", e.RestoreUntil.UTC().Format("2006-01-02 15:04 UTC"))
+}
+
+type AuthService struct {
+	repos                 *repository.Repositories
+	emailService          *EmailService
+	tokenService          *TokenService
+	mfaService            *MFAService
+	lockoutService        *LockoutService
+	resetURL              string
+	resetURLs             map[string]string
+	resetTokenExpiresIn   time.Duration
+	verifyCodeMaxAttempts int
+	verifyCodeCooldown    time.Duration
+	restorePeriod         time.Duration
+	marketingTermsVersion string
 }
 
-func NewAuthService(emailService *EmailService, jwtSecret string) *AuthService {
+func NewAuthService(cfg *config.Config, repos *repository.Repositories, emailService *EmailService, tokenService *TokenService, mfaService *MFAService, lockoutService *LockoutService) *AuthService {
 	return &AuthService{
-		emailService: emailService,
-		jwtSecret:    jwtSecret,
-		jwtExpiresIn: 60 * 60 * 24, // 24 hours
+		repos:                 repos,
+		emailService:          emailService,
+		tokenService:          tokenService,
+		mfaService:            mfaService,
+		lockoutService:        lockoutService,
+		resetURL:              cfg.PasswordResetURL,
+		resetURLs:             cfg.PasswordResetURLs,
+		resetTokenExpiresIn:   time.Duration(cfg.ResetTokenExpiresIn) * time.Second,
+		verifyCodeMaxAttempts: cfg.VerifyCodeMaxAttempts,
+		verifyCodeCooldown:    time.Duration(cfg.VerifyCodeCooldown) * time.Second,
+		restorePeriod:         time.Duration(cfg.AccountRestorePeriod) * time.Second,
+		marketingTermsVersion: cfg.MarketingTermsVersion,
 	}
 }
 
-func (s *AuthService) Login(email, password string) (*models.LoginResponse, error) {
-	var user models.User
-	if err := database.DB.Where("email = ? AND sign_up_status = ?", email, "COMPLETED").First(&user).Error; err != nil {
-		s.recordLoginFailure(email, "INVALID_EMAIL")
-		failureCount := s.getLoginFailureCount(email)
-		return nil, fmt.Errorf("계정 또는 비밀번호에 오류가 있습니다. (실패횟수: %d)", failureCount)
+// DormantAccountError는 휴면 계정으로 로그인했을 때 반환된다.
+// 이메일로 보낸 인증 코드와 VerificationID로 /auth/reactivate-account를 호출해야 로그인이 완료된다.
+type DormantAccountError struct {
+	VerificationID uint
+}
+
+func (e *DormantAccountError) Error() string {
+	return "휴면 상태인 계정입니다. 이메일로 발송된 인증 코드를 입력하면 계정을 다시 사용할 수 있습니다."
+}
+
+// Login은 비밀번호를 확인한 뒤 토큰을 발급한다.
+// 계정이나 IP(ip)가 잠겨 있으면 *LoginLockedError를 반환하고, 성공하면 계정의 실패 기록을 초기화한다.
+// 2단계 인증을 사용하는 계정이면 토큰 대신 2단계 인증용 임시 토큰(challenge)을 반환한다.
+func (s *AuthService) Login(email, password, ip string) (*models.LoginResponse, *models.MFAChallengeResponse, error) {
+	if err := s.lockoutService.Check(email, ip); err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.repos.Users.FindByEmail(email)
+	if err != nil {
+		// 비밀번호까지 맞으면 복구 가능한 탈퇴 계정임을 알려준다.
+		if deleted := s.findRestorableAccount(email); deleted != nil &&
+			bcrypt.CompareHashAndPassword([]byte(deleted.EncryptedPassword), []byte(password)) == nil {
+			return nil, nil, &AccountDeletedError{RestoreUntil: deleted.DeletedAt.Time.Add(s.restorePeriod)}
+		}
+		if dormant, err := s.repos.DormantUsers.FindByEmail(email); err == nil {
+			if bcrypt.CompareHashAndPassword([]byte(dormant.EncryptedPassword), []byte(password)) != nil {
+				return nil, nil, s.loginFailed(email, ip, "INVALID_PASSWORD")
+			}
+			return nil, nil, s.startReactivation(dormant)
+		}
+		return nil, nil, s.loginFailed(email, ip, "INVALID_EMAIL")
+	}
+	if user.SignUpStatus != models.SignUpStatusCompleted {
+		return nil, nil, s.loginFailed(email, ip, "INVALID_EMAIL")
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
-		s.recordLoginFailure(email, "INVALID_PASSWORD")
-		failureCount := s.getLoginFailureCount(email)
-		return nil, fmt.Errorf("계정 또는 비밀번호에 오류가 있습니다. (실패횟수: %d)", failureCount)
+		return nil, nil, s.loginFailed(email, ip, "INVALID_PASSWORD")
+	}
+
+	if s.mfaService.IsEnabled(user.ID) {
+		challenge, err := s.tokenService.IssueMFAChallenge(*user)
+		return nil, challenge, err
 	}
 
-	token, err := s.generateJWTToken(user)
+	if err := s.lockoutService.RecordSuccess(email); err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.issueLoginTokens(*user)
+	return response, nil, err
+}
+
+// VerifyMFALogin은 2단계 인증용 임시 토큰과 인증 코드(TOTP 또는 복구 코드)를 확인하고 토큰을 발급한다.
+// 잘못된 코드는 비밀번호 실패와 같은 잠금 정책으로 집계된다.
+func (s *AuthService) VerifyMFALogin(mfaToken, code, ip string) (*models.LoginResponse, error) {
+	claims, err := s.tokenService.VerifyMFAChallenge(mfaToken)
 	if err != nil {
+		return nil, errors.New("2단계 인증 요청이 만료되었거나 올바르지 않습니다. 다시 로그인해주세요.")
+	}
+
+	user, err := s.repos.Users.FindByID(claims.UserID)
+	if err != nil || user.SignUpStatus != models.SignUpStatusCompleted {
+		return nil, errors.New("User not found")
+	}
+
+	if err := s.lockoutService.Check(user.Email, ip); err != nil {
 		return nil, err
 	}
 
-	return &models.LoginResponse{
-		Token:     token,
-		ExpiresIn: s.jwtExpiresIn,
-	}, nil
+	if !s.mfaService.VerifyCode(user.ID, code) {
+		s.recordLoginFailure(user.Email, ip, "INVALID_MFA_CODE")
+		if _, err := s.lockoutService.RecordFailure(user.Email, ip); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidMFACode
+	}
+
+	if err := s.lockoutService.RecordSuccess(user.Email); err != nil {
+		return nil, err
+	}
+
+	return s.issueLoginTokens(*user)
+}
+
+// issueLoginTokens는 로그인 토큰을 발급하고, 새 버전이 시행되어 다시 동의해야 하는 필수 약관을 응답에 담는다.
+func (s *AuthService) issueLoginTokens(user models.User) (*models.LoginResponse, error) {
+	pending, err := pendingRequiredTerms(s.repos, user.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.tokenService.IssueTokens(user)
+	if err != nil {
+		return nil, err
+	}
+	response.PendingTerms = pending
+	return response, nil
 }
 
-func (s *AuthService) GetLoginFailureCount(email string) int {
-	return s.getLoginFailureCount(email)
+// startReactivation은 휴면 계정 해제용 인증 코드를 보내고 *DormantAccountError를 반환한다.
+// 재발송 대기 시간 안에 다시 로그인하면 새 코드를 보내지 않고 이전 인증 요청을 알려준다.
+func (s *AuthService) startReactivation(dormant *models.DormantUser) error {
+	verification, code, err := createEmailVerification(s.repos, dormant.Email, models.EmailVerificationPurposeReactivation, &dormant.UserID, s.verifyCodeCooldown)
+	var cooldownErr *VerificationCooldownError
+	if errors.As(err, &cooldownErr) {
+		latest, err := s.repos.EmailVerifications.FindLatestByEmail(dormant.Email, models.EmailVerificationPurposeReactivation)
+		if err != nil {
+			return err
+		}
+		return &DormantAccountError{VerificationID: latest.ID}
+	}
+	if err != nil {
+		return err
+	}
+
+	locale := s.emailService.ResolveLocale()
+	if user, err := s.repos.Users.FindByID(dormant.UserID); err == nil {
+		locale = s.emailService.ResolveLocale(user.Locale)
+	}
+	if err := s.emailService.SendReactivationCodeEmail(dormant.Email, locale, code); err != nil {
+		return errors.New("Failed to send verification email. Please try again.")
+	}
+	return &DormantAccountError{VerificationID: verification.ID}
 }
 
-func (s *AuthService) getLoginFailureCount(email string) int {
-	var count int64
-	oneHourAgo := time.Now().Add(-time.Hour)
+// ReactivateAccount는 로그인 시 받은 인증 코드로 휴면 계정을 해제하고 토큰을 발급한다.
+// 2단계 인증을 사용하는 계정이면 Login과 마찬가지로 2단계 인증용 임시 토큰을 반환한다.
+func (s *AuthService) ReactivateAccount(email, code string, verificationID uint, ip string) (*models.LoginResponse, *models.MFAChallengeResponse, error) {
+	if err := s.lockoutService.Check(email, ip); err != nil {
+		return nil, nil, err
+	}
+
+	dormant, err := s.repos.DormantUsers.FindByEmail(email)
+	if err != nil {
+		return nil, nil, errors.New("Verification request not found or email does not match.")
+	}
+	verification, err := s.repos.EmailVerifications.FindByIDAndEmail(verificationID, email, models.EmailVerificationPurposeReactivation)
+	if err != nil || verification.UserID == nil || *verification.UserID != dormant.UserID {
+		return nil, nil, errors.New("Verification request not found or email does not match.")
+	}
+
+	if err := checkEmailVerification(s.repos, verification, code, s.verifyCodeMaxAttempts); err != nil {
+		return nil, nil, err
+	}
+
+	reactivated, err := s.repos.DormantUsers.Reactivate(dormant.UserID, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !reactivated {
+		return nil, nil, errors.New("Verification request not found or email does not match.")
+	}
+
+	user, err := s.repos.Users.FindByID(dormant.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	database.DB.Model(&models.LoginFailure{}).
-		Where("email = ? AND created_at > ?", email, oneHourAgo).
-		Count(&count)
+	if s.mfaService.IsEnabled(user.ID) {
+		challenge, err := s.tokenService.IssueMFAChallenge(*user)
+		return nil, challenge, err
+	}
 
-	return int(count)
+	if err := s.lockoutService.RecordSuccess(email); err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.issueLoginTokens(*user)
+	return response, nil, err
+}
+
+// loginFailed는 실패를 기록하고 사용자에게 돌려줄 에러를 만든다. 이번 실패로 잠겼다면 *LoginLockedError를 반환한다.
+func (s *AuthService) loginFailed(email, ip, reason string) error {
+	s.recordLoginFailure(email, ip, reason)
+
+	failureCount, err := s.lockoutService.RecordFailure(email, ip)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("계정 또는 비밀번호에 오류가 있습니다. (실패횟수: %d)", failureCount)
 }
 
-func (s *AuthService) recordLoginFailure(email, reason string) {
+func (s *AuthService) recordLoginFailure(email, ip, reason string) {
 	failure := models.LoginFailure{
 		Email:         email,
 		FailureReason: reason,
+		IPAddress:     ip,
 	}
-	database.DB.Create(&failure)
+	s.repos.LoginFailures.Create(&failure)
 }
 
-func (s *AuthService) RequestEmailVerification(email string) (*models.RequestEmailVerificationResponse, error) {
-	code := s.generateVerificationCode()
-	expiresAt := time.Now().Add(10 * time.Minute)
-
-	verification := models.EmailVerification{
-		Email:            email,
-		VerificationCode: code,
-		ExpiresAt:        expiresAt,
-	}
-
-	if err := database.DB.Create(&verification).Error; err != nil {
+// RequestEmailVerification은 인증 코드를 발송한다. 메일 언어는 acceptLanguage(Accept-Language 헤더)로 고른다.
+// 같은 이메일로 재발송 대기 시간 안에 다시 요청하면 *VerificationCooldownError를 반환한다.
+func (s *AuthService) RequestEmailVerification(email, acceptLanguage string) (*models.RequestEmailVerificationResponse, error) {
+	verification, code, err := createEmailVerification(s.repos, email, models.EmailVerificationPurposeSignUp, nil, s.verifyCodeCooldown)
+	if err != nil {
 		return nil, err
 	}
 
-	if err := s.emailService.SendVerificationCodeEmail(email, code); err != nil {
+	locale := s.emailService.ResolveLocale(acceptLanguage)
+	if err := s.emailService.SendVerificationCodeEmail(email, locale, code); err != nil {
 		return nil, errors.New("Failed to send verification email. Please try again.")
 	}
 
@@ -106,47 +291,48 @@ func (s *AuthService) RequestEmailVerification(email string) (*models.RequestEma
 	}, nil
 }
 
+// VerifyEmailAccount는 회원가입용 인증 코드를 확인한다.
+// 인증 요청마다 시도 횟수를 세며, 최대 횟수에 도달하면 코드가 폐기되어 새로 요청해야 한다.
 func (s *AuthService) VerifyEmailAccount(email, code string, verificationID uint) error {
-	var verification models.EmailVerification
-	if err := database.DB.Where("id = ? AND email = ?", verificationID, email).First(&verification).Error; err != nil {
+	verification, err := s.repos.EmailVerifications.FindByIDAndEmail(verificationID, email, models.EmailVerificationPurposeSignUp)
+	if err != nil {
 		return errors.New("Verification request not found or email does not match.")
 	}
 
-	if verification.VerifiedAt != nil {
-		return errors.New("This email verification request has already been completed.")
+	return checkEmailVerification(s.repos, verification, code, s.verifyCodeMaxAttempts)
+}
+
+// SignUp은 이메일 인증을 마친 사용자를 가입시킨다.
+// 요청에 locale이 없으면 acceptLanguage(Accept-Language 헤더)로 안내 메일 언어를 정해 저장한다.
+// 현재 시행 중인 필수 약관에 모두 동의해야 하며, 동의한 약관과 마케팅 수신 동의(모든 채널)는 ip와 함께 기록한다.
+func (s *AuthService) SignUp(req *models.SignUpRequest, acceptLanguage, ip string) (*models.LoginResponse, error) {
+	emailVerification, err := s.repos.EmailVerifications.FindLatestByEmail(req.Email, models.EmailVerificationPurposeSignUp)
+	if err != nil {
+		return nil, errors.New("이메일 주소가 인증되지 않았습니다. 이메일 인증 후 다시 시도해주세요.")
 	}
 
-	if time.Now().After(verification.ExpiresAt) {
-		return errors.New("Verification code has expired. Please request a new one.")
+	if emailVerification.VerifiedAt == nil {
+		return nil, errors.New("이메일 주소가 인증되지 않았습니다. 이메일 인증 후 다시 시도해주세요.")
 	}
 
-	if verification.VerificationCode != code {
-		return errors.New("Invalid verification code.")
+	if err := ValidatePassword(req.Password, req.Email); err != nil {
+		return nil, err
 	}
 
 	now := time.Now()
-	verification.VerifiedAt = &now
-	return database.DB.Save(&verification).Error
-}
-
-func (s *AuthService) SignUp(req *models.SignUpRequest) (*models.LoginResponse, error) {
-	var emailVerification models.EmailVerification
-	if err := database.DB.Where("email = ?", req.Email).
-		Order("created_at DESC").
-		First(&emailVerification).Error; err != nil {
-		return nil, errors.New("이메일 주소가 인증되지 않았습니다. 이메일 인증 후 다시 시도해주세요.")
+	currentTerms, err := s.repos.Terms.FindCurrent(now)
+	if err != nil {
+		return nil, err
 	}
-
-	if emailVerification.VerifiedAt == nil {
-		return nil, errors.New("이메일 주소가 인증되지 않았습니다. 이메일 인증 후 다시 시도해주세요.")
+	if err := requireTerms(req.Terms, currentTerms); err != nil {
+		return nil, err
+	}
+	if err := checkTermsCurrent(req.Terms, currentTerms); err != nil {
+		return nil, err
 	}
 
-	var existingUser models.User
-	if err := database.DB.Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
-		if existingUser.SignUpStatus == "COMPLETED" {
-			return nil, errors.New("이미 가입한 이메일 주소입니다.")
-		}
-		database.DB.Delete(&existingUser)
+	if err := s.releaseSignUpEmail(req.Email); err != nil {
+		return nil, err
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
@@ -161,93 +347,163 @@ func (s *AuthService) SignUp(req *models.SignUpRequest) (*models.LoginResponse,
 		EncryptedPassword:    string(hashedPassword),
 		SignUpToken:          uuid.New().String(),
 		AgreedMarketingOptIn: req.AgreedMarketingOptIn,
-		SignUpStatus:         "COMPLETED",
+		SignUpStatus:         models.SignUpStatusCompleted,
+		Locale:               s.emailService.ResolveLocale(req.Locale, acceptLanguage),
 	}
 
-	if err := database.DB.Create(&user).Error; err != nil {
+	if err := s.createUser(&user, nil, req.Terms, currentTerms, ip, now); err != nil {
 		return nil, err
 	}
 
-	token, err := s.generateJWTToken(user)
-	if err != nil {
-		return nil, err
+	return s.tokenService.IssueTokens(user)
+}
+
+// releaseSignUpEmail은 email로 새로 가입할 수 있는지 확인한다.
+// 완료되지 않은 가입 행은 이메일 유니크 인덱스를 계속 차지하지 않도록 완전히 지운다.
+func (s *AuthService) releaseSignUpEmail(email string) error {
+	if existingUser, err := s.repos.Users.FindByEmail(email); err == nil {
+		if existingUser.SignUpStatus == models.SignUpStatusCompleted {
+			return errors.New("이미 가입한 이메일 주소입니다.")
+		}
+		if err := s.repos.PasswordResetTokens.DeleteByUserID(existingUser.ID); err != nil {
+			return err
+		}
+		return s.repos.Users.DeletePermanently(existingUser)
+	}
+	if _, err := s.repos.Users.FindDeletedByEmail(email); err == nil {
+		return ErrAccountPendingDeletion
+	}
+	if _, err := s.repos.DormantUsers.FindByEmail(email); err == nil {
+		return ErrAccountDormant
 	}
+	return nil
+}
 
-	return &models.LoginResponse{
-		Token:     token,
-		ExpiresIn: s.jwtExpiresIn,
-	}, nil
+// createUser는 가입을 마친 사용자를 약관 동의, 마케팅 수신 동의(모든 채널) 기록과 함께 한 트랜잭션으로 저장한다.
+// 기록 저장이 실패해 동의 기록 없는 사용자가 남으면 같은 이메일로 다시 가입할 수 없기 때문이다.
+// 소셜 가입이면 identity도 같은 트랜잭션으로 연결한다.
+func (s *AuthService) createUser(user *models.User, identity *models.UserIdentity, terms []models.AcceptedTerms, currentTerms []models.Terms, ip string, now time.Time) error {
+	if err := checkTermsCurrent(terms, currentTerms); err != nil {
+		return err
+	}
+
+	records := repository.SignUpRecords{TermsAgreements: termsAgreements(0, terms, ip, now), Identity: identity}
+	if user.AgreedMarketingOptIn {
+		records.MarketingConsents = signUpMarketingConsents(ip, s.marketingTermsVersion, now)
+		user.MarketingConfirmedAt = &now
+	}
+	return s.repos.Users.CreateWithRecords(user, records)
+}
+
+// RestoreAccount는 복구 기간 안의 탈퇴 계정을 이메일과 비밀번호로 되살린다.
+// 비밀번호 확인은 로그인과 같은 잠금 정책을 따른다.
+func (s *AuthService) RestoreAccount(email, password, ip string) error {
+	if err := s.lockoutService.Check(email, ip); err != nil {
+		return err
+	}
+
+	user := s.findRestorableAccount(email)
+	if user == nil {
+		return s.loginFailed(email, ip, "INVALID_EMAIL")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		return s.loginFailed(email, ip, "INVALID_PASSWORD")
+	}
+
+	if err := s.repos.Users.Restore(user.ID); err != nil {
+		return err
+	}
+	return s.lockoutService.RecordSuccess(email)
+}
+
+// findRestorableAccount는 복구 기간이 지나지 않은 탈퇴 계정을 찾고, 없으면 nil을 반환한다.
+func (s *AuthService) findRestorableAccount(email string) *models.User {
+	user, err := s.repos.Users.FindDeletedByEmail(email)
+	if err != nil || user.SignUpStatus != models.SignUpStatusCompleted {
+		return nil
+	}
+	if time.Now().After(user.DeletedAt.Time.Add(s.restorePeriod)) {
+		return nil
+	}
+	return user
 }
 
 func (s *AuthService) FindMyEmail(name, phone string) (string, error) {
-	var user models.User
-	if err := database.DB.Where("name = ? AND phone = ? AND sign_up_status = ?", name, phone, "COMPLETED").
-		First(&user).Error; err != nil {
+	user, err := s.repos.Users.FindCompletedByNameAndPhone(name, phone)
+	if err != nil {
 		return "", errors.New("가입한 이메일이 존재하지 않습니다.")
 	}
 
 	return s.maskEmail(user.Email), nil
 }
 
-func (s *AuthService) RequestPasswordReset(email string) error {
-	var user models.User
-	if err := database.DB.Where("email = ?", email).First(&user).Error; err != nil {
-		return errors.New("User not found")
+// RequestPasswordReset은 비밀번호 재설정 메일을 보낸다.
+// tenant가 있으면 PASSWORD_RESET_URLS에 등록된 해당 테넌트의 주소로 링크를 만들고, 이전에 발급한 재설정 토큰은 모두 무효화한다.
+// 메일 언어는 사용자에 저장된 로케일을 우선하고, 없으면 acceptLanguage(Accept-Language 헤더)를 따른다.
+func (s *AuthService) RequestPasswordReset(email, tenant, acceptLanguage string) error {
+	baseURL, err := s.passwordResetURL(tenant)
+	if err != nil {
+		return err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"sub":   user.ID,
-		"email": user.Email,
-		"type":  "password_reset",
-		"exp":   time.Now().Add(time.Hour).Unix(),
-	})
+	user, err := s.repos.Users.FindByEmail(email)
+	if err != nil {
+		return errors.New("User not found")
+	}
 
-	tokenString, err := token.SignedString([]byte(s.jwtSecret))
+	rawToken, err := generateOpaqueToken()
 	if err != nil {
 		return err
 	}
 
+	if err := s.repos.PasswordResetTokens.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+
 	resetToken := models.PasswordResetToken{
 		UserID:    user.ID,
-		Token:     tokenString,
-		ExpiresAt: time.Now().Add(time.Hour),
+		TokenHash: hashToken(rawToken),
+		ExpiresAt: time.Now().Add(s.resetTokenExpiresIn),
 	}
 
-	if err := database.DB.Create(&resetToken).Error; err != nil {
+	if err := s.repos.PasswordResetTokens.Create(&resetToken); err != nil {
 		return err
 	}
 
-	return s.emailService.SendPasswordResetEmail(email, tokenString)
-}
+	resetLink, err := buildTokenLink(baseURL, rawToken)
+	if err != nil {
+		return err
+	}
 
-func (s *AuthService) ResetPassword(tokenString, newPassword string) error {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.jwtSecret), nil
-	})
+	locale := s.emailService.ResolveLocale(user.Locale, acceptLanguage)
+	return s.emailService.SendPasswordResetEmail(email, locale, resetLink, s.resetTokenExpiresIn)
+}
 
-	if err != nil || !token.Valid {
-		return errors.New("Invalid token")
+// ResetPassword는 재설정 토큰을 한 번만 사용할 수 있도록 소비한 뒤 비밀번호를 바꾼다.
+// 남아 있는 재설정 토큰과 기존 로그인 세션은 모두 폐기되고, 계정 잠금(영구 잠금 포함)은 해제된다.
+func (s *AuthService) ResetPassword(rawToken, newPassword string) error {
+	resetToken, err := s.repos.PasswordResetTokens.FindByHash(hashToken(rawToken))
+	if err != nil {
+		return ErrInvalidResetToken
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return errors.New("Invalid token claims")
+	user, err := s.repos.Users.FindByID(resetToken.UserID)
+	if err != nil {
+		return errors.New("User not found")
 	}
 
-	userID := uint(claims["sub"].(float64))
-	email := claims["email"].(string)
-	tokenType := claims["type"].(string)
-
-	if tokenType != "password_reset" {
-		return errors.New("Invalid token type for password reset")
+	// 정책에 맞지 않는 비밀번호로 토큰이 소비되지 않도록 먼저 확인한다.
+	if err := ValidatePassword(newPassword, user.Email); err != nil {
+		return err
 	}
 
-	var user models.User
-	if err := database.DB.Where("id = ? AND email = ?", userID, email).First(&user).Error; err != nil {
-		return errors.New("User not found")
+	consumed, err := s.repos.PasswordResetTokens.ConsumeIfActive(resetToken.ID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrInvalidResetToken
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
@@ -256,53 +512,84 @@ func (s *AuthService) ResetPassword(tokenString, newPassword string) error {
 	}
 
 	user.EncryptedPassword = string(hashedPassword)
-	if err := database.DB.Save(&user).Error; err != nil {
+	if err := s.repos.Users.Save(user); err != nil {
 		return err
 	}
 
-	database.DB.Where("user_id = ?", userID).Delete(&models.PasswordResetToken{})
+	if err := s.repos.PasswordResetTokens.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
 
-	return nil
-}
+	if err := s.lockoutService.Reset(user.Email); err != nil {
+		return err
+	}
 
-func (s *AuthService) VerifyToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.jwtSecret), nil
-	})
+	return s.tokenService.RevokeAllForUser(user.ID, "")
+}
 
-	if err != nil || !token.Valid {
-		return nil, errors.New("Invalid token")
+func (s *AuthService) passwordResetURL(tenant string) (string, error) {
+	if tenant == "" {
+		return s.resetURL, nil
+	}
+	if resetURL, ok := s.resetURLs[tenant]; ok {
+		return resetURL, nil
 	}
+	return "", ErrUnknownTenant
+}
 
-	claims, ok := token.Claims.(*JWTClaims)
-	if !ok {
-		return nil, errors.New("Invalid token claims")
+// buildTokenLink는 프론트엔드 페이지 주소(비밀번호 재설정, 이메일 변경 되돌리기)에 token 쿼리 파라미터를 붙인다.
+func buildTokenLink(baseURL, rawToken string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid link url: %w", err)
 	}
+	query := u.Query()
+	query.Set("token", rawToken)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
 
-	return claims, nil
+func (s *AuthService) RefreshToken(refreshToken string) (*models.LoginResponse, error) {
+	return s.tokenService.Refresh(refreshToken)
 }
 
-func (s *AuthService) generateJWTToken(user models.User) (string, error) {
-	claims := &JWTClaims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Name:   user.Name,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(s.jwtExpiresIn) * time.Second)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+// Logout은 현재 액세스 토큰과 해당 세션의 리프레시 토큰을 폐기한다.
+// allDevices가 true이면 사용자의 모든 세션 토큰을 폐기한다.
+func (s *AuthService) Logout(claims *JWTClaims, allDevices bool) error {
+	if allDevices {
+		return s.tokenService.RevokeAllForUser(claims.UserID, "")
+	}
+
+	if err := s.tokenService.RevokeAccessToken(claims); err != nil {
+		return err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	if claims.SessionID == "" {
+		return nil
+	}
+	return s.tokenService.RevokeSession(claims.UserID, claims.SessionID)
 }
 
-func (s *AuthService) generateVerificationCode() string {
-	rand.Seed(time.Now().UnixNano())
-	return fmt.Sprintf("%06d", rand.Intn(1000000))
+func (s *AuthService) VerifyToken(tokenString string) (*JWTClaims, error) {
+	claims, err := s.tokenService.VerifyAccessToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	// 다른 앱(OpenID Connect 클라이언트)에 위임된 사용자 토큰으로는 이 서비스의 API를 호출할 수 없다.
+	// 서비스 토큰(client_credentials)은 받되, 사용자 전용 API는 미들웨어에서 막는다.
+	if claims.ClientID != "" && !claims.IsService() {
+		return nil, errors.New("Token was issued to a client application")
+	}
+	return claims, nil
+}
+
+// generateVerificationCode는 crypto/rand로 6자리 숫자 코드를 만든다.
+func generateVerificationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
 }
 
 func (s *AuthService) maskEmail(email string) string {
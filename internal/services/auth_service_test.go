@@ -0,0 +1,432 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/mailer"
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"auth-go-service/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAuthService(t *testing.T) (*AuthService, *repository.Repositories) {
+	s, repos, _ := newTestAuthServiceWithMailer(t)
+	return s, repos
+}
+
+func newTestAuthServiceWithMailer(t *testing.T) (*AuthService, *repository.Repositories, *mailer.MemoryMailer) {
+	t.Helper()
+
+	cfg := &config.Config{
+		JWTSecretKey:          "test-secret",
+		JWTExpiresIn:          60 * 15,
+		RefreshTokenExpiresIn: 60 * 60,
+		MFAIssuer:             "Momentir",
+		PasswordResetURL:      "https://app.example.com/reset-password",
+		PasswordResetURLs:     map[string]string{"partner": "https://partner.example.com/reset?from=momentir"},
+		ResetTokenExpiresIn:   60 * 60,
+		VerifyCodeMaxAttempts: 5,
+		VerifyCodeCooldown:    60,
+		LockoutThreshold:      3,
+		LockoutWindow:         60 * 15,
+		LockoutDuration:       60,
+		LockoutMaxDuration:    60 * 60,
+		LockoutPermanentAfter: 2,
+		IPLockoutThreshold:    5,
+		IPLockoutWindow:       60 * 15,
+		IPLockoutDuration:     60 * 15,
+		AccountRestorePeriod:  60 * 60 * 24 * 30,
+		OIDCIssuer:            "https://auth.example.com",
+	}
+	repos := repository.NewMemoryRepositories()
+	tokenService := NewTokenService(cfg, repos, NewHMACKeySet(cfg.JWTSecretKey))
+	mfaService := NewMFAService(repos, cfg.MFAIssuer)
+	lockoutService := NewLockoutService(cfg, repos)
+	mail := mailer.NewMemoryMailer()
+	renderer, err := mailer.NewRenderer(mailer.Brand{ProductName: "Momentir", SupportEmail: "support@example.com"}, "ko")
+	require.NoError(t, err)
+
+	return NewAuthService(cfg, repos, NewEmailService(mail, renderer), tokenService, mfaService, lockoutService), repos, mail
+}
+
+func signUpTestUser(t *testing.T, s *AuthService, repos *repository.Repositories, email, password string) *models.LoginResponse {
+	t.Helper()
+
+	verifiedAt := time.Now()
+	require.NoError(t, repos.EmailVerifications.Create(&models.EmailVerification{
+		Email:      email,
+		CodeHash:   "unused",
+		ExpiresAt:  time.Now().Add(10 * time.Minute),
+		VerifiedAt: &verifiedAt,
+	}))
+
+	response, err := s.SignUp(&models.SignUpRequest{
+		Name:     "홍길동",
+		Email:    email,
+		Phone:    "010-1234-5678",
+		Password: password,
+	}, "", "127.0.0.1")
+	require.NoError(t, err)
+	return response
+}
+
+func TestSignUpAndLoginIssueVerifiableTokens(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+
+	response, challenge, err := s.Login("user@example.com", "password123", "127.0.0.1")
+	require.NoError(t, err)
+	assert.Nil(t, challenge)
+	assert.NotEmpty(t, response.RefreshToken)
+
+	claims, err := s.VerifyToken(response.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", claims.Email)
+	assert.NotEmpty(t, claims.ID)
+
+	_, _, err = s.Login("user@example.com", "wrong-password", "127.0.0.1")
+	assert.EqualError(t, err, "계정 또는 비밀번호에 오류가 있습니다. (실패횟수: 1)")
+}
+
+func TestLoginLockoutBacksOffAndBecomesPermanent(t *testing.T) {
+	s, repos, mail := newTestAuthServiceWithMailer(t)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+
+	// 성공하면 실패 횟수가 초기화된다.
+	for i := 0; i < 2; i++ {
+		_, _, err := s.Login("user@example.com", "wrong-password", "10.0.0.1")
+		require.Error(t, err)
+	}
+	_, _, err := s.Login("user@example.com", "password123", "10.0.0.1")
+	require.NoError(t, err)
+
+	var lockedErr *LoginLockedError
+	for i := 0; i < 3; i++ {
+		_, _, err = s.Login("USER@example.com", "wrong-password", "10.0.0.2")
+	}
+	require.ErrorAs(t, err, &lockedErr)
+	assert.False(t, lockedErr.Permanent)
+	assert.Equal(t, 60, lockedErr.RetryAfterSeconds())
+
+	// 잠금 중에는 올바른 비밀번호로도 로그인할 수 없다.
+	_, _, err = s.Login("user@example.com", "password123", "10.0.0.3")
+	require.ErrorAs(t, err, &lockedErr)
+
+	// 잠금 시간이 지난 뒤 다시 잠기면 영구 잠금된다(LoginLockoutPermanentAfter: 2).
+	_, err = repos.LoginLockouts.Update(models.LockoutScopeAccount, "user@example.com", func(lockout *models.LoginLockout) {
+		expired := time.Now().Add(-time.Second)
+		lockout.LockedUntil = &expired
+	})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, _, err = s.Login("user@example.com", "wrong-password", "10.0.0.4")
+	}
+	require.ErrorAs(t, err, &lockedErr)
+	assert.True(t, lockedErr.Permanent)
+
+	// 비밀번호를 재설정하면 영구 잠금이 해제된다.
+	require.NoError(t, s.RequestPasswordReset("user@example.com", "", ""))
+	require.NoError(t, s.ResetPassword(resetTokenFromMail(t, mail, "user@example.com"), "newpassword123"))
+	_, _, err = s.Login("user@example.com", "newpassword123", "10.0.0.5")
+	assert.NoError(t, err)
+}
+
+func TestLoginLockoutPerIP(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+
+	var err error
+	for i := 0; i < 5; i++ {
+		_, _, err = s.Login(fmt.Sprintf("guess%d@example.com", i), "password123", "10.0.0.1")
+	}
+	var lockedErr *LoginLockedError
+	require.ErrorAs(t, err, &lockedErr)
+
+	_, _, err = s.Login("user@example.com", "password123", "10.0.0.1")
+	assert.ErrorAs(t, err, &lockedErr)
+	_, _, err = s.Login("user@example.com", "password123", "10.0.0.2")
+	assert.NoError(t, err)
+}
+
+func verificationCodeFromMail(t *testing.T, mail *mailer.MemoryMailer, email string) string {
+	t.Helper()
+
+	msg, ok := mail.LastTo(email)
+	require.True(t, ok)
+	match := regexp.MustCompile(`\b(\d{6})\b`).FindStringSubmatch(msg.TextBody)
+	require.NotNil(t, match)
+	return match[1]
+}
+
+func TestRequestEmailVerificationSendsCode(t *testing.T) {
+	s, repos, mail := newTestAuthServiceWithMailer(t)
+
+	response, err := s.RequestEmailVerification("new@example.com", "")
+	require.NoError(t, err)
+
+	msg, ok := mail.LastTo("new@example.com")
+	require.True(t, ok)
+	assert.Equal(t, "[Momentir] 이메일 인증 코드", msg.Subject)
+	code := verificationCodeFromMail(t, mail, "new@example.com")
+	assert.Contains(t, msg.HTMLBody, code)
+
+	// 코드는 해시로만 저장된다.
+	verification, err := repos.EmailVerifications.FindByIDAndEmail(response.VerificationID, "new@example.com", models.EmailVerificationPurposeSignUp)
+	require.NoError(t, err)
+	assert.NotContains(t, verification.CodeHash, code)
+
+	require.NoError(t, s.VerifyEmailAccount("new@example.com", code, response.VerificationID))
+}
+
+func TestEmailVerificationLimitsAttemptsAndResends(t *testing.T) {
+	s, _, mail := newTestAuthServiceWithMailer(t)
+
+	response, err := s.RequestEmailVerification("new@example.com", "")
+	require.NoError(t, err)
+	code := verificationCodeFromMail(t, mail, "new@example.com")
+
+	var cooldownErr *VerificationCooldownError
+	_, err = s.RequestEmailVerification("new@example.com", "")
+	require.ErrorAs(t, err, &cooldownErr)
+	assert.Equal(t, 60, cooldownErr.RetryAfterSeconds())
+
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	for i := 0; i < 4; i++ {
+		assert.Error(t, s.VerifyEmailAccount("new@example.com", wrong, response.VerificationID))
+	}
+	assert.ErrorIs(t, s.VerifyEmailAccount("new@example.com", wrong, response.VerificationID), ErrVerificationAttemptsExceeded)
+
+	// 시도 횟수를 모두 쓰면 올바른 코드도 더 이상 받지 않는다.
+	assert.ErrorIs(t, s.VerifyEmailAccount("new@example.com", code, response.VerificationID), ErrVerificationAttemptsExceeded)
+}
+
+func TestPasswordResetEmailUsesUserLocale(t *testing.T) {
+	s, repos, mail := newTestAuthServiceWithMailer(t)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+
+	user, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "ko", user.Locale)
+
+	// 저장된 로케일이 있으면 Accept-Language보다 우선한다.
+	require.NoError(t, s.RequestPasswordReset("user@example.com", "", "en-US"))
+	msg, ok := mail.LastTo("user@example.com")
+	require.True(t, ok)
+	assert.Equal(t, "[Momentir] 비밀번호 재설정 안내", msg.Subject)
+	assert.Contains(t, msg.TextBody, "support@example.com")
+
+	// 로케일이 없는 기존 사용자는 Accept-Language 중 지원하는 언어를 따른다.
+	user.Locale = ""
+	require.NoError(t, repos.Users.Save(user))
+
+	require.NoError(t, s.RequestPasswordReset("user@example.com", "", "fr-FR, en-US;q=0.8, ko;q=0.5"))
+	msg, ok = mail.LastTo("user@example.com")
+	require.True(t, ok)
+	assert.Equal(t, "[Momentir] Reset your password", msg.Subject)
+}
+
+func resetTokenFromMail(t *testing.T, mail *mailer.MemoryMailer, email string) string {
+	t.Helper()
+
+	msg, ok := mail.LastTo(email)
+	require.True(t, ok)
+	match := regexp.MustCompile(`token=([A-Za-z0-9_-]+)`).FindStringSubmatch(msg.TextBody)
+	require.NotNil(t, match)
+	return match[1]
+}
+
+func TestPasswordResetTokenIsSingleUse(t *testing.T) {
+	s, repos, mail := newTestAuthServiceWithMailer(t)
+	login := signUpTestUser(t, s, repos, "user@example.com", "password123")
+
+	require.NoError(t, s.RequestPasswordReset("user@example.com", "", ""))
+	oldToken := resetTokenFromMail(t, mail, "user@example.com")
+	require.NoError(t, s.RequestPasswordReset("user@example.com", "partner", ""))
+	msg, _ := mail.LastTo("user@example.com")
+	assert.Contains(t, msg.TextBody, "https://partner.example.com/reset?from=momentir&token=")
+	token := resetTokenFromMail(t, mail, "user@example.com")
+
+	// 새 토큰을 발급하면 이전 토큰은 사용할 수 없다.
+	assert.ErrorIs(t, s.ResetPassword(oldToken, "newpassword123"), ErrInvalidResetToken)
+
+	require.NoError(t, s.ResetPassword(token, "newpassword123"))
+	assert.ErrorIs(t, s.ResetPassword(token, "otherpassword123"), ErrInvalidResetToken)
+
+	_, _, err := s.Login("user@example.com", "newpassword123", "127.0.0.1")
+	assert.NoError(t, err)
+
+	// 비밀번호를 재설정하면 기존 세션은 폐기된다.
+	_, err = s.VerifyToken(login.Token)
+	assert.Error(t, err)
+
+	assert.ErrorIs(t, s.RequestPasswordReset("user@example.com", "unknown", ""), ErrUnknownTenant)
+}
+
+func TestRefreshTokenRotationIssuesShortLivedAccessTokens(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	login := signUpTestUser(t, s, repos, "user@example.com", "password123")
+	assert.Equal(t, 60*15, login.ExpiresIn)
+	assert.Equal(t, 60*60, login.RefreshExpiresIn)
+
+	// 리프레시 토큰은 해시로만 저장한다.
+	stored, err := repos.RefreshTokens.FindByHash(hashToken(login.RefreshToken))
+	require.NoError(t, err)
+	assert.NotEqual(t, login.RefreshToken, stored.TokenHash)
+
+	rotated, err := s.RefreshToken(login.RefreshToken)
+	require.NoError(t, err)
+	claims, err := s.VerifyToken(rotated.Token)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+
+	// 교체된 토큰은 같은 계열에 속하고 교체 기록이 남는다.
+	next, err := repos.RefreshTokens.FindByHash(hashToken(rotated.RefreshToken))
+	require.NoError(t, err)
+	assert.Equal(t, stored.FamilyID, next.FamilyID)
+	stored, err = repos.RefreshTokens.FindByHash(hashToken(login.RefreshToken))
+	require.NoError(t, err)
+	assert.NotNil(t, stored.RevokedAt)
+	require.NotNil(t, stored.ReplacedByID)
+	assert.Equal(t, next.ID, *stored.ReplacedByID)
+
+	_, err = s.RefreshToken("unknown-token")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	require.NoError(t, repos.RefreshTokens.Create(&models.RefreshToken{
+		UserID:    claims.UserID,
+		TokenHash: hashToken("expired-token"),
+		FamilyID:  "expired-family",
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}))
+	_, err = s.RefreshToken("expired-token")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	login := signUpTestUser(t, s, repos, "user@example.com", "password123")
+
+	rotated, err := s.RefreshToken(login.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, login.RefreshToken, rotated.RefreshToken)
+
+	_, err = s.RefreshToken(login.RefreshToken)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	// 재사용이 감지되면 정상적으로 교체받은 최신 토큰도 함께 폐기된다.
+	_, err = s.RefreshToken(rotated.RefreshToken)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+}
+
+func TestRevokedAccessTokenIsRejectedByJTI(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	login := signUpTestUser(t, s, repos, "user@example.com", "password123")
+	rotated, err := s.RefreshToken(login.RefreshToken)
+	require.NoError(t, err)
+
+	first, err := s.VerifyToken(login.Token)
+	require.NoError(t, err)
+	second, err := s.VerifyToken(rotated.Token)
+	require.NoError(t, err)
+	assert.NotEmpty(t, first.ID)
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.Equal(t, first.SessionID, second.SessionID)
+
+	// jti로 폐기하므로 같은 세션의 다른 액세스 토큰은 그대로 사용할 수 있다.
+	require.NoError(t, s.tokenService.RevokeAccessToken(first))
+	revoked, err := repos.TokenRevocations.IsTokenRevoked(first.ID)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	_, err = s.VerifyToken(login.Token)
+	assert.Error(t, err)
+	_, err = s.VerifyToken(rotated.Token)
+	assert.NoError(t, err)
+
+	// 만료된 토큰의 폐기 기록은 다음 폐기 때 정리된다.
+	require.NoError(t, repos.TokenRevocations.RevokeToken("expired-jti", first.UserID, time.Now().Add(-time.Minute)))
+	require.NoError(t, s.tokenService.RevokeAccessToken(second))
+	revoked, err = repos.TokenRevocations.IsTokenRevoked("expired-jti")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestLogoutRevokesCurrentSessionOnly(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	first := signUpTestUser(t, s, repos, "user@example.com", "password123")
+	second, _, err := s.Login("user@example.com", "password123", "127.0.0.1")
+	require.NoError(t, err)
+
+	claims, err := s.VerifyToken(first.Token)
+	require.NoError(t, err)
+	require.NoError(t, s.Logout(claims, false))
+
+	_, err = s.VerifyToken(first.Token)
+	assert.Error(t, err)
+	_, err = s.RefreshToken(first.RefreshToken)
+	assert.Error(t, err)
+
+	_, err = s.VerifyToken(second.Token)
+	assert.NoError(t, err)
+}
+
+func TestLogoutAllDevicesRevokesEverySession(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	first := signUpTestUser(t, s, repos, "user@example.com", "password123")
+	second, _, err := s.Login("user@example.com", "password123", "127.0.0.1")
+	require.NoError(t, err)
+
+	claims, err := s.VerifyToken(first.Token)
+	require.NoError(t, err)
+	require.NoError(t, s.Logout(claims, true))
+
+	for _, response := range []*models.LoginResponse{first, second} {
+		_, err = s.VerifyToken(response.Token)
+		assert.Error(t, err)
+		_, err = s.RefreshToken(response.RefreshToken)
+		assert.Error(t, err)
+	}
+}
+
+func TestLoginWithMFARequiresSecondFactor(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+	user, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+
+	enrollment, err := s.mfaService.Enroll(user.ID, user.Email)
+	require.NoError(t, err)
+	code, err := utils.TOTPCode(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+	recovery, err := s.mfaService.Confirm(user.ID, code)
+	require.NoError(t, err)
+	require.Len(t, recovery.RecoveryCodes, recoveryCodeCount)
+
+	response, challenge, err := s.Login("user@example.com", "password123", "127.0.0.1")
+	require.NoError(t, err)
+	assert.Nil(t, response)
+	require.NotNil(t, challenge)
+
+	// 임시 토큰은 액세스 토큰으로 사용할 수 없다.
+	_, err = s.VerifyToken(challenge.MFAToken)
+	assert.Error(t, err)
+
+	// 등록 확인에 쓴 코드는 같은 시간 구간 안에서 다시 사용할 수 없다.
+	_, err = s.VerifyMFALogin(challenge.MFAToken, code, "127.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+
+	loggedIn, err := s.VerifyMFALogin(challenge.MFAToken, recovery.RecoveryCodes[0], "127.0.0.1")
+	require.NoError(t, err)
+	assert.NotEmpty(t, loggedIn.Token)
+
+	_, err = s.VerifyMFALogin(challenge.MFAToken, recovery.RecoveryCodes[0], "127.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+}
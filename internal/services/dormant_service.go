@@ -0,0 +1,108 @@
+package services
+
+import (
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// dormantBatchSize는 휴면 안내와 전환 작업이 한 번에 처리하는 사용자 수다.
+const dormantBatchSize = 100
+
+// DormantService는 장기간 로그인하지 않은 계정을 휴면 전환한다(개인정보 유효기간제).
+// 전환 noticeBefore 전에 안내 메일을 보내고, 안내 후 noticeBefore가 지나야 전환한다.
+type DormantService struct {
+	repos        *repository.Repositories
+	emailService *EmailService
+	period       time.Duration
+	noticeBefore time.Duration
+}
+
+func NewDormantService(cfg *config.Config, repos *repository.Repositories, emailService *EmailService) *DormantService {
+	return &DormantService{
+		repos:        repos,
+		emailService: emailService,
+		period:       time.Duration(cfg.DormantPeriod) * time.Second,
+		noticeBefore: time.Duration(cfg.DormantNoticeBefore) * time.Second,
+	}
+}
+
+// SendDormancyNotices는 곧 휴면 전환될 사용자에게 안내 메일을 보내고, 보낸 수를 반환한다.
+func (s *DormantService) SendDormancyNotices(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	for ctx.Err() == nil {
+		users, err := s.repos.Users.FindInactiveSince(now.Add(s.noticeBefore-s.period), dormantBatchSize)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, user := range users {
+			ok, err := s.sendNotice(user, now)
+			if err != nil {
+				return sent, fmt.Errorf("dormancy notice for user %d: %w", user.ID, err)
+			}
+			if ok {
+				sent++
+			}
+		}
+
+		if len(users) < dormantBatchSize {
+			break
+		}
+	}
+	return sent, nil
+}
+
+// sendNotice는 다른 태스크와 중복 발송하지 않도록 안내 기록을 먼저 선점한 뒤 메일을 보낸다.
+func (s *DormantService) sendNotice(user models.User, now time.Time) (bool, error) {
+	claimed, err := s.repos.Users.ClaimDormantNotice(user.ID, now)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	// 안내 후 noticeBefore가 지나야 전환되므로, 로그인 기록이 오래되었더라도 그보다 앞당기지 않는다.
+	dormantAt := user.LastLoginAt.Add(s.period)
+	if earliest := now.Add(s.noticeBefore); dormantAt.Before(earliest) {
+		dormantAt = earliest
+	}
+
+	locale := s.emailService.ResolveLocale(user.Locale)
+	if err := s.emailService.SendDormancyNoticeEmail(user.Email, locale, dormantAt); err != nil {
+		if releaseErr := s.repos.Users.ReleaseDormantNotice(user.ID); releaseErr != nil {
+			log.Printf("Failed to release dormancy notice for user %d: %v", user.ID, releaseErr)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// MoveInactiveAccounts는 안내 후에도 로그인하지 않은 사용자를 휴면 전환하고, 전환한 수를 반환한다.
+// 개인정보는 dormant_users로 옮기고, 같은 트랜잭션으로 모든 세션의 토큰과 남은 재설정 링크를 폐기한다.
+func (s *DormantService) MoveInactiveAccounts(ctx context.Context, now time.Time) (int, error) {
+	moved := 0
+	for ctx.Err() == nil {
+		users, err := s.repos.Users.FindDormantCandidates(now.Add(-s.period), now.Add(-s.noticeBefore), dormantBatchSize)
+		if err != nil {
+			return moved, err
+		}
+
+		for _, user := range users {
+			ok, err := s.repos.DormantUsers.Move(&user, now)
+			if err != nil {
+				return moved, fmt.Errorf("move user %d to dormant: %w", user.ID, err)
+			}
+			if ok {
+				moved++
+			}
+		}
+
+		if len(users) < dormantBatchSize {
+			break
+		}
+	}
+	return moved, nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDormantService(s *AuthService) *DormantService {
+	cfg := &config.Config{
+		DormantPeriod:       60 * 60 * 24 * 365,
+		DormantNoticeBefore: 60 * 60 * 24 * 30,
+	}
+	return NewDormantService(cfg, s.repos, s.emailService)
+}
+
+func TestDormantAccountIsNoticedMovedAndReactivated(t *testing.T) {
+	s, repos, mail := newTestAuthServiceWithMailer(t)
+	login := signUpTestUser(t, s, repos, "user@example.com", "password123")
+	active := signUpTestUser(t, s, repos, "active@example.com", "password123")
+	dormantService := newTestDormantService(s)
+	ctx := context.Background()
+	day := 24 * time.Hour
+	now := time.Now()
+
+	sent, err := dormantService.SendDormancyNotices(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// 전환 30일 전에 한 번만 안내한다.
+	sent, err = dormantService.SendDormancyNotices(ctx, now.Add(336*day))
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	sent, err = dormantService.SendDormancyNotices(ctx, now.Add(337*day))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	_, ok := mail.LastTo("user@example.com")
+	assert.True(t, ok)
+
+	// 안내 후 로그인한 사용자는 전환하지 않는다.
+	activeClaims, err := s.VerifyToken(active.Token)
+	require.NoError(t, err)
+	require.NoError(t, repos.Users.RecordLogin(activeClaims.UserID, now.Add(340*day)))
+
+	moved, err := dormantService.MoveInactiveAccounts(ctx, now.Add(360*day))
+	require.NoError(t, err)
+	assert.Equal(t, 0, moved)
+	moved, err = dormantService.MoveInactiveAccounts(ctx, now.Add(367*day))
+	require.NoError(t, err)
+	assert.Equal(t, 1, moved)
+
+	// 개인정보는 users에서 분리되고 토큰은 폐기된다.
+	_, err = repos.Users.FindByEmail("user@example.com")
+	assert.Error(t, err)
+	_, err = s.VerifyToken(login.Token)
+	assert.Error(t, err)
+	_, err = s.RefreshToken(login.RefreshToken)
+	assert.Error(t, err)
+	_, err = s.SignUp(&models.SignUpRequest{Name: "홍길동", Email: "user@example.com", Phone: "010-1234-5678", Password: "password123"}, "", "127.0.0.1")
+	assert.ErrorIs(t, err, ErrAccountDormant)
+
+	_, _, err = s.Login("user@example.com", "wrong-password", "127.0.0.1")
+	var dormantErr *DormantAccountError
+	assert.False(t, errors.As(err, &dormantErr))
+
+	_, _, err = s.Login("user@example.com", "password123", "127.0.0.1")
+	require.ErrorAs(t, err, &dormantErr)
+	code := verificationCodeFromMail(t, mail, "user@example.com")
+
+	_, _, err = s.ReactivateAccount("user@example.com", "wrong", dormantErr.VerificationID, "127.0.0.1")
+	assert.Error(t, err)
+	response, _, err := s.ReactivateAccount("user@example.com", code, dormantErr.VerificationID, "127.0.0.1")
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.RefreshToken)
+
+	user, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "홍길동", user.Name)
+	assert.Nil(t, user.DormantAt)
+	_, err = repos.DormantUsers.FindByEmail("user@example.com")
+	assert.Error(t, err)
+}
+
+func TestRefreshRejectsDormantUser(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	login := signUpTestUser(t, s, repos, "user@example.com", "password123")
+
+	// 휴면 전환 직전에 조회되어 폐기되지 않은 리프레시 토큰이라도 휴면 계정에는 토큰을 발급하지 않는다.
+	user, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+	dormantAt := time.Now()
+	user.DormantAt = &dormantAt
+	require.NoError(t, repos.Users.Save(user))
+
+	_, err = s.RefreshToken(login.RefreshToken)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
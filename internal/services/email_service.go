@@ -1,67 +1,39 @@
 package services
 
 import (
-	"fmt"
+	"auth-go-service/internal/mailer"
 	"log"
-	"auth-go-service/internal/config"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/ses"
+	"math"
+	"time"
 )
 
+// verificationCodeExpiresIn은 이메일 인증 코드의 유효 시간(분)이다.
+const verificationCodeExpiresIn = 10
+
 type EmailService struct {
-	sesClient *ses.SES
-	fromEmail string
+	mailer   mailer.Mailer
+	renderer *mailer.Renderer
 }
 
-func NewEmailService(cfg *config.Config) *EmailService {
-	sess, err := session.NewSession(&aws.Config{
-		Region: aws.String(cfg.AWSRegion),
-		Credentials: credentials.NewStaticCredentials(
-			cfg.AWSSESAccessKey,
-			cfg.AWSSESSecretAccessKey,
-			"",
-		),
-	})
-	if err != nil {
-		log.Fatal("Failed to create AWS session:", err)
-	}
-
+func NewEmailService(m mailer.Mailer, renderer *mailer.Renderer) *EmailService {
 	return &EmailService{
-		sesClient: ses.New(sess),
-		fromEmail: cfg.AWSSESFromEmail,
+		mailer:   m,
+		renderer: renderer,
 	}
 }
 
-func (e *EmailService) SendVerificationCodeEmail(email, code string) error {
+// ResolveLocale은 사용자에 저장된 로케일, Accept-Language 헤더 순으로 메일 언어를 고른다.
+func (e *EmailService) ResolveLocale(candidates ...string) string {
+	return e.renderer.ResolveLocale(candidates...)
+}
+
+func (e *EmailService) SendVerificationCodeEmail(email, locale, code string) error {
 	log.Printf("Sending verification code email to %s", email)
-	log.Printf("Verification code: %s", code)
-
-	htmlBody := fmt.Sprintf(`
-		<p>Your verification code is: <strong>%s</strong></p>
-		<p>This code will expire in 10 minutes.</p>
-		<p>If you did not request this, please ignore this email.</p>
-	`, code)
-
-	input := &ses.SendEmailInput{
-		Source: aws.String(e.fromEmail),
-		Destination: &ses.Destination{
-			ToAddresses: []*string{aws.String(email)},
-		},
-		Message: &ses.Message{
-			Subject: &ses.Content{
-				Data: aws.String("Your Email Verification Code"),
-			},
-			Body: &ses.Body{
-				Html: &ses.Content{
-					Data: aws.String(htmlBody),
-				},
-			},
-		},
-	}
 
-	_, err := e.sesClient.SendEmail(input)
+	err := e.send(email, locale, "verification_code", map[string]interface{}{
+		"Code":             code,
+		"ExpiresInMinutes": verificationCodeExpiresIn,
+	})
 	if err != nil {
 		log.Printf("Failed to send verification email: %v", err)
 		return err
@@ -71,36 +43,13 @@ func (e *EmailService) SendVerificationCodeEmail(email, code string) error {
 	return nil
 }
 
-func (e *EmailService) SendPasswordResetEmail(email, token string) error {
+func (e *EmailService) SendPasswordResetEmail(email, locale, resetLink string, expiresIn time.Duration) error {
 	log.Printf("Sending password reset email to %s", email)
-	log.Printf("Reset token: %s", token)
-
-	resetLink := fmt.Sprintf("https://yourdomain.com/auth/reset-password?email=%s&token=%s", email, token)
-
-	htmlBody := fmt.Sprintf(`
-		<p>Click the link below to reset your password:</p>
-		<a href="%s">%s</a>
-		<p>If you didn't request a password reset, please ignore this email.</p>
-	`, resetLink, resetLink)
-
-	input := &ses.SendEmailInput{
-		Source: aws.String(e.fromEmail),
-		Destination: &ses.Destination{
-			ToAddresses: []*string{aws.String(email)},
-		},
-		Message: &ses.Message{
-			Subject: &ses.Content{
-				Data: aws.String("Password Reset Request"),
-			},
-			Body: &ses.Body{
-				Html: &ses.Content{
-					Data: aws.String(htmlBody),
-				},
-			},
-		},
-	}
 
-	_, err := e.sesClient.SendEmail(input)
+	err := e.send(email, locale, "password_reset", map[string]interface{}{
+		"ResetLink":        resetLink,
+		"ExpiresInMinutes": int(expiresIn.Minutes()),
+	})
 	if err != nil {
 		log.Printf("Failed to send password reset email: %v", err)
 		return err
@@ -108,4 +57,131 @@ func (e *EmailService) SendPasswordResetEmail(email, token string) error {
 
 	log.Printf("Password reset email sent successfully to %s", email)
 	return nil
-}
\ No newline at end of file
+}
+
+// SendPasswordChangedEmail은 로그인한 사용자가 비밀번호를 변경했음을 알린다.
+func (e *EmailService) SendPasswordChangedEmail(email, locale string, changedAt time.Time, ip string) error {
+	log.Printf("Sending password changed email to %s", email)
+
+	err := e.send(email, locale, "password_changed", map[string]interface{}{
+		"ChangedAt": changedAt.UTC().Format("2006-01-02 15:04 UTC"),
+		"IPAddress": ip,
+	})
+	if err != nil {
+		log.Printf("Failed to send password changed email: %v", err)
+		return err
+	}
+
+	log.Printf("Password changed email sent successfully to %s", email)
+	return nil
+}
+
+// SendEmailChangeCodeEmail은 변경할 새 이메일 주소로 인증 코드를 보낸다.
+func (e *EmailService) SendEmailChangeCodeEmail(email, locale, code string) error {
+	log.Printf("Sending email change code to %s", email)
+
+	err := e.send(email, locale, "email_change_code", map[string]interface{}{
+		"Code":             code,
+		"ExpiresInMinutes": verificationCodeExpiresIn,
+	})
+	if err != nil {
+		log.Printf("Failed to send email change code: %v", err)
+		return err
+	}
+
+	log.Printf("Email change code sent successfully to %s", email)
+	return nil
+}
+
+// SendEmailChangedEmail은 이전 이메일 주소로 변경 사실과 되돌리기 링크를 알린다.
+func (e *EmailService) SendEmailChangedEmail(oldEmail, locale, newEmail, undoLink string, expiresIn time.Duration) error {
+	log.Printf("Sending email changed notice to %s", oldEmail)
+
+	err := e.send(oldEmail, locale, "email_changed", map[string]interface{}{
+		"NewEmail":      newEmail,
+		"UndoLink":      undoLink,
+		"ExpiresInDays": int(math.Ceil(expiresIn.Hours() / 24)),
+	})
+	if err != nil {
+		log.Printf("Failed to send email changed notice: %v", err)
+		return err
+	}
+
+	log.Printf("Email changed notice sent successfully to %s", oldEmail)
+	return nil
+}
+
+// SendAccountDeletedEmail은 탈퇴 처리 사실과 복구할 수 있는 기한을 알린다.
+func (e *EmailService) SendAccountDeletedEmail(email, locale string, restoreUntil time.Time) error {
+	log.Printf("Sending account deleted email to %s", email)
+
+	err := e.send(email, locale, "account_deleted", map[string]interface{}{
+		"RestoreUntil": restoreUntil.UTC().Format("2006-01-02 15:04 UTC"),
+	})
+	if err != nil {
+		log.Printf("Failed to send account deleted email: %v", err)
+		return err
+	}
+
+	log.Printf("Account deleted email sent successfully to %s", email)
+	return nil
+}
+
+// SendDormancyNoticeEmail은 dormantAt에 휴면 전환된다는 사실을 미리 알린다.
+func (e *EmailService) SendDormancyNoticeEmail(email, locale string, dormantAt time.Time) error {
+	log.Printf("Sending dormancy notice email to %s", email)
+
+	err := e.send(email, locale, "dormancy_notice", map[string]interface{}{
+		"DormantAt": dormantAt.UTC().Format("2006-01-02"),
+	})
+	if err != nil {
+		log.Printf("Failed to send dormancy notice email: %v", err)
+		return err
+	}
+
+	log.Printf("Dormancy notice email sent successfully to %s", email)
+	return nil
+}
+
+// SendReactivationCodeEmail은 휴면 계정을 다시 활성화하기 위한 인증 코드를 보낸다.
+func (e *EmailService) SendReactivationCodeEmail(email, locale, code string) error {
+	log.Printf("Sending reactivation code to %s", email)
+
+	err := e.send(email, locale, "reactivation_code", map[string]interface{}{
+		"Code":             code,
+		"ExpiresInMinutes": verificationCodeExpiresIn,
+	})
+	if err != nil {
+		log.Printf("Failed to send reactivation code: %v", err)
+		return err
+	}
+
+	log.Printf("Reactivation code sent successfully to %s", email)
+	return nil
+}
+
+// SendMarketingReconfirmationEmail은 마케팅 수신 동의 현황(동의한 채널과 동의 시간)을 알린다.
+func (e *EmailService) SendMarketingReconfirmationEmail(email, locale string, channels []string, consentedAt time.Time) error {
+	log.Printf("Sending marketing re-confirmation email to %s", email)
+
+	err := e.send(email, locale, "marketing_reconfirmation", map[string]interface{}{
+		"Channels":    channels,
+		"ConsentedAt": consentedAt.UTC().Format("2006-01-02"),
+	})
+	if err != nil {
+		log.Printf("Failed to send marketing re-confirmation email: %v", err)
+		return err
+	}
+
+	log.Printf("Marketing re-confirmation email sent successfully to %s", email)
+	return nil
+}
+
+func (e *EmailService) send(to, locale, template string, data map[string]interface{}) error {
+	msg, err := e.renderer.Render(locale, template, data)
+	if err != nil {
+		return err
+	}
+	msg.To = to
+	return e.mailer.Send(msg)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"auth-go-service/pkg/utils"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// createEmailVerification은 purpose 용도의 인증 코드를 만들어 해시로 저장하고, 메일로 보낼 원문 코드를 반환한다.
+// 같은 이메일, 같은 용도로 재발송 대기 시간(cooldown) 안에 다시 요청하면 *VerificationCooldownError를 반환한다.
+func createEmailVerification(repos *repository.Repositories, email, purpose string, userID *uint, cooldown time.Duration) (*models.EmailVerification, string, error) {
+	if latest, err := repos.EmailVerifications.FindLatestByEmail(email, purpose); err == nil {
+		if wait := time.Until(latest.CreatedAt.Add(cooldown)); wait > 0 {
+			return nil, "", &VerificationCooldownError{RetryAfter: wait}
+		}
+	}
+
+	code, err := generateVerificationCode()
+	if err != nil {
+		return nil, "", err
+	}
+
+	codeHash, err := utils.HashPassword(code)
+	if err != nil {
+		return nil, "", err
+	}
+
+	verification := models.EmailVerification{
+		Email:     email,
+		Purpose:   purpose,
+		UserID:    userID,
+		CodeHash:  codeHash,
+		ExpiresAt: time.Now().Add(verificationCodeExpiresIn * time.Minute),
+	}
+
+	if err := repos.EmailVerifications.Create(&verification); err != nil {
+		return nil, "", err
+	}
+	return &verification, code, nil
+}
+
+// checkEmailVerification은 인증 코드를 확인하고 인증 완료로 저장한다.
+// 인증 요청마다 시도 횟수를 세며, maxAttempts에 도달하면 코드가 폐기되어 새로 요청해야 한다.
+func checkEmailVerification(repos *repository.Repositories, verification *models.EmailVerification, code string, maxAttempts int) error {
+	if verification.VerifiedAt != nil {
+		return errors.New("This email verification request has already been completed.")
+	}
+
+	if time.Now().After(verification.ExpiresAt) {
+		return errors.New("Verification code has expired. Please request a new one.")
+	}
+
+	// 동시에 여러 코드를 대입하지 못하도록 코드를 비교하기 전에 시도 횟수를 먼저 차감한다.
+	reserved, err := repos.EmailVerifications.ReserveAttempt(verification.ID, maxAttempts)
+	if err != nil {
+		return err
+	}
+	if !reserved {
+		return ErrVerificationAttemptsExceeded
+	}
+
+	if !utils.CheckPasswordHash(code, verification.CodeHash) {
+		remaining := maxAttempts - verification.Attempts - 1
+		if remaining <= 0 {
+			return ErrVerificationAttemptsExceeded
+		}
+		return fmt.Errorf("Invalid verification code. (%d attempts remaining)", remaining)
+	}
+
+	now := time.Now()
+	verification.VerifiedAt = &now
+	verification.Attempts++
+	return repos.EmailVerifications.Save(verification)
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// LockoutPolicy는 한 단위(계정 또는 IP)에 적용되는 로그인 잠금 정책이다.
+// Window 안에서 실패가 Threshold회에 도달하면 잠기며, 잠길 때마다 잠금 시간이 두 배로 늘어난다(최대 MaxDuration).
+// PermanentAfter회 잠기면 비밀번호를 재설정할 때까지 영구 잠금된다(0이면 사용하지 않음).
+type LockoutPolicy struct {
+	Threshold      int
+	Window         time.Duration
+	Duration       time.Duration
+	MaxDuration    time.Duration
+	PermanentAfter int
+}
+
+// LoginLockedError는 잠금 때문에 로그인을 시도할 수 없을 때 반환된다.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+	Permanent  bool
+}
+
+func (e *LoginLockedError) Error() string {
+	if e.Permanent {
+		return "로그인 실패가 반복되어 계정이 잠겼습니다. 비밀번호를 재설정한 뒤 다시 로그인해주세요."
+	}
+	return fmt.Sprintf("로그인 시도 횟수를 초과했습니다. %d초 후 다시 시도해주세요.", e.RetryAfterSeconds())
+}
+
+// RetryAfterSeconds는 Retry-After 헤더에 넣을 초 단위 대기 시간이다(올림).
+func (e *LoginLockedError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// LockoutService는 계정별, IP별 로그인 실패를 세고 잠금 정책을 적용한다.
+type LockoutService struct {
+	repos   *repository.Repositories
+	account LockoutPolicy
+	ip      LockoutPolicy
+}
+
+func NewLockoutService(cfg *config.Config, repos *repository.Repositories) *LockoutService {
+	return &LockoutService{
+		repos: repos,
+		account: LockoutPolicy{
+			Threshold:      cfg.LockoutThreshold,
+			Window:         time.Duration(cfg.LockoutWindow) * time.Second,
+			Duration:       time.Duration(cfg.LockoutDuration) * time.Second,
+			MaxDuration:    time.Duration(cfg.LockoutMaxDuration) * time.Second,
+			PermanentAfter: cfg.LockoutPermanentAfter,
+		},
+		ip: LockoutPolicy{
+			Threshold:   cfg.IPLockoutThreshold,
+			Window:      time.Duration(cfg.IPLockoutWindow) * time.Second,
+			Duration:    time.Duration(cfg.IPLockoutDuration) * time.Second,
+			MaxDuration: time.Duration(cfg.LockoutMaxDuration) * time.Second,
+		},
+	}
+}
+
+// Check는 계정이나 IP가 잠겨 있으면 *LoginLockedError를 반환한다.
+func (l *LockoutService) Check(email, ip string) error {
+	now := time.Now()
+	for _, target := range l.targets(email, ip) {
+		lockout, err := l.repos.LoginLockouts.Find(target.scope, target.identifier)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if lockedErr := lockedError(lockout, now); lockedErr != nil {
+			return lockedErr
+		}
+	}
+	return nil
+}
+
+// RecordFailure는 실패를 계정과 IP에 각각 기록하고, 이번 실패로 잠겼다면 *LoginLockedError를 반환한다.
+// 첫 번째 반환값은 현재 집계 구간(Window) 안에서 계정의 누적 실패 횟수다.
+func (l *LockoutService) RecordFailure(email, ip string) (int, error) {
+	now := time.Now()
+	failureCount := 0
+	var result error
+
+	for _, target := range l.targets(email, ip) {
+		policy := target.policy
+		lockout, err := l.repos.LoginLockouts.Update(target.scope, target.identifier, func(lockout *models.LoginLockout) {
+			if lockout.Permanent || (lockout.LockedUntil != nil && lockout.LockedUntil.After(now)) {
+				return
+			}
+			if now.Sub(lockout.WindowStartedAt) > policy.Window {
+				lockout.FailureCount = 0
+				lockout.WindowStartedAt = now
+			}
+			lockout.FailureCount++
+
+			if policy.Threshold <= 0 || lockout.FailureCount < policy.Threshold {
+				return
+			}
+			lockout.FailureCount = 0
+			lockout.LockoutCount++
+			if policy.PermanentAfter > 0 && lockout.LockoutCount >= policy.PermanentAfter {
+				lockout.Permanent = true
+				return
+			}
+			lockedUntil := now.Add(policy.lockDuration(lockout.LockoutCount))
+			lockout.LockedUntil = &lockedUntil
+		})
+		if err != nil {
+			return failureCount, err
+		}
+
+		if target.scope == models.LockoutScopeAccount {
+			failureCount = lockout.FailureCount
+		}
+		if lockedErr := lockedError(lockout, now); lockedErr != nil && result == nil {
+			result = lockedErr
+		}
+	}
+	return failureCount, result
+}
+
+// RecordSuccess는 로그인에 성공한 계정의 실패 기록과 잠금 단계를 초기화한다.
+// IP 단위 기록은 한 계정의 성공으로 다른 계정에 대한 대입 시도가 가려지지 않도록 그대로 둔다.
+func (l *LockoutService) RecordSuccess(email string) error {
+	return l.Reset(email)
+}
+
+// Reset은 계정 잠금(영구 잠금 포함)을 해제한다. 비밀번호를 재설정하면 호출된다.
+func (l *LockoutService) Reset(email string) error {
+	return l.repos.LoginLockouts.Delete(models.LockoutScopeAccount, normalizeLockoutEmail(email))
+}
+
+type lockoutTarget struct {
+	scope      string
+	identifier string
+	policy     LockoutPolicy
+}
+
+func (l *LockoutService) targets(email, ip string) []lockoutTarget {
+	targets := []lockoutTarget{{models.LockoutScopeAccount, normalizeLockoutEmail(email), l.account}}
+	if ip != "" {
+		targets = append(targets, lockoutTarget{models.LockoutScopeIP, ip, l.ip})
+	}
+	return targets
+}
+
+// lockDuration은 lockoutCount번째 잠금에 적용할 시간을 반환한다. 잠길 때마다 두 배씩 늘어난다.
+func (p LockoutPolicy) lockDuration(lockoutCount int) time.Duration {
+	duration := p.Duration
+	for i := 1; i < lockoutCount && (p.MaxDuration <= 0 || duration < p.MaxDuration); i++ {
+		duration *= 2
+	}
+	if p.MaxDuration > 0 && duration > p.MaxDuration {
+		duration = p.MaxDuration
+	}
+	return duration
+}
+
+func lockedError(lockout *models.LoginLockout, now time.Time) *LoginLockedError {
+	if lockout.Permanent {
+		return &LoginLockedError{Permanent: true}
+	}
+	if lockout.LockedUntil != nil && lockout.LockedUntil.After(now) {
+		return &LoginLockedError{RetryAfter: lockout.LockedUntil.Sub(now)}
+	}
+	return nil
+}
+
+// normalizeLockoutEmail은 대소문자만 다른 이메일로 잠금을 우회하지 못하도록 소문자로 바꾼다.
+func normalizeLockoutEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
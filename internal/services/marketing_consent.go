@@ -0,0 +1,97 @@
+package services
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"time"
+)
+
+// currentMarketingConsents는 동의 이력(최신순)에서 채널별 현재 동의 여부와 마지막 변경 이력을 구한다.
+// 이력이 없는 채널은 동의하지 않은 것으로 본다.
+func currentMarketingConsents(history []models.MarketingConsent) map[string]*models.MarketingConsent {
+	current := map[string]*models.MarketingConsent{}
+	for i := range history {
+		if _, ok := current[history[i].Channel]; !ok {
+			current[history[i].Channel] = &history[i]
+		}
+	}
+	return current
+}
+
+// recordMarketingConsents는 changes(채널별 동의 여부) 중 현재 상태와 다른 항목만 이력에 추가하고,
+// users의 마케팅 수신 동의 여부(한 채널이라도 동의)와 동의 시간을 갱신한다. 변경이 있었는지 여부를 반환한다.
+func recordMarketingConsents(repos *repository.Repositories, user *models.User, changes map[string]bool, source, ip, termsVersion string, at time.Time) (bool, error) {
+	history, err := repos.MarketingConsents.FindByUserID(user.ID)
+	if err != nil {
+		return false, err
+	}
+	current := currentMarketingConsents(history)
+
+	var entries []models.MarketingConsent
+	granted := false
+	agreed := false
+	for _, channel := range models.MarketingChannels {
+		value := current[channel] != nil && current[channel].Agreed
+		if next, ok := changes[channel]; ok && next != value {
+			value = next
+			granted = granted || next
+			entries = append(entries, models.MarketingConsent{
+				UserID:       user.ID,
+				Channel:      channel,
+				Agreed:       next,
+				Source:       source,
+				TermsVersion: termsVersion,
+				IPAddress:    ip,
+				CreatedAt:    at,
+			})
+		}
+		agreed = agreed || value
+	}
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	if err := repos.MarketingConsents.Create(entries); err != nil {
+		return false, err
+	}
+
+	// 새로 동의하면 재확인 기준 시간을 새로 잡고, 모두 철회하면 지운다.
+	confirmedAt := user.MarketingConfirmedAt
+	if granted || (agreed && confirmedAt == nil) {
+		confirmedAt = &at
+	}
+	if !agreed {
+		confirmedAt = nil
+	}
+	if err := repos.Users.UpdateMarketingConsent(user.ID, agreed, confirmedAt); err != nil {
+		return false, err
+	}
+	user.AgreedMarketingOptIn = agreed
+	user.MarketingConfirmedAt = confirmedAt
+	return true, nil
+}
+
+// allMarketingChannels는 모든 채널을 agreed로 바꾸는 changes를 만든다(회원 정보 수정의 단일 동의 항목).
+// signUpMarketingConsents는 가입할 때 마케팅 수신에 동의한 사용자의 채널별 동의 이력이다. UserID는 저장할 때 채운다.
+func signUpMarketingConsents(ip, termsVersion string, at time.Time) []models.MarketingConsent {
+	consents := make([]models.MarketingConsent, 0, len(models.MarketingChannels))
+	for _, channel := range models.MarketingChannels {
+		consents = append(consents, models.MarketingConsent{
+			Channel:      channel,
+			Agreed:       true,
+			Source:       models.MarketingSourceSignUp,
+			TermsVersion: termsVersion,
+			IPAddress:    ip,
+			CreatedAt:    at,
+		})
+	}
+	return consents
+}
+
+func allMarketingChannels(agreed bool) map[string]bool {
+	changes := map[string]bool{}
+	for _, channel := range models.MarketingChannels {
+		changes[channel] = agreed
+	}
+	return changes
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"auth-go-service/pkg/utils"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"time"
+)
+
+const recoveryCodeCount = 10
+
+var (
+	ErrMFAAlreadyEnabled = errors.New("2단계 인증이 이미 활성화되어 있습니다.")
+	ErrMFANotEnrolled    = errors.New("2단계 인증 등록 요청이 없습니다. 등록을 먼저 진행해주세요.")
+	ErrMFANotEnabled     = errors.New("2단계 인증이 활성화되어 있지 않습니다.")
+	ErrInvalidMFACode    = errors.New("인증 코드가 올바르지 않습니다.")
+)
+
+// MFAService는 TOTP 기반 2단계 인증의 등록, 확인, 해제와 코드 검증을 담당한다.
+type MFAService struct {
+	repos  *repository.Repositories
+	issuer string
+}
+
+func NewMFAService(repos *repository.Repositories, issuer string) *MFAService {
+	return &MFAService{
+		repos:  repos,
+		issuer: issuer,
+	}
+}
+
+// IsEnabled는 사용자가 등록을 확인까지 마친 2단계 인증을 사용 중인지 반환한다.
+func (m *MFAService) IsEnabled(userID uint) bool {
+	mfa, err := m.repos.MFA.FindByUserID(userID)
+	if err != nil {
+		return false
+	}
+	return mfa.ConfirmedAt != nil
+}
+
+// Enroll은 새 비밀키를 발급한다. 확인 전까지는 로그인에 적용되지 않으며, 다시 호출하면 비밀키가 교체된다.
+func (m *MFAService) Enroll(userID uint, email string) (*models.MFAEnrollResponse, error) {
+	mfa, err := m.repos.MFA.FindByUserID(userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		mfa = &models.UserMFA{UserID: userID}
+	} else if err != nil {
+		return nil, err
+	} else if mfa.ConfirmedAt != nil {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	mfa.Secret = secret
+	mfa.LastUsedStep = 0
+	if err := m.repos.MFA.Save(mfa); err != nil {
+		return nil, err
+	}
+
+	return &models.MFAEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPProvisioningURI(m.issuer, email, secret),
+	}, nil
+}
+
+// Confirm은 인증 앱에서 생성한 코드로 등록을 완료하고, 1회용 복구 코드를 발급한다.
+func (m *MFAService) Confirm(userID uint, code string) (*models.MFARecoveryCodesResponse, error) {
+	mfa, err := m.repos.MFA.FindByUserID(userID)
+	if err != nil {
+		return nil, ErrMFANotEnrolled
+	}
+	if mfa.ConfirmedAt != nil {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	if !m.verifyTOTP(mfa, code) {
+		return nil, ErrInvalidMFACode
+	}
+
+	now := time.Now()
+	mfa.ConfirmedAt = &now
+	if err := m.repos.MFA.Save(mfa); err != nil {
+		return nil, err
+	}
+
+	codes, err := m.regenerateRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MFARecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// Disable은 비밀번호와 2단계 인증 코드로 본인을 다시 확인한 뒤 2단계 인증을 해제한다.
+func (m *MFAService) Disable(userID uint, password, code string) error {
+	user, err := m.repos.Users.FindByID(userID)
+	if err != nil {
+		return errors.New("User not found")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		return errors.New("비밀번호가 올바르지 않습니다.")
+	}
+
+	if !m.IsEnabled(user.ID) {
+		return ErrMFANotEnabled
+	}
+
+	if !m.VerifyCode(user.ID, code) {
+		return ErrInvalidMFACode
+	}
+
+	return m.repos.MFA.DeleteByUserID(user.ID)
+}
+
+// VerifyCode는 TOTP 코드 또는 사용하지 않은 복구 코드를 검증한다. 복구 코드는 사용 즉시 소진된다.
+func (m *MFAService) VerifyCode(userID uint, code string) bool {
+	mfa, err := m.repos.MFA.FindByUserID(userID)
+	if err != nil || mfa.ConfirmedAt == nil {
+		return false
+	}
+
+	if m.verifyTOTP(mfa, code) {
+		return true
+	}
+
+	return m.consumeRecoveryCode(userID, code)
+}
+
+func (m *MFAService) verifyTOTP(mfa *models.UserMFA, code string) bool {
+	step, ok := utils.ValidateTOTP(mfa.Secret, code, time.Now(), 1)
+	if !ok || step <= mfa.LastUsedStep {
+		return false
+	}
+
+	// 같은 시간 구간의 코드가 두 번 쓰이지 않도록 마지막 사용 구간을 조건부로 갱신한다.
+	advanced, err := m.repos.MFA.AdvanceLastUsedStep(mfa.ID, step)
+	if err != nil || !advanced {
+		return false
+	}
+	mfa.LastUsedStep = step
+	return true
+}
+
+func (m *MFAService) consumeRecoveryCode(userID uint, code string) bool {
+	consumed, err := m.repos.MFA.ConsumeRecoveryCode(userID, hashToken(normalizeRecoveryCode(code)), time.Now())
+	return err == nil && consumed
+}
+
+func (m *MFAService) regenerateRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	codeHashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		codeHashes = append(codeHashes, hashToken(normalizeRecoveryCode(code)))
+	}
+
+	if err := m.repos.MFA.ReplaceRecoveryCodes(userID, codeHashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// generateRecoveryCode는 "xxxxx-xxxxx" 형식의 50비트 복구 코드를 생성한다.
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	encoded := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))[:10]
+	return encoded[:5] + "-" + encoded[5:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
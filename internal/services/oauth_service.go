@@ -0,0 +1,531 @@
+package services
+
+import (
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"auth-go-service/internal/social"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	grantTypeAuthorizationCode = "authorization_code"
+	grantTypeClientCredentials = "client_credentials"
+
+	authorizationCodeExpiresIn = 60 // 1 minute
+)
+
+// supportedScopes는 OpenID Connect 클라이언트가 요청할 수 있는 scope다.
+var supportedScopes = []string{"openid", "profile", "email", "phone"}
+
+// OAuthError는 OAuth 2.0 형식(error, error_description)으로 응답할 오류다.
+type OAuthError struct {
+	Status      int
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return e.Description
+}
+
+func newOAuthError(status int, code, description string) *OAuthError {
+	return &OAuthError{Status: status, Code: code, Description: description}
+}
+
+// OAuthService는 이 서비스를 OpenID Connect 제공자로 사용하는 앱(클라이언트)의 로그인 위임과
+// 백엔드 서비스(서비스 클라이언트)의 토큰 발급을 처리한다.
+// 사용자 로그인은 인가 코드 + PKCE(S256), 서비스 간 호출은 client_credentials만 지원한다.
+type OAuthService struct {
+	repos        *repository.Repositories
+	tokenService *TokenService
+	issuer       string
+	loginURL     string
+}
+
+func NewOAuthService(cfg *config.Config, repos *repository.Repositories, tokenService *TokenService) *OAuthService {
+	return &OAuthService{
+		repos:        repos,
+		tokenService: tokenService,
+		issuer:       cfg.OIDCIssuer,
+		loginURL:     cfg.OIDCLoginURL,
+	}
+}
+
+// Discovery는 /.well-known/openid-configuration 문서를 만든다.
+func (s *OAuthService) Discovery() models.OpenIDConfiguration {
+	return models.OpenIDConfiguration{
+		Issuer:                            s.issuer,
+		AuthorizationEndpoint:             s.issuer + "/v1/oauth/authorize",
+		TokenEndpoint:                     s.issuer + "/v1/oauth/token",
+		UserinfoEndpoint:                  s.issuer + "/v1/oauth/userinfo",
+		IntrospectionEndpoint:             s.issuer + "/v1/oauth/introspect",
+		RevocationEndpoint:                s.issuer + "/v1/oauth/revoke",
+		JWKSURI:                           s.issuer + "/.well-known/jwks.json",
+		ResponseTypesSupported:            []string{"code"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{s.tokenService.SigningAlgorithm()},
+		ScopesSupported:                   supportedScopes,
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "nonce", "name", "locale", "updated_at", "email", "email_verified", "phone_number"},
+		GrantTypesSupported:               []string{grantTypeAuthorizationCode, grantTypeClientCredentials},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+	}
+}
+
+// CheckClient는 client_id와 redirect_uri를 확인한다. 여기서 실패하면 클라이언트로 redirect하지 않고 오류를 보여줘야 한다.
+func (s *OAuthService) CheckClient(clientID, redirectURI string) (*models.OAuthClient, error) {
+	client, err := s.repos.OAuthClients.FindByClientID(clientID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, newOAuthError(http.StatusBadRequest, "invalid_request", "Unknown client_id")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !client.AllowsRedirectURI(redirectURI) {
+		return nil, newOAuthError(http.StatusBadRequest, "invalid_request", "redirect_uri is not registered for this client")
+	}
+	return client, nil
+}
+
+// CheckAuthorization은 client_id, redirect_uri 외의 인가 요청 값을 확인한다. 실패하면 redirect_uri로 오류를 돌려준다.
+func (s *OAuthService) CheckAuthorization(req *models.AuthorizeRequest) error {
+	if req.ResponseType != "code" {
+		return newOAuthError(http.StatusBadRequest, "unsupported_response_type", "Only response_type=code is supported")
+	}
+	if err := checkScope(req.Scope); err != nil {
+		return err
+	}
+	if req.CodeChallengeMethod != "S256" || len(req.CodeChallenge) != 43 {
+		return newOAuthError(http.StatusBadRequest, "invalid_request", "PKCE code_challenge with code_challenge_method=S256 is required")
+	}
+	return nil
+}
+
+// LoginRedirectURL은 인가 요청을 그대로 담아 프론트엔드 로그인 화면으로 보내는 주소다.
+// 로그인한 프론트엔드는 같은 값으로 POST /v1/oauth/authorize를 호출해 인가 코드를 받는다.
+func (s *OAuthService) LoginRedirectURL(query url.Values) string {
+	return appendQuery(s.loginURL, query)
+}
+
+// ErrorRedirectURL은 인가 요청 오류를 클라이언트의 redirect_uri로 알리는 주소다.
+func (s *OAuthService) ErrorRedirectURL(req *models.AuthorizeRequest, err *OAuthError) string {
+	params := url.Values{"error": {err.Code}, "error_description": {err.Description}}
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	return appendQuery(req.RedirectURI, params)
+}
+
+// Authorize는 로그인한 사용자(userID)에게 인가 코드를 발급하고, 코드를 담은 클라이언트 redirect 주소를 반환한다.
+func (s *OAuthService) Authorize(userID uint, req *models.AuthorizeRequest) (string, error) {
+	if _, err := s.CheckClient(req.ClientID, req.RedirectURI); err != nil {
+		return "", err
+	}
+	if err := s.CheckAuthorization(req); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	// 토큰으로 바꾸지 않은 코드가 남지 않도록 만료된 코드를 함께 정리한다.
+	if err := s.repos.OAuthCodes.DeleteExpired(now); err != nil {
+		return "", err
+	}
+
+	code, err := generateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	err = s.repos.OAuthCodes.Create(&models.OAuthAuthorizationCode{
+		CodeHash:      hashToken(code),
+		ClientID:      req.ClientID,
+		UserID:        userID,
+		RedirectURI:   req.RedirectURI,
+		Scope:         normalizeScope(req.Scope),
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		ExpiresAt:     now.Add(authorizationCodeExpiresIn * time.Second),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{"code": {code}}
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	return appendQuery(req.RedirectURI, params), nil
+}
+
+// Token은 토큰 엔드포인트 요청을 grant_type에 따라 처리한다.
+// 기밀 클라이언트는 client_secret_basic 또는 client_secret_post로 인증해야 한다.
+func (s *OAuthService) Token(req *models.OAuthTokenRequest, basicID, basicSecret string) (*models.OAuthTokenResponse, error) {
+	if req.GrantType != grantTypeAuthorizationCode && req.GrantType != grantTypeClientCredentials {
+		return nil, newOAuthError(http.StatusBadRequest, "unsupported_grant_type", "Only authorization_code and client_credentials grants are supported")
+	}
+
+	client, err := s.authenticateClient(req.ClientID, req.ClientSecret, basicID, basicSecret)
+	if err != nil {
+		return nil, err
+	}
+	if !client.AllowsGrantType(req.GrantType) {
+		return nil, newOAuthError(http.StatusBadRequest, "unauthorized_client", fmt.Sprintf("The client is not allowed to use grant_type=%s", req.GrantType))
+	}
+
+	if req.GrantType == grantTypeClientCredentials {
+		return s.clientCredentialsToken(client, req.Scope)
+	}
+	return s.authorizationCodeToken(client, req)
+}
+
+// authorizationCodeToken은 인가 코드를 액세스 토큰과 ID 토큰으로 바꾼다. 모든 클라이언트는 PKCE code_verifier를 보내야 한다.
+func (s *OAuthService) authorizationCodeToken(client *models.OAuthClient, req *models.OAuthTokenRequest) (*models.OAuthTokenResponse, error) {
+	if req.Code == "" || req.CodeVerifier == "" {
+		return nil, newOAuthError(http.StatusBadRequest, "invalid_request", "code and code_verifier are required")
+	}
+
+	invalidGrant := newOAuthError(http.StatusBadRequest, "invalid_grant", "Invalid or expired authorization code")
+	stored, err := s.repos.OAuthCodes.Consume(hashToken(req.Code))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, invalidGrant
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stored.ClientID != client.ClientID || stored.RedirectURI != req.RedirectURI || time.Now().After(stored.ExpiresAt) {
+		return nil, invalidGrant
+	}
+	if subtle.ConstantTimeCompare([]byte(social.CodeChallenge(req.CodeVerifier)), []byte(stored.CodeChallenge)) != 1 {
+		return nil, invalidGrant
+	}
+
+	user, err := s.repos.Users.FindByID(stored.UserID)
+	if err != nil || user.SignUpStatus != models.SignUpStatusCompleted || user.DormantAt != nil {
+		return nil, invalidGrant
+	}
+
+	accessToken, err := s.tokenService.IssueClientAccessToken(*user, client.ClientID, stored.Scope)
+	if err != nil {
+		return nil, err
+	}
+	idToken, err := s.tokenService.IssueIDToken(client.ClientID, stored.Nonce, userInfo(user, stored.Scope))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OAuthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   s.tokenService.jwtExpiresIn,
+		IDToken:     idToken,
+		Scope:       stored.Scope,
+	}, nil
+}
+
+// clientCredentialsToken은 서비스 클라이언트에 사용자 없는 서비스 토큰을 발급한다.
+// scope를 보내지 않으면 클라이언트에 허용된 scope를 모두 담는다.
+func (s *OAuthService) clientCredentialsToken(client *models.OAuthClient, scope string) (*models.OAuthTokenResponse, error) {
+	granted := client.Scopes
+	if scope != "" {
+		for _, requested := range strings.Fields(scope) {
+			if !hasScope(client.Scopes, requested) {
+				return nil, newOAuthError(http.StatusBadRequest, "invalid_scope", fmt.Sprintf("Scope not allowed for this client: %s", requested))
+			}
+		}
+		granted = normalizeScope(scope)
+	}
+
+	accessToken, err := s.tokenService.IssueServiceToken(client.ClientID, granted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OAuthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   s.tokenService.jwtExpiresIn,
+		Scope:       granted,
+	}, nil
+}
+
+// UserInfo는 클라이언트에 발급한 액세스 토큰의 scope에 맞춰 사용자 정보를 반환한다. openid scope가 있어야 한다.
+func (s *OAuthService) UserInfo(tokenString string) (*models.UserInfo, error) {
+	claims, err := s.tokenService.VerifyAccessToken(tokenString)
+	if err != nil {
+		return nil, newOAuthError(http.StatusUnauthorized, "invalid_token", "Invalid or expired access token")
+	}
+	if claims.IsService() || !hasScope(claims.Scope, "openid") {
+		return nil, newOAuthError(http.StatusForbidden, "insufficient_scope", "The access token does not have the openid scope")
+	}
+
+	user, err := s.repos.Users.FindByID(claims.UserID)
+	if err != nil || user.SignUpStatus != models.SignUpStatusCompleted || user.DormantAt != nil {
+		return nil, newOAuthError(http.StatusUnauthorized, "invalid_token", "User not found")
+	}
+	return userInfo(user, claims.Scope), nil
+}
+
+// Introspect는 토큰이 지금 사용할 수 있는지와 토큰의 클레임을 알려준다(RFC 7662).
+// 토큰을 직접 검증할 수 없는 서비스용이며, 기밀 클라이언트라면 어느 클라이언트에 발급된 토큰이든 확인할 수 있다.
+// 액세스 토큰은 Revoke와 같이 VerifyAccessToken으로 검증(서명, 만료, 폐기)하고, 리프레시 토큰은 저장된 해시로 교체/폐기 여부를 확인한다.
+// 사용할 수 없는 토큰은 오류 대신 active=false로 응답한다.
+func (s *OAuthService) Introspect(req *models.IntrospectionRequest, basicID, basicSecret string) (*models.IntrospectionResponse, error) {
+	if _, err := s.authenticateConfidentialClient(req.ClientID, req.ClientSecret, basicID, basicSecret); err != nil {
+		return nil, err
+	}
+
+	if claims, err := s.tokenService.VerifyAccessToken(req.Token); err == nil {
+		response := &models.IntrospectionResponse{
+			Active:      true,
+			TokenType:   "access_token",
+			Scope:       claims.Scope,
+			ClientID:    claims.ClientID,
+			Subject:     claims.Subject,
+			SubjectType: SubjectTypeUser,
+			Email:       claims.Email,
+			Name:        claims.Name,
+			Roles:       claims.Roles,
+			Permissions: claims.Permissions,
+			SessionID:   claims.SessionID,
+			JTI:         claims.ID,
+			IssuedAt:    claims.IssuedAt.Unix(),
+			ExpiresAt:   claims.ExpiresAt.Unix(),
+		}
+		if claims.IsService() {
+			response.SubjectType = SubjectTypeService
+		} else if response.Subject == "" {
+			// sub가 없던 이전 사용자 토큰
+			response.Subject = fmt.Sprint(claims.UserID)
+		}
+		return response, nil
+	}
+
+	if stored, err := s.tokenService.FindActiveRefreshToken(req.Token); err == nil {
+		return &models.IntrospectionResponse{
+			Active:      true,
+			TokenType:   "refresh_token",
+			Subject:     fmt.Sprint(stored.UserID),
+			SubjectType: SubjectTypeUser,
+			SessionID:   stored.FamilyID,
+			IssuedAt:    stored.CreatedAt.Unix(),
+			ExpiresAt:   stored.ExpiresAt.Unix(),
+		}, nil
+	}
+
+	return &models.IntrospectionResponse{Active: false}, nil
+}
+
+// Revoke는 액세스 토큰 또는 리프레시 토큰을 폐기한다(RFC 7009). 리프레시 토큰은 해당 로그인 세션의 토큰 계열을 모두 폐기한다.
+// 다른 클라이언트에 발급된 토큰은 폐기하지 않으며(RFC 7009 2.1), 이미 사용할 수 없는 토큰이어도 오류 없이 끝난다.
+func (s *OAuthService) Revoke(req *models.RevocationRequest, basicID, basicSecret string) error {
+	client, err := s.authenticateConfidentialClient(req.ClientID, req.ClientSecret, basicID, basicSecret)
+	if err != nil {
+		return err
+	}
+
+	if claims, err := s.tokenService.VerifyAccessToken(req.Token); err == nil {
+		if claims.ClientID != "" && claims.ClientID != client.ClientID {
+			return nil
+		}
+		return s.tokenService.RevokeAccessToken(claims)
+	}
+
+	if stored, err := s.tokenService.FindActiveRefreshToken(req.Token); err == nil {
+		return s.tokenService.RevokeSession(stored.UserID, stored.FamilyID)
+	}
+	return nil
+}
+
+// CreateClient는 클라이언트를 등록한다. 기밀 클라이언트(public=false)면 시크릿을 만들어 한 번만 반환한다.
+func (s *OAuthService) CreateClient(name string, redirectURIs []string, public bool) (*models.OAuthClient, string, error) {
+	if len(redirectURIs) == 0 {
+		return nil, "", errors.New("at least one redirect URI is required")
+	}
+	for _, uri := range redirectURIs {
+		parsed, err := url.Parse(uri)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" || parsed.Fragment != "" || strings.ContainsAny(uri, " \t\n") {
+			return nil, "", fmt.Errorf("invalid redirect URI: %q", uri)
+		}
+	}
+
+	client := models.OAuthClient{
+		ClientID:     uuid.New().String(),
+		Name:         name,
+		RedirectURIs: strings.Join(redirectURIs, " "),
+		GrantTypes:   grantTypeAuthorizationCode,
+	}
+	secret := ""
+	if !public {
+		var err error
+		if secret, err = generateOpaqueToken(); err != nil {
+			return nil, "", err
+		}
+		client.ClientSecretHash = hashToken(secret)
+	}
+
+	if err := s.repos.OAuthClients.Create(&client); err != nil {
+		return nil, "", err
+	}
+	return &client, secret, nil
+}
+
+// CreateServiceClient는 client_credentials로 서비스 토큰을 받는 백엔드 서비스용 기밀 클라이언트를 등록한다.
+// scopes는 이 클라이언트가 받을 수 있는 scope이며(예: users:read), 시크릿은 한 번만 반환한다.
+func (s *OAuthService) CreateServiceClient(name string, scopes []string) (*models.OAuthClient, string, error) {
+	for _, scope := range scopes {
+		if !validScopeToken(scope) {
+			return nil, "", fmt.Errorf("invalid scope: %q", scope)
+		}
+	}
+
+	secret, err := generateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+	client := models.OAuthClient{
+		ClientID:         uuid.New().String(),
+		ClientSecretHash: hashToken(secret),
+		Name:             name,
+		GrantTypes:       grantTypeClientCredentials,
+		Scopes:           normalizeScope(strings.Join(scopes, " ")),
+	}
+
+	if err := s.repos.OAuthClients.Create(&client); err != nil {
+		return nil, "", err
+	}
+	return &client, secret, nil
+}
+
+func (s *OAuthService) ListClients() ([]models.OAuthClient, error) {
+	return s.repos.OAuthClients.FindAll()
+}
+
+// authenticateClient는 HTTP Basic(client_secret_basic) 또는 폼 값(client_secret_post)으로 클라이언트를 인증한다.
+// 공개 클라이언트는 client_id만으로 통과한다.
+func (s *OAuthService) authenticateClient(clientID, secret, basicID, basicSecret string) (*models.OAuthClient, error) {
+	if basicID != "" {
+		clientID, secret = basicID, basicSecret
+	}
+
+	invalidClient := newOAuthError(http.StatusUnauthorized, "invalid_client", "Client authentication failed")
+	if clientID == "" {
+		return nil, invalidClient
+	}
+	client, err := s.repos.OAuthClients.FindByClientID(clientID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, invalidClient
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !client.IsPublic() && subtle.ConstantTimeCompare([]byte(hashToken(secret)), []byte(client.ClientSecretHash)) != 1 {
+		return nil, invalidClient
+	}
+	return client, nil
+}
+
+// authenticateConfidentialClient는 시크릿으로 인증한 기밀 클라이언트만 통과시킨다(토큰 확인, 폐기용).
+func (s *OAuthService) authenticateConfidentialClient(clientID, secret, basicID, basicSecret string) (*models.OAuthClient, error) {
+	client, err := s.authenticateClient(clientID, secret, basicID, basicSecret)
+	if err != nil {
+		return nil, err
+	}
+	if client.IsPublic() {
+		return nil, newOAuthError(http.StatusUnauthorized, "invalid_client", "Client authentication failed")
+	}
+	return client, nil
+}
+
+// userInfo는 scope에 해당하는 사용자 클레임만 담는다(ID 토큰과 userinfo 응답에 공통으로 사용).
+func userInfo(user *models.User, scope string) *models.UserInfo {
+	info := &models.UserInfo{Subject: fmt.Sprint(user.ID)}
+	if hasScope(scope, "profile") {
+		info.Name = user.Name
+		info.Locale = user.Locale
+		info.UpdatedAt = user.UpdatedAt.Unix()
+	}
+	if hasScope(scope, "email") {
+		// 가입할 때 이메일 인증(또는 소셜 로그인 제공자의 확인)을 거친다.
+		verified := true
+		info.Email = user.Email
+		info.EmailVerified = &verified
+	}
+	if hasScope(scope, "phone") {
+		info.PhoneNumber = user.Phone
+	}
+	return info
+}
+
+// checkScope는 scope에 openid가 있고 지원하지 않는 값이 없는지 확인한다.
+func checkScope(scope string) error {
+	if !hasScope(scope, "openid") {
+		return newOAuthError(http.StatusBadRequest, "invalid_scope", "The openid scope is required")
+	}
+	for _, requested := range strings.Fields(scope) {
+		supported := false
+		for _, s := range supportedScopes {
+			if requested == s {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return newOAuthError(http.StatusBadRequest, "invalid_scope", fmt.Sprintf("Unsupported scope: %s", requested))
+		}
+	}
+	return nil
+}
+
+// validScopeToken은 scope 값 하나가 RFC 6749의 scope-token 문자(공백, ", \ 제외한 출력 가능 ASCII)로만 되어 있는지 확인한다.
+func validScopeToken(scope string) bool {
+	if scope == "" {
+		return false
+	}
+	for _, r := range scope {
+		if r <= 0x20 || r >= 0x7f || r == '"' || r == '\\' {
+			return false
+		}
+	}
+	return true
+}
+
+func hasScope(scope, want string) bool {
+	for _, s := range strings.Fields(scope) {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeScope는 중복과 여분의 공백을 없앤 scope를 만든다.
+func normalizeScope(scope string) string {
+	var result []string
+	for _, s := range strings.Fields(scope) {
+		if !hasScope(strings.Join(result, " "), s) {
+			result = append(result, s)
+		}
+	}
+	return strings.Join(result, " ")
+}
+
+// appendQuery는 rawURL의 기존 쿼리를 유지한 채 params를 더한다.
+func appendQuery(rawURL string, params url.Values) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := parsed.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
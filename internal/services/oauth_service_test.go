@@ -0,0 +1,322 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/social"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOAuthService(s *AuthService) *OAuthService {
+	cfg := &config.Config{
+		OIDCIssuer:   "https://auth.example.com",
+		OIDCLoginURL: "https://app.example.com/auth/login",
+	}
+	return NewOAuthService(cfg, s.repos, s.tokenService)
+}
+
+func requireOAuthError(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var oauthErr *OAuthError
+	require.True(t, errors.As(err, &oauthErr), "expected OAuthError, got %v", err)
+	assert.Equal(t, code, oauthErr.Code)
+}
+
+func TestOAuthAuthorizationCodeFlowWithPKCE(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	o := newTestOAuthService(s)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+	user, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+
+	client, secret, err := o.CreateClient("Admin", []string{"https://admin.example.com/callback"}, false)
+	require.NoError(t, err)
+	require.NotEmpty(t, secret)
+
+	verifier, err := social.NewCodeVerifier()
+	require.NoError(t, err)
+	req := &models.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            client.ClientID,
+		RedirectURI:         "https://admin.example.com/callback",
+		Scope:               "openid email",
+		State:               "xyz",
+		Nonce:               "n-0S6",
+		CodeChallenge:       social.CodeChallenge(verifier),
+		CodeChallengeMethod: "S256",
+	}
+
+	_, err = o.CheckClient(client.ClientID, "https://evil.example.com/callback")
+	requireOAuthError(t, err, "invalid_request")
+
+	redirect, err := o.Authorize(user.ID, req)
+	require.NoError(t, err)
+	parsed, err := url.Parse(redirect)
+	require.NoError(t, err)
+	assert.Equal(t, "xyz", parsed.Query().Get("state"))
+	code := parsed.Query().Get("code")
+	require.NotEmpty(t, code)
+
+	tokenReq := &models.OAuthTokenRequest{
+		GrantType:    "authorization_code",
+		Code:         code,
+		RedirectURI:  req.RedirectURI,
+		CodeVerifier: verifier,
+	}
+
+	// 시크릿이 틀리면 코드를 소모하지 않고 거부한다.
+	_, err = o.Token(tokenReq, client.ClientID, "wrong-secret")
+	requireOAuthError(t, err, "invalid_client")
+
+	response, err := o.Token(tokenReq, client.ClientID, secret)
+	require.NoError(t, err)
+	assert.Equal(t, "openid email", response.Scope)
+
+	idClaims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(response.IDToken, idClaims, s.tokenService.keys.Keyfunc)
+	require.NoError(t, err)
+	assert.Equal(t, "https://auth.example.com", idClaims["iss"])
+	assert.Equal(t, client.ClientID, idClaims["aud"])
+	assert.Equal(t, "n-0S6", idClaims["nonce"])
+	assert.Equal(t, fmt.Sprint(user.ID), idClaims["sub"])
+	assert.Equal(t, "user@example.com", idClaims["email"])
+	assert.NotContains(t, idClaims, "name")
+
+	info, err := o.UserInfo(response.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", info.Email)
+	assert.Empty(t, info.PhoneNumber)
+
+	// 클라이언트에 발급한 토큰으로는 이 서비스의 API를 호출할 수 없다.
+	_, err = s.VerifyToken(response.AccessToken)
+	assert.Error(t, err)
+
+	// 인가 코드는 한 번만 쓸 수 있다.
+	_, err = o.Token(tokenReq, client.ClientID, secret)
+	requireOAuthError(t, err, "invalid_grant")
+}
+
+func TestOAuthPublicClientRequiresMatchingVerifier(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	o := newTestOAuthService(s)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+	user, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+
+	client, secret, err := o.CreateClient("Mobile", []string{"com.example.app://callback", "https://app.example.com/callback"}, true)
+	require.NoError(t, err)
+	assert.Empty(t, secret)
+	assert.True(t, client.IsPublic())
+
+	verifier, err := social.NewCodeVerifier()
+	require.NoError(t, err)
+	req := &models.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            client.ClientID,
+		RedirectURI:         "https://app.example.com/callback",
+		Scope:               "openid admin",
+		CodeChallenge:       social.CodeChallenge(verifier),
+		CodeChallengeMethod: "S256",
+	}
+	_, err = o.Authorize(user.ID, req)
+	requireOAuthError(t, err, "invalid_scope")
+
+	req.Scope = "openid profile"
+	redirect, err := o.Authorize(user.ID, req)
+	require.NoError(t, err)
+	parsed, err := url.Parse(redirect)
+	require.NoError(t, err)
+
+	otherVerifier, err := social.NewCodeVerifier()
+	require.NoError(t, err)
+	_, err = o.Token(&models.OAuthTokenRequest{
+		GrantType:    "authorization_code",
+		ClientID:     client.ClientID,
+		Code:         parsed.Query().Get("code"),
+		RedirectURI:  req.RedirectURI,
+		CodeVerifier: otherVerifier,
+	}, "", "")
+	requireOAuthError(t, err, "invalid_grant")
+}
+
+func TestOAuthClientCredentialsIssuesServiceTokens(t *testing.T) {
+	s, _ := newTestAuthService(t)
+	o := newTestOAuthService(s)
+
+	client, secret, err := o.CreateServiceClient("billing", []string{"users:read", "users:write"})
+	require.NoError(t, err)
+	require.NotEmpty(t, secret)
+
+	_, err = o.Token(&models.OAuthTokenRequest{GrantType: "client_credentials", Scope: "users:delete"}, client.ClientID, secret)
+	requireOAuthError(t, err, "invalid_scope")
+
+	response, err := o.Token(&models.OAuthTokenRequest{GrantType: "client_credentials", Scope: "users:read"}, client.ClientID, secret)
+	require.NoError(t, err)
+	assert.Equal(t, "users:read", response.Scope)
+	assert.Empty(t, response.IDToken)
+
+	claims, err := s.VerifyToken(response.AccessToken)
+	require.NoError(t, err)
+	assert.True(t, claims.IsService())
+	assert.Equal(t, client.ClientID, claims.Subject)
+	assert.Zero(t, claims.UserID)
+
+	// 서비스 토큰으로는 사용자 정보를 조회할 수 없다.
+	_, err = o.UserInfo(response.AccessToken)
+	requireOAuthError(t, err, "insufficient_scope")
+
+	// 서비스 클라이언트는 인가 코드를 받을 수 없고, 사용자 로그인 클라이언트는 client_credentials를 쓸 수 없다.
+	_, err = o.CheckClient(client.ClientID, "https://billing.example.com/callback")
+	requireOAuthError(t, err, "invalid_request")
+	webClient, webSecret, err := o.CreateClient("Admin", []string{"https://admin.example.com/callback"}, false)
+	require.NoError(t, err)
+	_, err = o.Token(&models.OAuthTokenRequest{GrantType: "client_credentials"}, webClient.ClientID, webSecret)
+	requireOAuthError(t, err, "unauthorized_client")
+}
+
+func TestOAuthIntrospectAndRevokeTokens(t *testing.T) {
+	s, _ := newTestAuthService(t)
+	o := newTestOAuthService(s)
+
+	client, secret, err := o.CreateServiceClient("gateway", []string{"users:read"})
+	require.NoError(t, err)
+	other, otherSecret, err := o.CreateServiceClient("billing", []string{"users:read"})
+	require.NoError(t, err)
+	publicClient, _, err := o.CreateClient("Mobile", []string{"https://app.example.com/callback"}, true)
+	require.NoError(t, err)
+
+	token, err := o.Token(&models.OAuthTokenRequest{GrantType: "client_credentials"}, client.ClientID, secret)
+	require.NoError(t, err)
+
+	// 공개 클라이언트나 잘못된 시크릿으로는 호출할 수 없다.
+	_, err = o.Introspect(&models.IntrospectionRequest{Token: token.AccessToken, ClientID: publicClient.ClientID}, "", "")
+	requireOAuthError(t, err, "invalid_client")
+	_, err = o.Introspect(&models.IntrospectionRequest{Token: token.AccessToken}, client.ClientID, "wrong-secret")
+	requireOAuthError(t, err, "invalid_client")
+
+	access, err := o.Introspect(&models.IntrospectionRequest{Token: token.AccessToken}, client.ClientID, secret)
+	require.NoError(t, err)
+	assert.True(t, access.Active)
+	assert.Equal(t, "access_token", access.TokenType)
+	assert.Equal(t, client.ClientID, access.Subject)
+	assert.Equal(t, "service", access.SubjectType)
+	assert.Equal(t, "users:read", access.Scope)
+
+	unknown, err := o.Introspect(&models.IntrospectionRequest{Token: "not-a-token"}, client.ClientID, secret)
+	require.NoError(t, err)
+	assert.Equal(t, &models.IntrospectionResponse{Active: false}, unknown)
+
+	// 다른 기밀 클라이언트도 토큰을 확인할 수 있지만 폐기할 수는 없다.
+	access, err = o.Introspect(&models.IntrospectionRequest{Token: token.AccessToken}, other.ClientID, otherSecret)
+	require.NoError(t, err)
+	assert.True(t, access.Active)
+	assert.Equal(t, client.ClientID, access.ClientID)
+	require.NoError(t, o.Revoke(&models.RevocationRequest{Token: token.AccessToken}, other.ClientID, otherSecret))
+	_, err = s.VerifyToken(token.AccessToken)
+	require.NoError(t, err)
+
+	require.NoError(t, o.Revoke(&models.RevocationRequest{Token: token.AccessToken}, client.ClientID, secret))
+	access, err = o.Introspect(&models.IntrospectionRequest{Token: token.AccessToken}, client.ClientID, secret)
+	require.NoError(t, err)
+	assert.False(t, access.Active)
+	_, err = s.VerifyToken(token.AccessToken)
+	assert.Error(t, err)
+
+	// 이미 폐기된 토큰도 오류 없이 처리한다.
+	assert.NoError(t, o.Revoke(&models.RevocationRequest{Token: token.AccessToken}, client.ClientID, secret))
+}
+
+func TestOAuthIntrospectAndRevokeUserTokens(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	o := newTestOAuthService(s)
+	rbac := NewRBACService(repos, s.tokenService)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+	_, err := rbac.AssignRoleByEmail("user@example.com", "support")
+	require.NoError(t, err)
+	login, _, err := s.Login("user@example.com", "password123", "127.0.0.1")
+	require.NoError(t, err)
+	user, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+
+	gateway, gatewaySecret, err := o.CreateServiceClient("gateway", []string{"users:read"})
+	require.NoError(t, err)
+
+	// 이 서비스에서 직접 로그인한 토큰도 어느 기밀 클라이언트든 확인할 수 있다.
+	access, err := o.Introspect(&models.IntrospectionRequest{Token: login.Token}, gateway.ClientID, gatewaySecret)
+	require.NoError(t, err)
+	assert.True(t, access.Active)
+	assert.Equal(t, "access_token", access.TokenType)
+	assert.Equal(t, fmt.Sprint(user.ID), access.Subject)
+	assert.Equal(t, "user", access.SubjectType)
+	assert.Empty(t, access.ClientID)
+	assert.Equal(t, []string{"support"}, access.Roles)
+	assert.Equal(t, []string{models.PermissionRolesRead, models.PermissionUsersRead}, access.Permissions)
+
+	refresh, err := o.Introspect(&models.IntrospectionRequest{Token: login.RefreshToken}, gateway.ClientID, gatewaySecret)
+	require.NoError(t, err)
+	assert.True(t, refresh.Active)
+	assert.Equal(t, "refresh_token", refresh.TokenType)
+	assert.Equal(t, access.SessionID, refresh.SessionID)
+
+	// 교체된 리프레시 토큰은 더 이상 사용할 수 없다.
+	rotated, err := s.RefreshToken(login.RefreshToken)
+	require.NoError(t, err)
+	refresh, err = o.Introspect(&models.IntrospectionRequest{Token: login.RefreshToken}, gateway.ClientID, gatewaySecret)
+	require.NoError(t, err)
+	assert.Equal(t, &models.IntrospectionResponse{Active: false}, refresh)
+
+	// 리프레시 토큰을 폐기하면 그 세션의 리프레시 토큰이 모두 폐기된다.
+	require.NoError(t, o.Revoke(&models.RevocationRequest{Token: rotated.RefreshToken}, gateway.ClientID, gatewaySecret))
+	refresh, err = o.Introspect(&models.IntrospectionRequest{Token: rotated.RefreshToken}, gateway.ClientID, gatewaySecret)
+	require.NoError(t, err)
+	assert.False(t, refresh.Active)
+	_, err = s.RefreshToken(rotated.RefreshToken)
+	assert.Error(t, err)
+
+	require.NoError(t, o.Revoke(&models.RevocationRequest{Token: login.Token}, gateway.ClientID, gatewaySecret))
+	access, err = o.Introspect(&models.IntrospectionRequest{Token: login.Token}, gateway.ClientID, gatewaySecret)
+	require.NoError(t, err)
+	assert.False(t, access.Active)
+}
+
+func TestOAuthRevokeSkipsTokensIssuedToOtherClients(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	o := newTestOAuthService(s)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+	user, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+
+	webClient, webSecret, err := o.CreateClient("Admin", []string{"https://admin.example.com/callback"}, false)
+	require.NoError(t, err)
+	gateway, gatewaySecret, err := o.CreateServiceClient("gateway", []string{"users:read"})
+	require.NoError(t, err)
+	delegated, err := s.tokenService.IssueClientAccessToken(*user, webClient.ClientID, "openid")
+	require.NoError(t, err)
+
+	// 위임된 토큰은 폐기할 수 있는 클라이언트와 같은 검증으로 확인한다.
+	for _, caller := range []struct{ id, secret string }{{webClient.ClientID, webSecret}, {gateway.ClientID, gatewaySecret}} {
+		introspected, err := o.Introspect(&models.IntrospectionRequest{Token: delegated}, caller.id, caller.secret)
+		require.NoError(t, err)
+		assert.True(t, introspected.Active)
+		assert.Equal(t, webClient.ClientID, introspected.ClientID)
+		assert.Equal(t, "openid", introspected.Scope)
+	}
+
+	// 다른 클라이언트에 위임된 토큰은 그 클라이언트만 폐기할 수 있다.
+	require.NoError(t, o.Revoke(&models.RevocationRequest{Token: delegated}, gateway.ClientID, gatewaySecret))
+	_, err = s.tokenService.VerifyAccessToken(delegated)
+	require.NoError(t, err)
+	require.NoError(t, o.Revoke(&models.RevocationRequest{Token: delegated}, webClient.ClientID, webSecret))
+	introspected, err := o.Introspect(&models.IntrospectionRequest{Token: delegated}, webClient.ClientID, webSecret)
+	require.NoError(t, err)
+	assert.False(t, introspected.Active)
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+const (
+	passwordMinLength = 8
+	passwordMaxLength = 72 // bcrypt는 72바이트까지만 비교하므로 그보다 긴 비밀번호는 받지 않는다.
+)
+
+var (
+	ErrPasswordPolicy         = errors.New("비밀번호는 8자 이상 72바이트 이하이며, 영문자와 숫자를 모두 포함해야 합니다.")
+	ErrPasswordContainsID     = errors.New("비밀번호에 이메일 주소를 포함할 수 없습니다.")
+	ErrPasswordNotChanged     = errors.New("새 비밀번호가 현재 비밀번호와 같습니다.")
+	ErrInvalidCurrentPassword = errors.New("현재 비밀번호가 올바르지 않습니다.")
+)
+
+// ValidatePassword는 회원가입, 비밀번호 재설정, 비밀번호 변경에 공통으로 적용되는 비밀번호 정책을 확인한다.
+func ValidatePassword(password, email string) error {
+	if len([]rune(password)) < passwordMinLength || len(password) > passwordMaxLength {
+		return ErrPasswordPolicy
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return ErrPasswordPolicy
+	}
+
+	if local, _, ok := strings.Cut(strings.ToLower(email), "@"); ok && len(local) >= 4 &&
+		strings.Contains(strings.ToLower(password), local) {
+		return ErrPasswordContainsID
+	}
+	return nil
+}
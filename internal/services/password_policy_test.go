@@ -0,0 +1,19 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePassword(t *testing.T) {
+	assert.NoError(t, ValidatePassword("password123", "user@example.com"))
+	assert.NoError(t, ValidatePassword("비밀번호는길게1", "user@example.com"))
+
+	assert.ErrorIs(t, ValidatePassword("pass123", "user@example.com"), ErrPasswordPolicy)
+	assert.ErrorIs(t, ValidatePassword("12345678", "user@example.com"), ErrPasswordPolicy)
+	assert.ErrorIs(t, ValidatePassword("abcdefgh", "user@example.com"), ErrPasswordPolicy)
+	assert.ErrorIs(t, ValidatePassword("a1"+strings.Repeat("x", 71), "user@example.com"), ErrPasswordPolicy)
+	assert.ErrorIs(t, ValidatePassword("hong.gildong1", "Hong.Gildong@example.com"), ErrPasswordContainsID)
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"errors"
+)
+
+var ErrRoleNotFound = errors.New("존재하지 않는 역할입니다.")
+
+// RBACService는 역할 조회와 사용자 역할 부여/회수를 담당한다.
+// 역할과 권한은 액세스 토큰에 담기므로, 바뀐 내용은 토큰을 새로 받아야 반영된다.
+type RBACService struct {
+	repos        *repository.Repositories
+	tokenService *TokenService
+}
+
+func NewRBACService(repos *repository.Repositories, tokenService *TokenService) *RBACService {
+	return &RBACService{
+		repos:        repos,
+		tokenService: tokenService,
+	}
+}
+
+func (s *RBACService) ListRoles() ([]models.Role, error) {
+	return s.repos.Roles.FindAll()
+}
+
+func (s *RBACService) GetUserRoles(userID uint) ([]models.Role, error) {
+	if _, err := s.findUser(userID); err != nil {
+		return nil, err
+	}
+	return s.repos.Roles.FindByUserID(userID)
+}
+
+// AssignRole은 사용자에게 역할을 부여하고 부여 후의 역할 목록을 반환한다.
+// 기존 토큰에는 권한이 없을 뿐이므로 폐기하지 않고, 다음 토큰 갱신 때부터 반영된다.
+func (s *RBACService) AssignRole(userID uint, roleName string) ([]models.Role, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.findRole(roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repos.Roles.AssignToUser(user.ID, role.ID); err != nil {
+		return nil, err
+	}
+	return s.repos.Roles.FindByUserID(user.ID)
+}
+
+// RemoveRole은 사용자의 역할을 회수한다. 회수한 권한이 토큰 만료 전까지 남지 않도록
+// 이미 발급된 액세스 토큰을 폐기하며, 사용자는 리프레시 토큰으로 새 토큰을 받으면 된다.
+func (s *RBACService) RemoveRole(userID uint, roleName string) error {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+	role, err := s.findRole(roleName)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.repos.Roles.RemoveFromUser(user.ID, role.ID)
+	if err != nil || !removed {
+		return err
+	}
+	return s.tokenService.RevokeAccessTokensForUser(user.ID)
+}
+
+// AssignRoleByEmail은 첫 관리자 지정처럼 운영자가 CLI에서 이메일로 역할을 부여할 때 사용한다.
+func (s *RBACService) AssignRoleByEmail(email, roleName string) ([]models.Role, error) {
+	user, err := s.findUserByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	return s.AssignRole(user.ID, roleName)
+}
+
+// RemoveRoleByEmail은 CLI에서 이메일로 역할을 회수할 때 사용한다.
+func (s *RBACService) RemoveRoleByEmail(email, roleName string) error {
+	user, err := s.findUserByEmail(email)
+	if err != nil {
+		return err
+	}
+	return s.RemoveRole(user.ID, roleName)
+}
+
+func (s *RBACService) findUser(userID uint) (*models.User, error) {
+	user, err := s.repos.Users.FindByID(userID)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && user.SignUpStatus != models.SignUpStatusCompleted) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+func (s *RBACService) findUserByEmail(email string) (*models.User, error) {
+	user, err := s.repos.Users.FindByEmail(email)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && user.SignUpStatus != models.SignUpStatusCompleted) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+func (s *RBACService) findRole(name string) (*models.Role, error) {
+	role, err := s.repos.Roles.FindByName(name)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrRoleNotFound
+	}
+	return role, err
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"testing"
+
+	"auth-go-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRBACAssignedRolesAreEmbeddedInAccessTokens(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	rbac := NewRBACService(repos, s.tokenService)
+
+	login := signUpTestUser(t, s, repos, "support@example.com", "password123")
+	user, err := repos.Users.FindByEmail("support@example.com")
+	require.NoError(t, err)
+
+	claims, err := s.VerifyToken(login.Token)
+	require.NoError(t, err)
+	assert.Empty(t, claims.Roles)
+	assert.False(t, claims.HasPermission(models.PermissionUsersRead))
+
+	roles, err := rbac.AssignRole(user.ID, "support")
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, "support", roles[0].Name)
+	_, err = rbac.AssignRole(user.ID, "support")
+	require.NoError(t, err)
+
+	// 역할은 토큰을 새로 받을 때 반영된다.
+	refreshed, err := s.tokenService.Refresh(login.RefreshToken)
+	require.NoError(t, err)
+	claims, err = s.VerifyToken(refreshed.Token)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"support"}, claims.Roles)
+	assert.Equal(t, []string{models.PermissionRolesRead, models.PermissionUsersRead}, claims.Permissions)
+	assert.True(t, claims.HasPermission(models.PermissionUsersRead))
+	assert.False(t, claims.HasPermission(models.PermissionRolesWrite))
+
+	_, err = rbac.AssignRole(user.ID, "owner")
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+	_, err = rbac.AssignRole(user.ID+100, "support")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestRBACRemoveRoleRevokesIssuedAccessTokens(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	rbac := NewRBACService(repos, s.tokenService)
+
+	signUpTestUser(t, s, repos, "admin@example.com", "password123")
+	_, err := rbac.AssignRoleByEmail("admin@example.com", "admin")
+	require.NoError(t, err)
+	admin, _, err := s.Login("admin@example.com", "password123", "127.0.0.1")
+	require.NoError(t, err)
+	claims, err := s.VerifyToken(admin.Token)
+	require.NoError(t, err)
+	assert.True(t, claims.HasPermission(models.PermissionRolesWrite))
+
+	require.NoError(t, rbac.RemoveRoleByEmail("admin@example.com", "admin"))
+	_, err = s.VerifyToken(admin.Token)
+	assert.Error(t, err)
+
+	// 리프레시 토큰은 유지되고, 새 토큰에는 회수한 역할이 없다.
+	refreshed, err := s.tokenService.Refresh(admin.RefreshToken)
+	require.NoError(t, err)
+	claims, err = s.tokenService.parseToken(refreshed.Token, tokenUseAccess)
+	require.NoError(t, err)
+	assert.Empty(t, claims.Roles)
+	assert.Empty(t, claims.Permissions)
+}
+
+func TestServiceTokenScopesAreNotPermissions(t *testing.T) {
+	claims := &JWTClaims{SubjectType: SubjectTypeService, Scope: "users:read roles:write"}
+	assert.True(t, claims.HasScope(models.PermissionRolesWrite))
+	assert.False(t, claims.HasPermission(models.PermissionRolesWrite))
+	assert.False(t, claims.HasPermission(models.PermissionUsersRead))
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/models"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SigningKey는 JWT 서명/검증에 쓰이는 키 한 개다.
+// PrivateKey가 없는 키는 교체 후 유예 기간 동안 검증에만 사용된다.
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey interface{}
+	PublicKey  interface{}
+}
+
+// KeySet은 현재 서명에 쓰는 활성 키와, 이미 발급된 토큰 검증을 위해 유지하는 키들을 kid로 관리한다.
+// 비대칭 키가 설정되지 않은 경우 JWT_SECRET_KEY 기반 HS256으로 동작한다.
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// LoadKeySet은 JWT_SIGNING_KEY(단일 PEM) 또는 JWT_SIGNING_KEYS_DIR(<kid>.pem 파일들)에서 키를 읽는다.
+func LoadKeySet(cfg *config.Config) (*KeySet, error) {
+	keySet := &KeySet{keys: map[string]*SigningKey{}}
+
+	if cfg.JWTSigningKey != "" {
+		key, err := parseSigningKey(cfg.JWTSigningKeyID, []byte(cfg.JWTSigningKey))
+		if err != nil {
+			return nil, err
+		}
+		keySet.keys[key.ID] = key
+	}
+
+	if cfg.JWTSigningKeysDir != "" {
+		paths, err := filepath.Glob(filepath.Join(cfg.JWTSigningKeysDir, "*.pem"))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+			key, err := parseSigningKey(kid, data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			keySet.keys[key.ID] = key
+		}
+	}
+
+	if len(keySet.keys) == 0 {
+		return NewHMACKeySet(cfg.JWTSecretKey), nil
+	}
+
+	activeID := cfg.JWTActiveKeyID
+	if activeID == "" {
+		activeID = keySet.latestSigningKeyID()
+	}
+	active, ok := keySet.keys[activeID]
+	if !ok || active.PrivateKey == nil {
+		return nil, fmt.Errorf("active signing key %q not found or has no private key", activeID)
+	}
+	keySet.active = active
+
+	return keySet, nil
+}
+
+// NewHMACKeySet은 공유 비밀키 하나로 HS256 서명/검증하는 KeySet을 만든다.
+func NewHMACKeySet(secret string) *KeySet {
+	key := &SigningKey{
+		Method:     jwt.SigningMethodHS256,
+		PrivateKey: []byte(secret),
+		PublicKey:  []byte(secret),
+	}
+	return &KeySet{
+		active: key,
+		keys:   map[string]*SigningKey{"": key},
+	}
+}
+
+// NewKeySet은 이미 파싱된 키들로 KeySet을 만든다. activeID의 키로 서명한다.
+func NewKeySet(activeID string, keys ...*SigningKey) (*KeySet, error) {
+	keySet := &KeySet{keys: map[string]*SigningKey{}}
+	for _, key := range keys {
+		keySet.keys[key.ID] = key
+	}
+	active, ok := keySet.keys[activeID]
+	if !ok || active.PrivateKey == nil {
+		return nil, fmt.Errorf("active signing key %q not found or has no private key", activeID)
+	}
+	keySet.active = active
+	return keySet, nil
+}
+
+// Sign은 활성 키로 서명하고 헤더에 kid를 기록한다.
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.active.Method, claims)
+	if k.active.ID != "" {
+		token.Header["kid"] = k.active.ID
+	}
+	return token.SignedString(k.active.PrivateKey)
+}
+
+// Algorithm은 활성 키의 서명 알고리즘(RS256, ES256, HS256)이다.
+func (k *KeySet) Algorithm() string {
+	return k.active.Method.Alg()
+}
+
+// Keyfunc는 토큰 헤더의 kid로 검증 키를 고르며, 키에 지정된 알고리즘 외의 서명은 거부한다.
+func (k *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %q", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.PublicKey, nil
+}
+
+// JWKS는 검증에 쓰이는 공개키 목록을 반환한다. HS256 공유 비밀키는 공개하지 않는다.
+func (k *KeySet) JWKS() models.JSONWebKeySet {
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	jwks := models.JSONWebKeySet{Keys: []models.JSONWebKey{}}
+	for _, id := range ids {
+		if jwk, ok := toJSONWebKey(k.keys[id]); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+	return jwks
+}
+
+func (k *KeySet) latestSigningKeyID() string {
+	latest := ""
+	for id, key := range k.keys {
+		if key.PrivateKey != nil && id > latest {
+			latest = id
+		}
+	}
+	return latest
+}
+
+func parseSigningKey(kid string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid PEM data")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{ID: kid}
+	if signer, ok := parsed.(crypto.Signer); ok {
+		key.PrivateKey = signer
+		parsed = signer.Public()
+	}
+
+	switch pub := parsed.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+		key.PublicKey = pub
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return nil, errors.New("only P-256 EC keys are supported")
+		}
+		key.Method = jwt.SigningMethodES256
+		key.PublicKey = pub
+	default:
+		return nil, errors.New("unsupported key type (RSA or EC P-256 required)")
+	}
+
+	return key, nil
+}
+
+func toJSONWebKey(key *SigningKey) (models.JSONWebKey, bool) {
+	jwk := models.JSONWebKey{
+		KeyID:     key.ID,
+		Use:       "sig",
+		Algorithm: key.Method.Alg(),
+	}
+
+	switch pub := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		jwk.KeyType = "EC"
+		jwk.Curve = "P-256"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, 32)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, 32)))
+	default:
+		return models.JSONWebKey{}, false
+	}
+
+	return jwk, true
+}
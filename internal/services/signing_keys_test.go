@@ -0,0 +1,89 @@
+package services
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClaims() *JWTClaims {
+	return &JWTClaims{
+		UserID:   1,
+		Email:    "user@example.com",
+		TokenUse: tokenUseAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "test-jti",
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+}
+
+func TestKeySetRotationKeepsRetiringKeyForVerification(t *testing.T) {
+	oldRSA, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newEC, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	oldKey := &SigningKey{ID: "2026-01", Method: jwt.SigningMethodRS256, PrivateKey: oldRSA, PublicKey: &oldRSA.PublicKey}
+	newKey := &SigningKey{ID: "2026-10", Method: jwt.SigningMethodES256, PrivateKey: newEC, PublicKey: &newEC.PublicKey}
+
+	before, err := NewKeySet("2026-01", oldKey)
+	require.NoError(t, err)
+	issuedBeforeRotation, err := before.Sign(newTestClaims())
+	require.NoError(t, err)
+
+	// 교체 후에는 새 키로 서명하고, 이전 키는 공개키만 남겨 검증에 사용한다.
+	retiring := &SigningKey{ID: oldKey.ID, Method: oldKey.Method, PublicKey: oldKey.PublicKey}
+	after, err := NewKeySet("2026-10", retiring, newKey)
+	require.NoError(t, err)
+	issuedAfterRotation, err := after.Sign(newTestClaims())
+	require.NoError(t, err)
+
+	for _, tokenString := range []string{issuedBeforeRotation, issuedAfterRotation} {
+		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, after.Keyfunc)
+		require.NoError(t, err)
+		assert.True(t, token.Valid)
+	}
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(issuedAfterRotation, &JWTClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10", parsed.Header["kid"])
+
+	jwks := after.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "EC", jwks.Keys[1].KeyType)
+}
+
+func TestKeySetRejectsAlgorithmMismatch(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keySet, err := NewKeySet("k1", &SigningKey{ID: "k1", Method: jwt.SigningMethodES256, PrivateKey: ecKey, PublicKey: &ecKey.PublicKey})
+	require.NoError(t, err)
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, newTestClaims())
+	forged.Header["kid"] = "k1"
+	tokenString, err := forged.SignedString([]byte("attacker-secret"))
+	require.NoError(t, err)
+
+	_, err = jwt.ParseWithClaims(tokenString, &JWTClaims{}, keySet.Keyfunc)
+	assert.Error(t, err)
+}
+
+func TestHMACKeySetIsNotPublishedInJWKS(t *testing.T) {
+	keySet := NewHMACKeySet("secret")
+
+	tokenString, err := keySet.Sign(newTestClaims())
+	require.NoError(t, err)
+	_, err = jwt.ParseWithClaims(tokenString, &JWTClaims{}, keySet.Keyfunc)
+	assert.NoError(t, err)
+	assert.Empty(t, keySet.JWKS().Keys)
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"auth-go-service/internal/social"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"log"
+	"time"
+)
+
+const socialLoginStateExpiresIn = 60 * 10 // 10 minutes
+
+var (
+	ErrUnknownProvider        = errors.New("지원하지 않는 소셜 로그인입니다.")
+	ErrInvalidSocialState     = errors.New("소셜 로그인 요청이 만료되었거나 올바르지 않습니다. 다시 시도해주세요.")
+	ErrSocialLoginFailed      = errors.New("소셜 로그인에 실패했습니다. 다시 시도해주세요.")
+	ErrSocialEmailNotVerified = errors.New("인증된 이메일 주소를 제공하는 소셜 계정만 사용할 수 있습니다. 이메일 제공에 동의했는지 확인해주세요.")
+	ErrIdentityAlreadyLinked  = errors.New("이미 가입한 소셜 계정입니다. 다시 로그인해주세요.")
+)
+
+// SocialLoginService는 카카오, 네이버, 구글 계정으로 로그인(인가 코드 + PKCE)과 가입을 처리한다.
+type SocialLoginService struct {
+	repos       *repository.Repositories
+	providers   map[string]social.Provider
+	authService *AuthService
+}
+
+func NewSocialLoginService(repos *repository.Repositories, providers map[string]social.Provider, authService *AuthService) *SocialLoginService {
+	return &SocialLoginService{
+		repos:       repos,
+		providers:   providers,
+		authService: authService,
+	}
+}
+
+// Authorize는 state와 PKCE code_verifier를 만들어 저장하고, 사용자를 보낼 제공자 로그인 화면 주소를 반환한다.
+func (s *SocialLoginService) Authorize(providerName string) (*models.SocialAuthorizeResponse, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	now := time.Now()
+	// 콜백까지 오지 않은 요청이 남지 않도록 만료된 state를 함께 정리한다.
+	if err := s.repos.SocialLoginStates.DeleteExpired(now); err != nil {
+		return nil, err
+	}
+
+	state, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := social.NewCodeVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repos.SocialLoginStates.Create(&models.SocialLoginState{
+		StateHash:    hashToken(state),
+		Provider:     providerName,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    now.Add(socialLoginStateExpiresIn * time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SocialAuthorizeResponse{
+		AuthorizationURL: provider.AuthCodeURL(state, social.CodeChallenge(codeVerifier)),
+		State:            state,
+		ExpiresIn:        socialLoginStateExpiresIn,
+	}, nil
+}
+
+// Callback은 인가 코드로 제공자 사용자 정보를 받아 로그인한다.
+// 연결된 계정이 없으면 제공자가 확인한 이메일로 기존 계정과 연결하고, 기존 계정도 없으면 가입 전용 토큰을 반환한다.
+// 2단계 인증을 사용하는 계정이면 2단계 인증용 임시 토큰을, 휴면 계정이면 *DormantAccountError를 반환한다.
+func (s *SocialLoginService) Callback(ctx context.Context, providerName, code, state string) (*models.LoginResponse, *models.MFAChallengeResponse, *models.SocialSignUpRequiredResponse, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, nil, nil, ErrUnknownProvider
+	}
+
+	stored, err := s.repos.SocialLoginStates.Consume(hashToken(state))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, nil, ErrInvalidSocialState
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if stored.Provider != providerName || time.Now().After(stored.ExpiresAt) {
+		return nil, nil, nil, ErrInvalidSocialState
+	}
+
+	profile, err := provider.Exchange(ctx, code, stored.CodeVerifier)
+	if err != nil {
+		log.Printf("Social login failed: %v", err)
+		return nil, nil, nil, ErrSocialLoginFailed
+	}
+
+	identity, err := s.repos.UserIdentities.FindByProviderSubject(providerName, profile.Subject)
+	if err == nil {
+		response, challenge, err := s.login(identity.UserID)
+		return response, challenge, nil, err
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, nil, err
+	}
+
+	// 제공자가 소유를 확인한 이메일만 기존 계정과 연결하거나 새 계정에 사용한다.
+	if !profile.EmailVerified {
+		return nil, nil, nil, ErrSocialEmailNotVerified
+	}
+
+	userID, err := s.findAccountByEmail(profile.Email)
+	if errors.Is(err, repository.ErrNotFound) {
+		signUp, err := s.signUpRequired(providerName, profile)
+		return nil, nil, signUp, err
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := s.repos.UserIdentities.Create(&models.UserIdentity{
+		UserID:   userID,
+		Provider: providerName,
+		Subject:  profile.Subject,
+	}); err != nil {
+		return nil, nil, nil, err
+	}
+	response, challenge, err := s.login(userID)
+	return response, challenge, nil, err
+}
+
+// SignUp은 가입 전용 토큰의 소셜 계정으로 새 사용자를 만들고 토큰을 발급한다.
+// 이메일은 제공자가 확인했으므로 이메일 인증을 다시 받지 않으며, 비밀번호는 비밀번호 재설정으로 만들 수 있다.
+func (s *SocialLoginService) SignUp(req *models.SocialSignUpRequest, acceptLanguage, ip string) (*models.LoginResponse, error) {
+	claims, err := s.authService.tokenService.VerifySocialSignUp(req.SignUpToken)
+	if err != nil {
+		return nil, errors.New("소셜 가입 요청이 만료되었거나 올바르지 않습니다. 다시 로그인해주세요.")
+	}
+
+	if _, err := s.repos.UserIdentities.FindByProviderSubject(claims.Provider, claims.Subject); err == nil {
+		return nil, ErrIdentityAlreadyLinked
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	now := time.Now()
+	currentTerms, err := s.repos.Terms.FindCurrent(now)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireTerms(req.Terms, currentTerms); err != nil {
+		return nil, err
+	}
+	if err := checkTermsCurrent(req.Terms, currentTerms); err != nil {
+		return nil, err
+	}
+
+	if err := s.authService.releaseSignUpEmail(claims.Email); err != nil {
+		return nil, err
+	}
+
+	// 비밀번호로는 로그인할 수 없도록 알 수 없는 값을 저장한다.
+	password, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := models.User{
+		Name:                 req.Name,
+		Email:                claims.Email,
+		Phone:                req.Phone,
+		EncryptedPassword:    string(hashedPassword),
+		SignUpToken:          uuid.New().String(),
+		AgreedMarketingOptIn: req.AgreedMarketingOptIn,
+		SignUpStatus:         models.SignUpStatusCompleted,
+		Locale:               s.authService.emailService.ResolveLocale(req.Locale, acceptLanguage),
+	}
+	identity := models.UserIdentity{Provider: claims.Provider, Subject: claims.Subject}
+	if err := s.authService.createUser(&user, &identity, req.Terms, currentTerms, ip, now); err != nil {
+		return nil, err
+	}
+
+	return s.authService.tokenService.IssueTokens(user)
+}
+
+// findAccountByEmail은 email을 사용하는 가입 완료(또는 휴면) 계정의 ID를 찾는다.
+// 탈퇴 처리 중인 계정의 이메일이면 ErrAccountPendingDeletion, 계정이 없으면 repository.ErrNotFound다.
+func (s *SocialLoginService) findAccountByEmail(email string) (uint, error) {
+	user, err := s.repos.Users.FindByEmail(email)
+	if err == nil && user.SignUpStatus == models.SignUpStatusCompleted {
+		return user.ID, nil
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return 0, err
+	}
+
+	if dormant, err := s.repos.DormantUsers.FindByEmail(email); err == nil {
+		return dormant.UserID, nil
+	}
+	if _, err := s.repos.Users.FindDeletedByEmail(email); err == nil {
+		return 0, ErrAccountPendingDeletion
+	}
+	return 0, repository.ErrNotFound
+}
+
+// login은 연결된 계정으로 로그인한다. 휴면 계정이면 비밀번호 로그인과 같이 휴면 해제 인증 코드를 보낸다.
+func (s *SocialLoginService) login(userID uint) (*models.LoginResponse, *models.MFAChallengeResponse, error) {
+	user, err := s.repos.Users.FindByID(userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, ErrAccountPendingDeletion
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if user.DormantAt != nil {
+		dormant, err := s.repos.DormantUsers.FindByUserID(user.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, s.authService.startReactivation(dormant)
+	}
+	if user.SignUpStatus != models.SignUpStatusCompleted {
+		return nil, nil, ErrSocialLoginFailed
+	}
+
+	if s.authService.mfaService.IsEnabled(user.ID) {
+		challenge, err := s.authService.tokenService.IssueMFAChallenge(*user)
+		return nil, challenge, err
+	}
+
+	response, err := s.authService.issueLoginTokens(*user)
+	return response, nil, err
+}
+
+func (s *SocialLoginService) signUpRequired(providerName string, profile *social.Profile) (*models.SocialSignUpRequiredResponse, error) {
+	token, err := s.authService.tokenService.IssueSocialSignUp(providerName, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SocialSignUpRequiredResponse{
+		SignUpRequired: true,
+		SignUpToken:    token,
+		Email:          profile.Email,
+		Name:           profile.Name,
+		ExpiresIn:      socialSignUpExpiresIn,
+	}, nil
+}
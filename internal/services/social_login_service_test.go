@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"auth-go-service/internal/social"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKakao는 인가 코드 + PKCE를 검증하는 가짜 카카오 OAuth 서버다.
+type fakeKakao struct {
+	mu        sync.Mutex
+	challenge string // 마지막 로그인 화면 요청의 code_challenge
+	id        int64
+	email     string
+}
+
+func (f *fakeKakao) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if r.FormValue("code") != "valid-code" || social.CodeChallenge(r.FormValue("code_verifier")) != f.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "kakao-access-token", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/v2/user/me", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer kakao-access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"id": f.id,
+			"kakao_account": map[string]any{
+				"email":             f.email,
+				"is_email_valid":    true,
+				"is_email_verified": true,
+				"profile":           map[string]string{"nickname": "카카오"},
+			},
+		})
+	})
+	return mux
+}
+
+// authorize는 소셜 로그인을 시작하고, 가짜 서버가 PKCE를 검증할 수 있도록 code_challenge를 넘겨준다.
+func (f *fakeKakao) authorize(t *testing.T, s *SocialLoginService) string {
+	t.Helper()
+
+	response, err := s.Authorize("kakao")
+	require.NoError(t, err)
+	authURL, err := url.Parse(response.AuthorizationURL)
+	require.NoError(t, err)
+	assert.Equal(t, response.State, authURL.Query().Get("state"))
+	assert.Equal(t, "S256", authURL.Query().Get("code_challenge_method"))
+
+	f.mu.Lock()
+	f.challenge = authURL.Query().Get("code_challenge")
+	f.mu.Unlock()
+	return response.State
+}
+
+func TestSocialLoginLinksVerifiedEmailAndSignsUpNewUsers(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+	existing, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+
+	kakao := &fakeKakao{id: 1001, email: "user@example.com"}
+	server := httptest.NewServer(kakao.handler())
+	defer server.Close()
+	providers, err := social.NewProviders(map[string]config.OAuthProvider{
+		"kakao": {
+			ClientID:     "client-id",
+			ClientSecret: "client-secret",
+			RedirectURL:  "https://app.example.com/auth/kakao/callback",
+			AuthURL:      server.URL + "/oauth/authorize",
+			TokenURL:     server.URL + "/oauth/token",
+			UserInfoURL:  server.URL + "/v2/user/me",
+		},
+	})
+	require.NoError(t, err)
+	socialLogin := NewSocialLoginService(repos, providers, s)
+	ctx := context.Background()
+
+	_, err = socialLogin.Authorize("apple")
+	assert.ErrorIs(t, err, ErrUnknownProvider)
+
+	// 제공자가 확인한 이메일이 같으면 기존 계정에 연결한다.
+	state := kakao.authorize(t, socialLogin)
+	response, _, signUp, err := socialLogin.Callback(ctx, "kakao", "valid-code", state)
+	require.NoError(t, err)
+	assert.Nil(t, signUp)
+	require.NotNil(t, response)
+	identities, err := repos.UserIdentities.FindByUserID(existing.ID)
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	assert.Equal(t, "1001", identities[0].Subject)
+
+	// state는 한 번만 사용할 수 있다.
+	_, _, _, err = socialLogin.Callback(ctx, "kakao", "valid-code", state)
+	assert.ErrorIs(t, err, ErrInvalidSocialState)
+
+	// code_verifier가 맞지 않으면 제공자가 토큰을 주지 않는다.
+	state = kakao.authorize(t, socialLogin)
+	kakao.mu.Lock()
+	kakao.challenge = "tampered"
+	kakao.mu.Unlock()
+	_, _, _, err = socialLogin.Callback(ctx, "kakao", "valid-code", state)
+	assert.ErrorIs(t, err, ErrSocialLoginFailed)
+
+	// 연결된 뒤에는 제공자 계정의 이메일이 바뀌어도 같은 계정으로 로그인한다.
+	kakao.email = "changed@example.com"
+	state = kakao.authorize(t, socialLogin)
+	response, _, _, err = socialLogin.Callback(ctx, "kakao", "valid-code", state)
+	require.NoError(t, err)
+	claims, err := s.VerifyToken(response.Token)
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, claims.UserID)
+
+	// 연결된 계정도, 같은 이메일의 계정도 없으면 가입 전용 토큰을 받는다.
+	kakao.id, kakao.email = 2002, "new@example.com"
+	state = kakao.authorize(t, socialLogin)
+	response, _, signUp, err = socialLogin.Callback(ctx, "kakao", "valid-code", state)
+	require.NoError(t, err)
+	assert.Nil(t, response)
+	require.NotNil(t, signUp)
+	assert.Equal(t, "new@example.com", signUp.Email)
+	assert.Equal(t, "카카오", signUp.Name)
+
+	req := &models.SocialSignUpRequest{SignUpToken: signUp.SignUpToken, Name: "김카카오", Phone: "010-0000-0000"}
+	response, err = socialLogin.SignUp(req, "", "127.0.0.1")
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	created, err := repos.Users.FindByEmail("new@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, models.SignUpStatusCompleted, created.SignUpStatus)
+	identity, err := repos.UserIdentities.FindByProviderSubject("kakao", "2002")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, identity.UserID)
+
+	_, err = socialLogin.SignUp(req, "", "127.0.0.1")
+	assert.ErrorIs(t, err, ErrIdentityAlreadyLinked)
+	// 가입 전용 토큰은 액세스 토큰으로 사용할 수 없다.
+	_, err = s.VerifyToken(signUp.SignUpToken)
+	assert.Error(t, err)
+}
+
+func TestSocialSignUpLeavesNoUserWhenIdentityCannotBeLinked(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	// 같은 제공자 계정으로 동시에 가입해 다른 요청이 먼저 연결한 경우
+	require.NoError(t, repos.UserIdentities.Create(&models.UserIdentity{UserID: 100, Provider: "kakao", Subject: "3003"}))
+
+	user := models.User{Name: "김카카오", Email: "new@example.com", SignUpStatus: models.SignUpStatusCompleted, AgreedMarketingOptIn: true}
+	identity := models.UserIdentity{Provider: "kakao", Subject: "3003"}
+	err := s.createUser(&user, &identity, nil, nil, "127.0.0.1", time.Now())
+	require.Error(t, err)
+
+	_, err = repos.Users.FindByEmail("new@example.com")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	consents, err := repos.MarketingConsents.FindByUserID(user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, consents)
+}
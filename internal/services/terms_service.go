@@ -0,0 +1,174 @@
+package services
+
+import (
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRequiredTermsNotAccepted = errors.New("필수 약관에 모두 동의해야 합니다.")
+	ErrTermsNotCurrent          = errors.New("현재 시행 중인 약관 버전이 아닙니다. 약관을 다시 확인해주세요.")
+)
+
+// TermsService는 약관 목록과 사용자의 약관 동의를 관리한다.
+type TermsService struct {
+	repos *repository.Repositories
+}
+
+func NewTermsService(repos *repository.Repositories) *TermsService {
+	return &TermsService{
+		repos: repos,
+	}
+}
+
+// ListCurrent는 현재 시행 중인 약관 목록을 반환한다(회원가입 화면용).
+func (s *TermsService) ListCurrent() ([]models.Terms, error) {
+	return s.repos.Terms.FindCurrent(time.Now())
+}
+
+// GetStatus는 현재 약관별로 사용자가 마지막으로 동의한 버전과 다시 동의해야 하는지 여부를 반환한다.
+func (s *TermsService) GetStatus(userID uint) ([]models.TermsStatus, error) {
+	current, err := s.repos.Terms.FindCurrent(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	agreements, err := s.repos.Terms.FindAgreementsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := latestTermsAgreements(agreements)
+	statuses := make([]models.TermsStatus, 0, len(current))
+	for _, terms := range current {
+		status := models.TermsStatus{Terms: terms}
+		if agreement, ok := latest[terms.Code]; ok {
+			status.AgreedVersion = agreement.TermsVersion
+			agreedAt := agreement.AgreedAt
+			status.AgreedAt = &agreedAt
+		}
+		status.Pending = terms.Required && !hasAgreed(agreements, terms)
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Accept는 로그인한 사용자의 약관 동의를 ip와 함께 기록하고 갱신된 동의 현황을 반환한다.
+func (s *TermsService) Accept(userID uint, accepted []models.AcceptedTerms, ip string) ([]models.TermsStatus, error) {
+	now := time.Now()
+	current, err := s.repos.Terms.FindCurrent(now)
+	if err != nil {
+		return nil, err
+	}
+	if err := acceptTerms(s.repos, userID, accepted, current, ip, now); err != nil {
+		return nil, err
+	}
+	return s.GetStatus(userID)
+}
+
+// requireTerms는 accepted가 current의 필수 약관을 모두 포함하는지 확인한다. 빠진 약관의 code를 오류에 담는다.
+func requireTerms(accepted []models.AcceptedTerms, current []models.Terms) error {
+	var missing []string
+	for _, terms := range current {
+		if !terms.Required {
+			continue
+		}
+		found := false
+		for _, a := range accepted {
+			if a.Code == terms.Code && a.Version == terms.Version {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, terms.Code)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w (%s)", ErrRequiredTermsNotAccepted, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkTermsCurrent는 accepted가 모두 current(현재 시행 중인 약관)에 있는 버전인지 확인한다.
+func checkTermsCurrent(accepted []models.AcceptedTerms, current []models.Terms) error {
+	for _, a := range accepted {
+		found := false
+		for _, terms := range current {
+			if a.Code == terms.Code && a.Version == terms.Version {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w (%s %s)", ErrTermsNotCurrent, a.Code, a.Version)
+		}
+	}
+	return nil
+}
+
+// acceptTerms는 accepted를 동의 기록으로 남긴다. 현재 버전이 아닌 약관이 있으면 아무것도 기록하지 않는다.
+func acceptTerms(repos *repository.Repositories, userID uint, accepted []models.AcceptedTerms, current []models.Terms, ip string, at time.Time) error {
+	if err := checkTermsCurrent(accepted, current); err != nil {
+		return err
+	}
+
+	return repos.Terms.CreateAgreements(termsAgreements(userID, accepted, ip, at))
+}
+
+func termsAgreements(userID uint, accepted []models.AcceptedTerms, ip string, at time.Time) []models.TermsAgreement {
+	agreements := make([]models.TermsAgreement, 0, len(accepted))
+	for _, a := range accepted {
+		agreements = append(agreements, models.TermsAgreement{
+			UserID:       userID,
+			TermsCode:    a.Code,
+			TermsVersion: a.Version,
+			IPAddress:    ip,
+			AgreedAt:     at,
+		})
+	}
+	return agreements
+}
+
+// pendingRequiredTerms는 사용자가 현재 버전에 동의하지 않은 필수 약관을 반환한다.
+func pendingRequiredTerms(repos *repository.Repositories, userID uint, now time.Time) ([]models.Terms, error) {
+	current, err := repos.Terms.FindCurrent(now)
+	if err != nil || len(current) == 0 {
+		return nil, err
+	}
+	agreements, err := repos.Terms.FindAgreementsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []models.Terms
+	for _, terms := range current {
+		if terms.Required && !hasAgreed(agreements, terms) {
+			pending = append(pending, terms)
+		}
+	}
+	return pending, nil
+}
+
+func hasAgreed(agreements []models.TermsAgreement, terms models.Terms) bool {
+	for _, agreement := range agreements {
+		if agreement.TermsCode == terms.Code && agreement.TermsVersion == terms.Version {
+			return true
+		}
+	}
+	return false
+}
+
+// latestTermsAgreements는 동의 기록(최신순)에서 약관 종류별 마지막 동의를 구한다.
+func latestTermsAgreements(agreements []models.TermsAgreement) map[string]models.TermsAgreement {
+	latest := map[string]models.TermsAgreement{}
+	for _, agreement := range agreements {
+		if _, ok := latest[agreement.TermsCode]; !ok {
+			latest[agreement.TermsCode] = agreement
+		}
+	}
+	return latest
+}
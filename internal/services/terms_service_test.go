@@ -0,0 +1,108 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"auth-go-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignUpRequiresCurrentTermsAndLoginSignalsNewVersion(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	termsService := NewTermsService(repos)
+	effectiveAt := time.Now().Add(-time.Hour)
+	require.NoError(t, repos.Terms.Create(&models.Terms{Code: "service", Version: "1", Title: "서비스 이용약관", URL: "/terms/service/1", Required: true, DisplayOrder: 1, EffectiveAt: effectiveAt}))
+	require.NoError(t, repos.Terms.Create(&models.Terms{Code: "privacy", Version: "1", Title: "개인정보 수집 및 이용 동의", URL: "/terms/privacy/1", Required: true, DisplayOrder: 2, EffectiveAt: effectiveAt}))
+
+	verifiedAt := time.Now()
+	require.NoError(t, repos.EmailVerifications.Create(&models.EmailVerification{
+		Email:      "user@example.com",
+		CodeHash:   "unused",
+		ExpiresAt:  time.Now().Add(10 * time.Minute),
+		VerifiedAt: &verifiedAt,
+	}))
+	req := &models.SignUpRequest{
+		Name:     "홍길동",
+		Email:    "user@example.com",
+		Phone:    "010-1234-5678",
+		Password: "password123",
+		Terms:    []models.AcceptedTerms{{Code: "service", Version: "1"}},
+	}
+
+	_, err := s.SignUp(req, "", "127.0.0.1")
+	assert.ErrorIs(t, err, ErrRequiredTermsNotAccepted)
+	req.Terms = append(req.Terms, models.AcceptedTerms{Code: "privacy", Version: "0"})
+	_, err = s.SignUp(req, "", "127.0.0.1")
+	assert.ErrorIs(t, err, ErrRequiredTermsNotAccepted)
+	req.Terms = append(req.Terms, models.AcceptedTerms{Code: "privacy", Version: "1"})
+	_, err = s.SignUp(req, "", "127.0.0.1")
+	assert.ErrorIs(t, err, ErrTermsNotCurrent)
+	req.Terms = []models.AcceptedTerms{{Code: "service", Version: "1"}, {Code: "privacy", Version: "1"}}
+	_, err = s.SignUp(req, "", "127.0.0.1")
+	require.NoError(t, err)
+
+	response, _, err := s.Login("user@example.com", "password123", "127.0.0.1")
+	require.NoError(t, err)
+	assert.Empty(t, response.PendingTerms)
+
+	// 개인정보 처리방침 새 버전이 시행되면 로그인 응답으로 다시 동의받아야 함을 알린다.
+	require.NoError(t, repos.Terms.Create(&models.Terms{Code: "privacy", Version: "2", Title: "개인정보 수집 및 이용 동의", URL: "/terms/privacy/2", Required: true, DisplayOrder: 2, EffectiveAt: time.Now()}))
+	response, _, err = s.Login("user@example.com", "password123", "127.0.0.1")
+	require.NoError(t, err)
+	require.Len(t, response.PendingTerms, 1)
+	assert.Equal(t, "2", response.PendingTerms[0].Version)
+
+	stored, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+	_, err = termsService.Accept(stored.ID, []models.AcceptedTerms{{Code: "privacy", Version: "1"}}, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrTermsNotCurrent)
+	statuses, err := termsService.Accept(stored.ID, []models.AcceptedTerms{{Code: "privacy", Version: "2"}}, "10.0.0.1")
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "2", statuses[1].AgreedVersion)
+	assert.False(t, statuses[1].Pending)
+
+	response, _, err = s.Login("user@example.com", "password123", "127.0.0.1")
+	require.NoError(t, err)
+	assert.Empty(t, response.PendingTerms)
+}
+
+func TestSignUpStoresTermsAgreementsAndMarketingConsentsWithUser(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	require.NoError(t, repos.Terms.Create(&models.Terms{Code: "service", Version: "1", Title: "서비스 이용약관", URL: "/terms/service/1", Required: true, DisplayOrder: 1, EffectiveAt: time.Now().Add(-time.Hour)}))
+
+	verifiedAt := time.Now()
+	require.NoError(t, repos.EmailVerifications.Create(&models.EmailVerification{
+		Email:      "user@example.com",
+		CodeHash:   "unused",
+		ExpiresAt:  time.Now().Add(10 * time.Minute),
+		VerifiedAt: &verifiedAt,
+	}))
+	_, err := s.SignUp(&models.SignUpRequest{
+		Name:                 "홍길동",
+		Email:                "user@example.com",
+		Phone:                "010-1234-5678",
+		Password:             "password123",
+		Terms:                []models.AcceptedTerms{{Code: "service", Version: "1"}},
+		AgreedMarketingOptIn: true,
+	}, "", "127.0.0.1")
+	require.NoError(t, err)
+
+	user, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+	assert.NotNil(t, user.MarketingConfirmedAt)
+	agreements, err := repos.Terms.FindAgreementsByUserID(user.ID)
+	require.NoError(t, err)
+	require.Len(t, agreements, 1)
+	assert.Equal(t, "127.0.0.1", agreements[0].IPAddress)
+	consents, err := repos.MarketingConsents.FindByUserID(user.ID)
+	require.NoError(t, err)
+	require.Len(t, consents, len(models.MarketingChannels))
+	for _, consent := range consents {
+		assert.Equal(t, user.ID, consent.UserID)
+		assert.True(t, consent.Agreed)
+	}
+}
@@ -0,0 +1,492 @@
+package services
+
+import (
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"auth-go-service/internal/social"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+const (
+	tokenUseAccess       = "access"
+	tokenUseMFAPending   = "mfa_pending"
+	tokenUseSocialSignUp = "social_sign_up"
+
+	// sub_type 클레임: 토큰 주체가 사용자인지 서비스 클라이언트인지 나타낸다. 서비스 토큰의 sub는 client_id다.
+	SubjectTypeUser    = "user"
+	SubjectTypeService = "service"
+
+	mfaChallengeExpiresIn = 60 * 5  // 5 minutes
+	socialSignUpExpiresIn = 60 * 10 // 10 minutes
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("Invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("Refresh token has already been used. Please log in again.")
+)
+
+type JWTClaims struct {
+	UserID      uint     `json:"userId"`
+	Email       string   `json:"email"`
+	Name        string   `json:"name"`
+	SessionID   string   `json:"sid,omitempty"`
+	TokenUse    string   `json:"token_use"`
+	SubjectType string   `json:"sub_type,omitempty"`
+	Provider    string   `json:"provider,omitempty"`
+	ClientID    string   `json:"client_id,omitempty"`
+	Scope       string   `json:"scope,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// IsService는 client_credentials로 발급한 서비스 토큰인지 여부다. sub_type이 없는 이전 토큰은 사용자 토큰이다.
+func (c *JWTClaims) IsService() bool {
+	return c.SubjectType == SubjectTypeService
+}
+
+// HasPermission은 사용자가 역할로 받은 권한(permissions 클레임)에 permission이 있는지 확인한다.
+// 서비스 토큰의 scope는 권한으로 보지 않으므로, 서비스 토큰은 HasScope로 확인한다.
+func (c *JWTClaims) HasPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope는 클라이언트에 발급한 토큰(서비스 토큰 등)의 scope에 scope가 있는지 확인한다.
+func (c *JWTClaims) HasScope(scope string) bool {
+	return hasScope(c.Scope, scope)
+}
+
+// TokenService는 액세스 토큰(JWT)과 서버에 저장되는 리프레시 토큰의 발급/검증을 담당한다.
+type TokenService struct {
+	repos            *repository.Repositories
+	keys             *KeySet
+	jwtExpiresIn     int
+	refreshExpiresIn int
+	issuer           string
+}
+
+func NewTokenService(cfg *config.Config, repos *repository.Repositories, keys *KeySet) *TokenService {
+	return &TokenService{
+		repos:            repos,
+		keys:             keys,
+		jwtExpiresIn:     cfg.JWTExpiresIn,
+		refreshExpiresIn: cfg.RefreshTokenExpiresIn,
+		issuer:           cfg.OIDCIssuer,
+	}
+}
+
+// IssueTokens는 새 로그인 세션을 위해 액세스 토큰과 새로운 계열(family)의 리프레시 토큰을 발급한다.
+func (t *TokenService) IssueTokens(user models.User) (*models.LoginResponse, error) {
+	response, _, err := t.issueTokens(user, uuid.New().String())
+	return response, err
+}
+
+// Refresh는 리프레시 토큰을 1회 사용 후 교체(rotation)한다.
+// 이미 교체된 토큰이 다시 제출되면 탈취로 간주하고 같은 계열의 토큰을 모두 폐기한다.
+func (t *TokenService) Refresh(rawToken string) (*models.LoginResponse, error) {
+	stored, err := t.repos.RefreshTokens.FindByHash(hashToken(rawToken))
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if stored.RevokedAt != nil {
+		t.repos.RefreshTokens.RevokeFamily(stored.FamilyID, time.Now())
+		return nil, ErrRefreshTokenReused
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := t.repos.Users.FindByID(stored.UserID)
+	// 휴면 전환 때 토큰을 폐기하지만, 전환 직전에 조회한 토큰으로 휴면 계정의 토큰이 발급되지 않도록 다시 확인한다.
+	if err != nil || user.SignUpStatus != models.SignUpStatusCompleted || user.DormantAt != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// 동시 요청으로 같은 토큰이 두 번 교체되지 않도록 조건부 업데이트로 선점한다.
+	revoked, err := t.repos.RefreshTokens.RevokeIfActive(stored.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !revoked {
+		t.repos.RefreshTokens.RevokeFamily(stored.FamilyID, time.Now())
+		return nil, ErrRefreshTokenReused
+	}
+
+	response, replacement, err := t.issueTokens(*user, stored.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	t.repos.RefreshTokens.SetReplacedBy(stored.ID, replacement.ID)
+
+	return response, nil
+}
+
+// FindActiveRefreshToken은 폐기(교체, 계열 폐기 포함)되지 않았고 만료 전인 리프레시 토큰을 찾는다. 토큰을 교체하지 않는다(토큰 확인용).
+func (t *TokenService) FindActiveRefreshToken(rawToken string) (*models.RefreshToken, error) {
+	stored, err := t.repos.RefreshTokens.FindByHash(hashToken(rawToken))
+	if err != nil || stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := t.repos.Users.FindByID(stored.UserID)
+	if err != nil || user.SignUpStatus != models.SignUpStatusCompleted || user.DormantAt != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	return stored, nil
+}
+
+func (t *TokenService) VerifyAccessToken(tokenString string) (*JWTClaims, error) {
+	claims, err := t.parseToken(tokenString, tokenUseAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := t.isRevoked(claims)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, errors.New("Token has been revoked")
+	}
+
+	return claims, nil
+}
+
+// IssueMFAChallenge는 비밀번호 확인을 마친 사용자에게 2단계 인증 전용 단기 토큰을 발급한다.
+// 이 토큰은 액세스 토큰으로 사용할 수 없다.
+func (t *TokenService) IssueMFAChallenge(user models.User) (*models.MFAChallengeResponse, error) {
+	now := time.Now()
+	claims := &JWTClaims{
+		UserID:   user.ID,
+		Email:    user.Email,
+		TokenUse: tokenUseMFAPending,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(mfaChallengeExpiresIn * time.Second)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	token, err := t.keys.Sign(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MFAChallengeResponse{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresIn:   mfaChallengeExpiresIn,
+	}, nil
+}
+
+func (t *TokenService) VerifyMFAChallenge(tokenString string) (*JWTClaims, error) {
+	return t.parseToken(tokenString, tokenUseMFAPending)
+}
+
+// IssueSocialSignUp은 연결된 계정이 없는 소셜 로그인 사용자에게 가입 전용 단기 토큰을 발급한다.
+// 제공자와 제공자의 사용자 ID(sub), 확인된 이메일을 담으며, 액세스 토큰으로 사용할 수 없다.
+func (t *TokenService) IssueSocialSignUp(provider string, profile *social.Profile) (string, error) {
+	now := time.Now()
+	claims := &JWTClaims{
+		Email:    profile.Email,
+		Name:     profile.Name,
+		TokenUse: tokenUseSocialSignUp,
+		Provider: provider,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   profile.Subject,
+			ExpiresAt: jwt.NewNumericDate(now.Add(socialSignUpExpiresIn * time.Second)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	return t.keys.Sign(claims)
+}
+
+func (t *TokenService) VerifySocialSignUp(tokenString string) (*JWTClaims, error) {
+	return t.parseToken(tokenString, tokenUseSocialSignUp)
+}
+
+// IssueClientAccessToken은 OpenID Connect 클라이언트(clientID)에 위임된 scope의 액세스 토큰을 발급한다.
+// 리프레시 토큰은 발급하지 않으며, 로그인으로 보고 마지막 로그인 시간을 기록한다.
+func (t *TokenService) IssueClientAccessToken(user models.User, clientID, scope string) (string, error) {
+	if err := t.repos.Users.RecordLogin(user.ID, time.Now()); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := &JWTClaims{
+		UserID:      user.ID,
+		Email:       user.Email,
+		Name:        user.Name,
+		TokenUse:    tokenUseAccess,
+		SubjectType: SubjectTypeUser,
+		ClientID:    clientID,
+		Scope:       scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   fmt.Sprint(user.ID),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(t.jwtExpiresIn) * time.Second)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	return t.keys.Sign(claims)
+}
+
+// IssueServiceToken은 client_credentials로 인증한 서비스 클라이언트에 액세스 토큰을 발급한다.
+// 사용자 정보 없이 sub(client_id)와 허용된 scope만 담는다.
+func (t *TokenService) IssueServiceToken(clientID, scope string) (string, error) {
+	now := time.Now()
+	claims := &JWTClaims{
+		TokenUse:    tokenUseAccess,
+		SubjectType: SubjectTypeService,
+		ClientID:    clientID,
+		Scope:       scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   clientID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(t.jwtExpiresIn) * time.Second)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	return t.keys.Sign(claims)
+}
+
+// IssueIDToken은 userInfo의 클레임에 iss, aud(clientID), iat, exp, nonce를 더해 ID 토큰을 발급한다.
+func (t *TokenService) IssueIDToken(clientID, nonce string, userInfo *models.UserInfo) (string, error) {
+	data, err := json.Marshal(userInfo)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.MapClaims{}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims["iss"] = t.issuer
+	claims["aud"] = clientID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(time.Duration(t.jwtExpiresIn) * time.Second).Unix()
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	return t.keys.Sign(claims)
+}
+
+// SigningAlgorithm은 토큰 서명 알고리즘이다(OpenID Connect discovery용).
+func (t *TokenService) SigningAlgorithm() string {
+	return t.keys.Algorithm()
+}
+
+// JWKS는 다른 서비스가 토큰을 검증할 수 있도록 공개키 목록을 반환한다.
+func (t *TokenService) JWKS() models.JSONWebKeySet {
+	return t.keys.JWKS()
+}
+
+// RevokeAccessToken은 만료 전의 액세스 토큰 한 개를 즉시 무효화한다.
+func (t *TokenService) RevokeAccessToken(claims *JWTClaims) error {
+	return t.repos.TokenRevocations.RevokeToken(claims.ID, claims.UserID, claims.ExpiresAt.Time)
+}
+
+// RevokeSession은 로그인 세션(리프레시 토큰 계열)에 속한 리프레시 토큰을 모두 폐기한다.
+func (t *TokenService) RevokeSession(userID uint, sessionID string) error {
+	return t.repos.RefreshTokens.RevokeUserFamily(userID, sessionID, time.Now())
+}
+
+// RevokeAllForUser는 사용자에게 발급된 모든 액세스/리프레시 토큰을 폐기한다.
+// exceptSessionID가 주어지면 해당 세션의 토큰은 유지한다.
+func (t *TokenService) RevokeAllForUser(userID uint, exceptSessionID string) error {
+	now := time.Now()
+	if err := t.repos.TokenRevocations.RevokeAllForUser(userID, now, exceptSessionID); err != nil {
+		return err
+	}
+
+	return t.repos.RefreshTokens.RevokeAllForUser(userID, exceptSessionID, now)
+}
+
+// RevokeAccessTokensForUser는 사용자에게 이미 발급된 액세스 토큰만 폐기한다.
+// 리프레시 토큰은 유지되므로, 클라이언트는 토큰을 갱신해 바뀐 역할과 권한이 담긴 토큰을 받는다.
+func (t *TokenService) RevokeAccessTokensForUser(userID uint) error {
+	return t.repos.TokenRevocations.RevokeAllForUser(userID, time.Now(), "")
+}
+
+// ReissueTokens는 사용자의 모든 세션 토큰을 폐기하고 새 세션의 토큰을 발급한다.
+// 토큰에 담긴 사용자 정보(이메일 등)가 바뀌어 기존 토큰을 더 이상 쓰면 안 될 때 사용한다.
+func (t *TokenService) ReissueTokens(user models.User) (*models.LoginResponse, error) {
+	// 새 세션을 일괄 폐기 대상에서 제외해야 같은 초에 발급된 새 토큰이 함께 폐기되지 않는다.
+	sessionID := uuid.New().String()
+	if err := t.RevokeAllForUser(user.ID, sessionID); err != nil {
+		return nil, err
+	}
+
+	response, _, err := t.issueTokens(user, sessionID)
+	return response, err
+}
+
+func (t *TokenService) parseToken(tokenString, tokenUse string) (*JWTClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, t.keys.Keyfunc)
+
+	if err != nil || !token.Valid {
+		return nil, errors.New("Invalid token")
+	}
+
+	claims, ok := token.Claims.(*JWTClaims)
+	if !ok || claims.ID == "" || claims.IssuedAt == nil || claims.TokenUse != tokenUse {
+		return nil, errors.New("Invalid token claims")
+	}
+
+	return claims, nil
+}
+
+func (t *TokenService) isRevoked(claims *JWTClaims) (bool, error) {
+	revoked, err := t.repos.TokenRevocations.IsTokenRevoked(claims.ID)
+	if err != nil || revoked || claims.IsService() {
+		return revoked, err
+	}
+
+	userRevocation, err := t.repos.TokenRevocations.GetUserRevocation(claims.UserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if userRevocation.ExceptSessionID != "" && userRevocation.ExceptSessionID == claims.SessionID {
+		return false, nil
+	}
+
+	// iat는 초 단위로 기록되므로, 일괄 폐기 시각과 같은 초에 발급된 토큰까지 폐기 대상으로 본다.
+	return claims.IssuedAt.Unix() <= userRevocation.RevokedBefore.Unix(), nil
+}
+
+// issueTokens는 토큰을 발급하고 마지막 로그인 시간을 기록한다. 리프레시 토큰 교체도 서비스 이용으로 보고 휴면 전환 기준을 늦춘다.
+func (t *TokenService) issueTokens(user models.User, familyID string) (*models.LoginResponse, *models.RefreshToken, error) {
+	if err := t.repos.Users.RecordLogin(user.ID, time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	accessToken, err := t.generateAccessToken(user, familyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawRefreshToken, refreshToken, err := t.createRefreshToken(user.ID, familyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &models.LoginResponse{
+		Token:            accessToken,
+		ExpiresIn:        t.jwtExpiresIn,
+		RefreshToken:     rawRefreshToken,
+		RefreshExpiresIn: t.refreshExpiresIn,
+	}, refreshToken, nil
+}
+
+// generateAccessToken은 이 서비스에 직접 로그인한 사용자의 액세스 토큰에 역할과 권한을 담는다.
+// OpenID Connect 클라이언트에 위임된 토큰(IssueClientAccessToken)은 scope로만 제한되므로 담지 않는다.
+func (t *TokenService) generateAccessToken(user models.User, sessionID string) (string, error) {
+	roles, permissions, err := t.roleClaims(user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	claims := &JWTClaims{
+		UserID:      user.ID,
+		Email:       user.Email,
+		Name:        user.Name,
+		SessionID:   sessionID,
+		TokenUse:    tokenUseAccess,
+		SubjectType: SubjectTypeUser,
+		Roles:       roles,
+		Permissions: permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   fmt.Sprint(user.ID),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(t.jwtExpiresIn) * time.Second)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return t.keys.Sign(claims)
+}
+
+// roleClaims는 사용자의 역할 이름과, 역할들에 포함된 권한을 중복 없이 정렬해 반환한다.
+func (t *TokenService) roleClaims(userID uint) ([]string, []string, error) {
+	userRoles, err := t.repos.Roles.FindByUserID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var roles, permissions []string
+	seen := map[string]bool{}
+	for _, role := range userRoles {
+		roles = append(roles, role.Name)
+		for _, permission := range role.Permissions {
+			if !seen[permission.Name] {
+				seen[permission.Name] = true
+				permissions = append(permissions, permission.Name)
+			}
+		}
+	}
+	sort.Strings(permissions)
+	return roles, permissions, nil
+}
+
+func (t *TokenService) createRefreshToken(userID uint, familyID string) (string, *models.RefreshToken, error) {
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	refreshToken := models.RefreshToken{
+		UserID:    userID,
+		TokenHash: hashToken(rawToken),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(time.Duration(t.refreshExpiresIn) * time.Second),
+	}
+
+	if err := t.repos.RefreshTokens.Create(&refreshToken); err != nil {
+		return "", nil, err
+	}
+
+	return rawToken, &refreshToken, nil
+}
+
+// generateOpaqueToken은 추측 불가능한 256비트 난수 토큰을 URL-safe 문자열로 반환한다.
+func generateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken은 DB에 평문 토큰이 남지 않도록 SHA-256 해시를 반환한다.
+func hashToken(rawToken string) string {
+	sum := sha256.Sum256([]byte(rawToken))
+	return hex.EncodeToString(sum[:])
+}
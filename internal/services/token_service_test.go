@@ -0,0 +1,100 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTokenService() *TokenService {
+	return NewTokenService(&config.Config{
+		JWTExpiresIn:          60 * 15,
+		RefreshTokenExpiresIn: 60 * 60,
+	}, repository.NewMemoryRepositories(), NewHMACKeySet("test-secret"))
+}
+
+func TestAccessTokenIsShortLived(t *testing.T) {
+	tokens := newTestTokenService()
+	user := models.User{ID: 1, Email: "user@example.com", Name: "홍길동"}
+
+	token, err := tokens.generateAccessToken(user, "session")
+	require.NoError(t, err)
+	claims, err := tokens.VerifyAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, claims.UserID)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+
+	// 만료된 토큰과 다른 키로 서명된 토큰은 거부한다.
+	tokens.jwtExpiresIn = -60
+	expired, err := tokens.generateAccessToken(user, "session")
+	require.NoError(t, err)
+	_, err = tokens.VerifyAccessToken(expired)
+	assert.Error(t, err)
+
+	other := newTestTokenService()
+	other.keys = NewHMACKeySet("other-secret")
+	_, err = other.VerifyAccessToken(token)
+	assert.Error(t, err)
+}
+
+func TestRefreshTokensAreOpaqueAndStoredAsHash(t *testing.T) {
+	first, err := generateOpaqueToken()
+	require.NoError(t, err)
+	second, err := generateOpaqueToken()
+	require.NoError(t, err)
+	assert.Len(t, first, 43)
+	assert.NotEqual(t, first, second)
+
+	// DB에는 평문 대신 항상 같은 SHA-256 해시가 저장된다.
+	assert.Len(t, hashToken(first), 64)
+	assert.NotEqual(t, first, hashToken(first))
+	assert.Equal(t, hashToken(first), hashToken(first))
+	assert.NotEqual(t, hashToken(first), hashToken(second))
+}
+
+func TestRevokeAccessTokenByJTI(t *testing.T) {
+	tokens := newTestTokenService()
+	user := models.User{ID: 1, Email: "user@example.com"}
+
+	first, err := tokens.generateAccessToken(user, "session")
+	require.NoError(t, err)
+	second, err := tokens.generateAccessToken(user, "session")
+	require.NoError(t, err)
+
+	firstClaims, err := tokens.VerifyAccessToken(first)
+	require.NoError(t, err)
+	secondClaims, err := tokens.VerifyAccessToken(second)
+	require.NoError(t, err)
+	assert.NotEmpty(t, firstClaims.ID)
+	assert.NotEqual(t, firstClaims.ID, secondClaims.ID)
+	assert.Equal(t, "session", firstClaims.SessionID)
+
+	// jti로 폐기하므로 같은 세션의 다른 액세스 토큰은 그대로 사용할 수 있다.
+	require.NoError(t, tokens.RevokeAccessToken(firstClaims))
+	_, err = tokens.VerifyAccessToken(first)
+	assert.Error(t, err)
+	_, err = tokens.VerifyAccessToken(second)
+	assert.NoError(t, err)
+}
+
+func TestUserRevocationKeepsExceptedSession(t *testing.T) {
+	tokens := newTestTokenService()
+	user := models.User{ID: 1, Email: "user@example.com"}
+
+	current, err := tokens.generateAccessToken(user, "current")
+	require.NoError(t, err)
+	other, err := tokens.generateAccessToken(user, "other")
+	require.NoError(t, err)
+
+	require.NoError(t, tokens.repos.TokenRevocations.RevokeAllForUser(user.ID, time.Now(), "current"))
+	_, err = tokens.VerifyAccessToken(current)
+	assert.NoError(t, err)
+	_, err = tokens.VerifyAccessToken(other)
+	assert.Error(t, err)
+}
@@ -0,0 +1,543 @@
+package services
+
+import (
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/models"
+	"auth-go-service/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrUserNotFound    = errors.New("User not found")
+	ErrProfileConflict = errors.New("회원 정보가 다른 요청에서 먼저 변경되었습니다. 다시 조회한 뒤 시도해주세요.")
+	ErrNothingToUpdate = errors.New("변경할 항목이 없습니다.")
+	ErrEmailInUse      = errors.New("이미 사용 중인 이메일 주소입니다.")
+	ErrEmailNotChanged = errors.New("현재 사용 중인 이메일 주소와 같습니다.")
+	ErrInvalidUndoLink = errors.New("이메일 변경 되돌리기 링크가 만료되었거나 이미 사용되었습니다.")
+)
+
+// phonePattern은 숫자와 하이픈으로 된 전화번호(국가번호 + 허용)다. 예: 010-1234-5678, +82-10-1234-5678
+var phonePattern = regexp.MustCompile(`^\+?[0-9][0-9-]{6,27}[0-9]$`)
+
+// UserService는 로그인한 사용자의 회원 정보를 다룬다.
+type UserService struct {
+	repos                 *repository.Repositories
+	tokenService          *TokenService
+	emailService          *EmailService
+	undoURL               string
+	undoExpiresIn         time.Duration
+	verifyCodeMaxAttempts int
+	verifyCodeCooldown    time.Duration
+	restorePeriod         time.Duration
+	retention             time.Duration
+	marketingTermsVersion string
+	marketingReconfirm    time.Duration
+}
+
+// jobBatchSize는 탈퇴 계정 정리, 마케팅 동의 재확인 작업이 한 번에 처리하는 사용자 수다.
+const jobBatchSize = 100
+
+func NewUserService(cfg *config.Config, repos *repository.Repositories, tokenService *TokenService, emailService *EmailService) *UserService {
+	return &UserService{
+		repos:                 repos,
+		tokenService:          tokenService,
+		emailService:          emailService,
+		undoURL:               cfg.EmailUndoURL,
+		undoExpiresIn:         time.Duration(cfg.EmailUndoExpiresIn) * time.Second,
+		verifyCodeMaxAttempts: cfg.VerifyCodeMaxAttempts,
+		verifyCodeCooldown:    time.Duration(cfg.VerifyCodeCooldown) * time.Second,
+		restorePeriod:         time.Duration(cfg.AccountRestorePeriod) * time.Second,
+		retention:             time.Duration(cfg.AccountRetention) * time.Second,
+		marketingTermsVersion: cfg.MarketingTermsVersion,
+		marketingReconfirm:    time.Duration(cfg.MarketingReconfirm) * time.Second,
+	}
+}
+
+// GetProfile은 userID의 회원 정보를 반환한다. 탈퇴했거나 가입을 마치지 않은 사용자는 ErrUserNotFound다.
+func (s *UserService) GetProfile(userID uint) (*models.User, error) {
+	user, err := s.repos.Users.FindByID(userID)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && user.SignUpStatus != models.SignUpStatusCompleted) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// UpdateProfile은 요청에 포함된 항목만 변경한다.
+// req.UpdatedAt이 저장된 값과 다르면(조회 이후 다른 요청이 먼저 변경했으면) ErrProfileConflict를 반환한다.
+// 마케팅 수신 동의는 모든 채널에 적용되며 ip와 함께 동의 이력에 남는다.
+func (s *UserService) UpdateProfile(userID uint, req *models.UpdateProfileRequest, ip string) (*models.User, error) {
+	if req.Name == nil && req.Phone == nil && req.AgreedMarketingOptIn == nil {
+		return nil, ErrNothingToUpdate
+	}
+
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.UpdatedAt.Equal(req.UpdatedAt) {
+		return nil, ErrProfileConflict
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || utf8.RuneCountInString(name) > 30 {
+			return nil, errors.New("이름은 1자 이상 30자 이하로 입력해주세요.")
+		}
+		user.Name = name
+	}
+	if req.Phone != nil {
+		phone := strings.TrimSpace(*req.Phone)
+		if !phonePattern.MatchString(phone) {
+			return nil, errors.New("전화번호 형식이 올바르지 않습니다. 숫자와 하이픈(-)만 입력해주세요.")
+		}
+		user.Phone = phone
+	}
+	updated, err := s.repos.Users.UpdateProfile(user, req.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrProfileConflict
+	}
+
+	if req.AgreedMarketingOptIn != nil {
+		changes := allMarketingChannels(*req.AgreedMarketingOptIn)
+		changed, err := recordMarketingConsents(s.repos, user, changes, models.MarketingSourceProfile, ip, s.marketingTermsVersion, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			// 동의 여부를 저장하면서 updated_at이 바뀌므로 다시 조회해 반환한다.
+			return s.GetProfile(userID)
+		}
+	}
+	return user, nil
+}
+
+// GetMarketingConsents는 채널별 마케팅 수신 동의 여부와 동의 이력을 반환한다.
+func (s *UserService) GetMarketingConsents(userID uint) (*models.MarketingConsentResponse, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.repos.MarketingConsents.FindByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	current := currentMarketingConsents(history)
+
+	response := &models.MarketingConsentResponse{
+		ConfirmedAt: user.MarketingConfirmedAt,
+		History:     history,
+	}
+	for _, channel := range models.MarketingChannels {
+		status := models.MarketingConsentStatus{Channel: channel}
+		if consent := current[channel]; consent != nil {
+			status.Agreed = consent.Agreed
+			status.UpdatedAt = &consent.CreatedAt
+		}
+		response.Consents = append(response.Consents, status)
+	}
+	if response.History == nil {
+		response.History = []models.MarketingConsent{}
+	}
+	return response, nil
+}
+
+// UpdateMarketingConsents는 요청한 채널의 마케팅 수신 동의 여부를 바꾸고 ip와 함께 이력에 남긴다.
+// 현재 상태와 같은 항목은 이력에 남기지 않는다.
+func (s *UserService) UpdateMarketingConsents(userID uint, req *models.UpdateMarketingConsentRequest, ip string) (*models.MarketingConsentResponse, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]bool{}
+	for _, change := range req.Consents {
+		changes[change.Channel] = *change.Agreed
+	}
+	if _, err := recordMarketingConsents(s.repos, user, changes, models.MarketingSourceSettings, ip, s.marketingTermsVersion, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.GetMarketingConsents(userID)
+}
+
+// SendMarketingReconfirmations는 동의(재확인)한 지 재확인 주기가 지난 사용자에게 수신 동의 현황을 안내하고, 보낸 수를 반환한다.
+// 안내한 시간을 새 재확인 기준으로 삼고, 동의 중인 채널마다 안내 사실을 이력에 남긴다.
+func (s *UserService) SendMarketingReconfirmations(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	for ctx.Err() == nil {
+		users, err := s.repos.Users.FindMarketingReconfirmationDue(now.Add(-s.marketingReconfirm), jobBatchSize)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, user := range users {
+			ok, err := s.sendMarketingReconfirmation(user, now)
+			if err != nil {
+				return sent, fmt.Errorf("marketing re-confirmation for user %d: %w", user.ID, err)
+			}
+			if ok {
+				sent++
+			}
+		}
+
+		if len(users) < jobBatchSize {
+			break
+		}
+	}
+	return sent, nil
+}
+
+// sendMarketingReconfirmation은 다른 태스크와 중복 발송하지 않도록 재확인 기준 시간을 먼저 바꾼 뒤 메일을 보낸다.
+// 발송에 실패하면 기준 시간을 되돌려 다음 실행에서 다시 보낸다.
+func (s *UserService) sendMarketingReconfirmation(user models.User, now time.Time) (bool, error) {
+	confirmedAt := *user.MarketingConfirmedAt
+	claimed, err := s.repos.Users.SwapMarketingConfirmedAt(user.ID, confirmedAt, now)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	history, err := s.repos.MarketingConsents.FindByUserID(user.ID)
+	if err != nil {
+		return false, err
+	}
+	var channels []string
+	var entries []models.MarketingConsent
+	current := currentMarketingConsents(history)
+	for _, channel := range models.MarketingChannels {
+		if consent := current[channel]; consent != nil && consent.Agreed {
+			channels = append(channels, channel)
+			entries = append(entries, models.MarketingConsent{
+				UserID:       user.ID,
+				Channel:      channel,
+				Agreed:       true,
+				Source:       models.MarketingSourceReconfirmation,
+				TermsVersion: consent.TermsVersion,
+				CreatedAt:    now,
+			})
+		}
+	}
+
+	locale := s.emailService.ResolveLocale(user.Locale)
+	if err := s.emailService.SendMarketingReconfirmationEmail(user.Email, locale, channels, confirmedAt); err != nil {
+		if _, revertErr := s.repos.Users.SwapMarketingConfirmedAt(user.ID, now, confirmedAt); revertErr != nil {
+			log.Printf("Failed to revert marketing re-confirmation for user %d: %v", user.ID, revertErr)
+		}
+		return false, err
+	}
+
+	if err := s.repos.MarketingConsents.Create(entries); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ChangePassword는 현재 비밀번호를 확인한 뒤 비밀번호를 바꾼다.
+// sessionID(현재 로그인 세션)를 제외한 모든 세션의 토큰과 남아 있는 재설정 링크는 폐기되고, 변경 알림 메일이 발송된다.
+func (s *UserService) ChangePassword(userID uint, sessionID, currentPassword, newPassword, ip, acceptLanguage string) error {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(currentPassword)); err != nil {
+		return ErrInvalidCurrentPassword
+	}
+	if err := ValidatePassword(newPassword, user.Email); err != nil {
+		return err
+	}
+	if currentPassword == newPassword {
+		return ErrPasswordNotChanged
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	user.EncryptedPassword = string(hashedPassword)
+	if err := s.repos.Users.Save(user); err != nil {
+		return err
+	}
+
+	if err := s.repos.PasswordResetTokens.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+
+	if err := s.tokenService.RevokeAllForUser(user.ID, sessionID); err != nil {
+		return err
+	}
+
+	// 비밀번호는 이미 바뀌었으므로 알림 메일 발송 실패는 요청 실패로 처리하지 않는다.
+	locale := s.emailService.ResolveLocale(user.Locale, acceptLanguage)
+	if err := s.emailService.SendPasswordChangedEmail(user.Email, locale, time.Now(), ip); err != nil {
+		log.Printf("Failed to notify password change for user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// RequestEmailChange는 비밀번호를 다시 확인한 뒤 새 이메일 주소로 인증 코드를 보낸다.
+// 이메일은 ConfirmEmailChange로 인증을 마칠 때까지 바뀌지 않는다.
+func (s *UserService) RequestEmailChange(userID uint, password, newEmail, acceptLanguage string) (*models.RequestEmailVerificationResponse, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		return nil, ErrInvalidCurrentPassword
+	}
+	if err := s.checkEmailAvailable(user, newEmail); err != nil {
+		return nil, err
+	}
+
+	verification, code, err := createEmailVerification(s.repos, newEmail, models.EmailVerificationPurposeEmailChange, &user.ID, s.verifyCodeCooldown)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := s.emailService.ResolveLocale(user.Locale, acceptLanguage)
+	if err := s.emailService.SendEmailChangeCodeEmail(newEmail, locale, code); err != nil {
+		return nil, errors.New("Failed to send verification email. Please try again.")
+	}
+
+	return &models.RequestEmailVerificationResponse{
+		Message:        "Verification email sent. Please check your inbox.",
+		VerificationID: verification.ID,
+	}, nil
+}
+
+// ConfirmEmailChange는 새 이메일 주소의 인증 코드를 확인하고 이메일을 변경한다.
+// 이전 이메일이 담긴 토큰은 모두 폐기되고 새 세션의 토큰을 반환한다. 이전 주소로는 되돌리기 링크가 발송된다.
+func (s *UserService) ConfirmEmailChange(userID uint, newEmail, code string, verificationID uint, acceptLanguage string) (*models.LoginResponse, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	verification, err := s.repos.EmailVerifications.FindByIDAndEmail(verificationID, newEmail, models.EmailVerificationPurposeEmailChange)
+	if err != nil || verification.UserID == nil || *verification.UserID != user.ID {
+		return nil, errors.New("Verification request not found or email does not match.")
+	}
+	if err := checkEmailVerification(s.repos, verification, code, s.verifyCodeMaxAttempts); err != nil {
+		return nil, err
+	}
+	if err := s.checkEmailAvailable(user, newEmail); err != nil {
+		return nil, err
+	}
+
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	undoLink, err := buildTokenLink(s.undoURL, rawToken)
+	if err != nil {
+		return nil, err
+	}
+
+	oldEmail := user.Email
+	user.Email = newEmail
+	if err := s.repos.Users.Save(user); err != nil {
+		return nil, err
+	}
+
+	if err := s.repos.EmailChanges.Create(&models.EmailChange{
+		UserID:        user.ID,
+		OldEmail:      oldEmail,
+		NewEmail:      newEmail,
+		UndoTokenHash: hashToken(rawToken),
+		UndoExpiresAt: time.Now().Add(s.undoExpiresIn),
+	}); err != nil {
+		return nil, err
+	}
+
+	// 이전 주소로 발송된 재설정 링크로 비밀번호를 바꾸지 못하도록 폐기한다.
+	if err := s.repos.PasswordResetTokens.DeleteByUserID(user.ID); err != nil {
+		return nil, err
+	}
+
+	response, err := s.tokenService.ReissueTokens(*user)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := s.emailService.ResolveLocale(user.Locale, acceptLanguage)
+	if err := s.emailService.SendEmailChangedEmail(oldEmail, locale, newEmail, undoLink, s.undoExpiresIn); err != nil {
+		log.Printf("Failed to notify email change for user %d: %v", user.ID, err)
+	}
+	return response, nil
+}
+
+// UndoEmailChange는 이전 주소로 발송한 되돌리기 링크의 토큰으로 이메일을 이전 주소로 되돌린다.
+// 계정 탈취로 인한 변경일 수 있으므로 모든 세션의 토큰과 재설정 링크를 폐기한다.
+func (s *UserService) UndoEmailChange(rawToken string) error {
+	change, err := s.repos.EmailChanges.FindByUndoTokenHash(hashToken(rawToken))
+	if err != nil || change.UndoneAt != nil || !change.UndoExpiresAt.After(time.Now()) {
+		return ErrInvalidUndoLink
+	}
+
+	user, err := s.repos.Users.FindByID(change.UserID)
+	if err != nil {
+		return ErrInvalidUndoLink
+	}
+	if user.Email != change.NewEmail {
+		return errors.New("이후에 이메일이 다시 변경되어 되돌릴 수 없습니다. 고객센터로 문의해주세요.")
+	}
+	if existing, err := s.repos.Users.FindByEmail(change.OldEmail); err == nil && existing.ID != user.ID {
+		return ErrEmailInUse
+	}
+
+	undone, err := s.repos.EmailChanges.UndoIfActive(change.ID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !undone {
+		return ErrInvalidUndoLink
+	}
+
+	user.Email = change.OldEmail
+	if err := s.repos.Users.Save(user); err != nil {
+		return err
+	}
+
+	if err := s.repos.PasswordResetTokens.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+	return s.tokenService.RevokeAllForUser(user.ID, "")
+}
+
+func (s *UserService) checkEmailAvailable(user *models.User, email string) error {
+	if email == user.Email {
+		return ErrEmailNotChanged
+	}
+	if _, err := s.repos.Users.FindByEmail(email); err == nil {
+		return ErrEmailInUse
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	// 탈퇴 후 개인정보를 지우기 전까지는 복구될 수 있으므로 이메일을 비워 두지 않는다.
+	if _, err := s.repos.Users.FindDeletedByEmail(email); err == nil {
+		return ErrEmailInUse
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	// 휴면 계정의 이메일은 dormant_users에만 남아 있다.
+	if _, err := s.repos.DormantUsers.FindByEmail(email); err == nil {
+		return ErrEmailInUse
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// DeleteAccount는 비밀번호를 다시 확인한 뒤 탈퇴 처리(소프트 삭제)하고, 복구할 수 있는 기한을 반환한다.
+// 모든 세션의 토큰은 폐기되며, 복구 기간 안에는 /auth/restore-account로 되살릴 수 있다.
+func (s *UserService) DeleteAccount(userID uint, password, acceptLanguage string) (time.Time, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		return time.Time{}, ErrInvalidCurrentPassword
+	}
+
+	if err := s.repos.Users.Delete(user); err != nil {
+		return time.Time{}, err
+	}
+	restoreUntil := time.Now().Add(s.restorePeriod)
+
+	if err := s.repos.PasswordResetTokens.DeleteByUserID(user.ID); err != nil {
+		return time.Time{}, err
+	}
+	if err := s.tokenService.RevokeAllForUser(user.ID, ""); err != nil {
+		return time.Time{}, err
+	}
+
+	locale := s.emailService.ResolveLocale(user.Locale, acceptLanguage)
+	if err := s.emailService.SendAccountDeletedEmail(user.Email, locale, restoreUntil); err != nil {
+		log.Printf("Failed to notify account deletion for user %d: %v", user.ID, err)
+	}
+	return restoreUntil, nil
+}
+
+// PurgeDeletedAccounts는 탈퇴 후 보관 기간(복구 기간보다 짧으면 복구 기간)이 지난 사용자의 개인정보를 지우고, 처리한 사용자 수를 반환한다.
+// 사용자 행은 다른 테이블이 참조하므로 남기고, 이메일로 연결된 인증/로그인 기록과 2단계 인증 정보는 삭제한다.
+func (s *UserService) PurgeDeletedAccounts(ctx context.Context, now time.Time) (int, error) {
+	retention := s.retention
+	if retention < s.restorePeriod {
+		retention = s.restorePeriod
+	}
+
+	purged := 0
+	for ctx.Err() == nil {
+		users, err := s.repos.Users.FindDeletedBefore(now.Add(-retention), jobBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, user := range users {
+			if err := s.purgePersonalData(user, now); err != nil {
+				return purged, fmt.Errorf("purge user %d: %w", user.ID, err)
+			}
+			purged++
+		}
+
+		if len(users) < jobBatchSize {
+			break
+		}
+	}
+	return purged, nil
+}
+
+// purgePersonalData는 관련 기록을 먼저 지우고 사용자 행을 마지막에 익명화한다.
+// 중간에 실패하면 다음 실행에서 처음부터 다시 처리된다.
+func (s *UserService) purgePersonalData(user models.User, now time.Time) error {
+	if err := s.repos.EmailVerifications.DeleteByEmail(user.Email); err != nil {
+		return err
+	}
+	if err := s.repos.EmailVerifications.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+	if err := s.repos.EmailChanges.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+	if err := s.repos.LoginFailures.DeleteByEmail(user.Email); err != nil {
+		return err
+	}
+	if err := s.repos.LoginLockouts.Delete(models.LockoutScopeAccount, normalizeLockoutEmail(user.Email)); err != nil {
+		return err
+	}
+	if err := s.repos.PasswordResetTokens.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+	if err := s.repos.MFA.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+	if err := s.repos.MarketingConsents.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+	if err := s.repos.Terms.DeleteAgreementsByUserID(user.ID); err != nil {
+		return err
+	}
+	if err := s.repos.UserIdentities.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+	if err := s.repos.Roles.DeleteByUserID(user.ID); err != nil {
+		return err
+	}
+	return s.repos.Users.Anonymize(user.ID, now)
+}
@@ -0,0 +1,330 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"auth-go-service/internal/config"
+	"auth-go-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUserService(s *AuthService) *UserService {
+	cfg := &config.Config{
+		EmailUndoURL:          "https://app.example.com/undo-email-change",
+		EmailUndoExpiresIn:    60 * 60 * 24 * 7,
+		VerifyCodeMaxAttempts: 5,
+		VerifyCodeCooldown:    60,
+		AccountRestorePeriod:  60 * 60 * 24 * 30,
+		AccountRetention:      60 * 60 * 24 * 30,
+		MarketingTermsVersion: "1",
+		MarketingReconfirm:    60 * 60 * 24 * 730,
+	}
+	return NewUserService(cfg, s.repos, s.tokenService, s.emailService)
+}
+
+func TestUpdateProfileChangesOnlyGivenFields(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+	stored, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+	userService := newTestUserService(s)
+
+	profile, err := userService.GetProfile(stored.ID)
+	require.NoError(t, err)
+
+	phone := " 010-9876-5432 "
+	optIn := true
+	updated, err := userService.UpdateProfile(stored.ID, &models.UpdateProfileRequest{
+		Phone:                &phone,
+		AgreedMarketingOptIn: &optIn,
+		UpdatedAt:            profile.UpdatedAt,
+	}, "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "홍길동", updated.Name)
+	assert.Equal(t, "010-9876-5432", updated.Phone)
+	assert.True(t, updated.AgreedMarketingOptIn)
+
+	reloaded, err := userService.GetProfile(stored.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "010-9876-5432", reloaded.Phone)
+	assert.True(t, reloaded.UpdatedAt.Equal(updated.UpdatedAt))
+}
+
+func TestUpdateProfileRejectsStaleUpdatedAt(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+	stored, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+	userService := newTestUserService(s)
+
+	name := "김철수"
+	_, err = userService.UpdateProfile(stored.ID, &models.UpdateProfileRequest{Name: &name, UpdatedAt: stored.UpdatedAt}, "127.0.0.1")
+	require.NoError(t, err)
+
+	name = "이영희"
+	_, err = userService.UpdateProfile(stored.ID, &models.UpdateProfileRequest{Name: &name, UpdatedAt: stored.UpdatedAt}, "127.0.0.1")
+	assert.ErrorIs(t, err, ErrProfileConflict)
+
+	// 저장소 조건부 갱신도 같은 기준으로 막는다(조회와 저장 사이에 변경된 경우).
+	stored.Name = "이영희"
+	ok, err := repos.Users.UpdateProfile(stored, stored.UpdatedAt.Add(-time.Second))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestUpdateProfileValidatesInput(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+	stored, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+	userService := newTestUserService(s)
+
+	_, err = userService.UpdateProfile(stored.ID, &models.UpdateProfileRequest{UpdatedAt: stored.UpdatedAt}, "127.0.0.1")
+	assert.ErrorIs(t, err, ErrNothingToUpdate)
+
+	phone := "call me"
+	_, err = userService.UpdateProfile(stored.ID, &models.UpdateProfileRequest{Phone: &phone, UpdatedAt: stored.UpdatedAt}, "127.0.0.1")
+	assert.Error(t, err)
+
+	name := "   "
+	_, err = userService.UpdateProfile(stored.ID, &models.UpdateProfileRequest{Name: &name, UpdatedAt: stored.UpdatedAt}, "127.0.0.1")
+	assert.Error(t, err)
+
+	_, err = userService.GetProfile(stored.ID + 100)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestChangePasswordKeepsOnlyCurrentSession(t *testing.T) {
+	s, repos, mail := newTestAuthServiceWithMailer(t)
+	current := signUpTestUser(t, s, repos, "user@example.com", "password123")
+	other, _, err := s.Login("user@example.com", "password123", "127.0.0.1")
+	require.NoError(t, err)
+	userService := newTestUserService(s)
+
+	claims, err := s.VerifyToken(current.Token)
+	require.NoError(t, err)
+
+	err = userService.ChangePassword(claims.UserID, claims.SessionID, "wrong-password", "newpassword123", "10.0.0.1", "")
+	assert.ErrorIs(t, err, ErrInvalidCurrentPassword)
+	err = userService.ChangePassword(claims.UserID, claims.SessionID, "password123", "password123", "10.0.0.1", "")
+	assert.ErrorIs(t, err, ErrPasswordNotChanged)
+	err = userService.ChangePassword(claims.UserID, claims.SessionID, "password123", "onlyletters", "10.0.0.1", "")
+	assert.ErrorIs(t, err, ErrPasswordPolicy)
+
+	require.NoError(t, userService.ChangePassword(claims.UserID, claims.SessionID, "password123", "newpassword123", "10.0.0.1", ""))
+
+	_, err = s.VerifyToken(current.Token)
+	assert.NoError(t, err)
+	_, err = s.RefreshToken(current.RefreshToken)
+	assert.NoError(t, err)
+
+	_, err = s.VerifyToken(other.Token)
+	assert.Error(t, err)
+	_, err = s.RefreshToken(other.RefreshToken)
+	assert.Error(t, err)
+
+	_, _, err = s.Login("user@example.com", "password123", "127.0.0.1")
+	assert.Error(t, err)
+
+	msg, ok := mail.LastTo("user@example.com")
+	require.True(t, ok)
+	assert.Equal(t, "[Momentir] 비밀번호가 변경되었습니다", msg.Subject)
+	assert.Contains(t, msg.TextBody, "10.0.0.1")
+}
+
+func TestEmailChangeRequiresVerificationAndCanBeUndone(t *testing.T) {
+	s, repos, mail := newTestAuthServiceWithMailer(t)
+	login := signUpTestUser(t, s, repos, "user@example.com", "password123")
+	signUpTestUser(t, s, repos, "taken@example.com", "password123")
+	userService := newTestUserService(s)
+	claims, err := s.VerifyToken(login.Token)
+	require.NoError(t, err)
+
+	_, err = userService.RequestEmailChange(claims.UserID, "password123", "taken@example.com", "")
+	assert.ErrorIs(t, err, ErrEmailInUse)
+	_, err = userService.RequestEmailChange(claims.UserID, "wrong-password", "new@example.com", "")
+	assert.ErrorIs(t, err, ErrInvalidCurrentPassword)
+
+	requested, err := userService.RequestEmailChange(claims.UserID, "password123", "new@example.com", "")
+	require.NoError(t, err)
+	code := verificationCodeFromMail(t, mail, "new@example.com")
+
+	// 이메일 변경용 인증은 회원가입 인증으로 사용할 수 없다.
+	assert.Error(t, s.VerifyEmailAccount("new@example.com", code, requested.VerificationID))
+
+	// 인증 전에는 이메일이 바뀌지 않는다.
+	profile, err := userService.GetProfile(claims.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", profile.Email)
+
+	_, err = userService.ConfirmEmailChange(claims.UserID, "new@example.com", "wrong", requested.VerificationID, "")
+	assert.Error(t, err)
+	reissued, err := userService.ConfirmEmailChange(claims.UserID, "new@example.com", code, requested.VerificationID, "")
+	require.NoError(t, err)
+
+	// 이전 이메일이 담긴 토큰은 폐기되고 새 토큰에는 새 이메일이 담긴다.
+	_, err = s.VerifyToken(login.Token)
+	assert.Error(t, err)
+	_, err = s.RefreshToken(login.RefreshToken)
+	assert.Error(t, err)
+	newClaims, err := s.VerifyToken(reissued.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", newClaims.Email)
+
+	_, _, err = s.Login("new@example.com", "password123", "127.0.0.1")
+	assert.NoError(t, err)
+
+	undoToken := resetTokenFromMail(t, mail, "user@example.com")
+	require.NoError(t, userService.UndoEmailChange(undoToken))
+	assert.ErrorIs(t, userService.UndoEmailChange(undoToken), ErrInvalidUndoLink)
+
+	profile, err = userService.GetProfile(claims.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", profile.Email)
+	_, err = s.VerifyToken(reissued.Token)
+	assert.Error(t, err)
+}
+
+func TestDeleteAccountCanBeRestored(t *testing.T) {
+	s, repos, mail := newTestAuthServiceWithMailer(t)
+	login := signUpTestUser(t, s, repos, "user@example.com", "password123")
+	userService := newTestUserService(s)
+	claims, err := s.VerifyToken(login.Token)
+	require.NoError(t, err)
+
+	_, err = userService.DeleteAccount(claims.UserID, "wrong-password", "")
+	assert.ErrorIs(t, err, ErrInvalidCurrentPassword)
+
+	restoreUntil, err := userService.DeleteAccount(claims.UserID, "password123", "")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), restoreUntil, time.Minute)
+	_, ok := mail.LastTo("user@example.com")
+	assert.True(t, ok)
+
+	// 탈퇴하면 모든 토큰이 폐기되고, 로그인 대신 복구 안내를 받는다.
+	_, err = s.VerifyToken(login.Token)
+	assert.Error(t, err)
+	_, _, err = s.Login("user@example.com", "password123", "127.0.0.1")
+	var deletedErr *AccountDeletedError
+	assert.ErrorAs(t, err, &deletedErr)
+
+	// 복구 기간 동안에는 같은 이메일로 다시 가입하거나 이메일을 변경해 가져갈 수 없다.
+	_, err = s.SignUp(&models.SignUpRequest{Name: "홍길동", Email: "user@example.com", Phone: "010-1234-5678", Password: "password123"}, "", "127.0.0.1")
+	assert.ErrorIs(t, err, ErrAccountPendingDeletion)
+	other := signUpTestUser(t, s, repos, "other@example.com", "password123")
+	otherClaims, err := s.VerifyToken(other.Token)
+	require.NoError(t, err)
+	_, err = userService.RequestEmailChange(otherClaims.UserID, "password123", "user@example.com", "")
+	assert.ErrorIs(t, err, ErrEmailInUse)
+
+	assert.Error(t, s.RestoreAccount("user@example.com", "wrong-password", "127.0.0.1"))
+	require.NoError(t, s.RestoreAccount("user@example.com", "password123", "127.0.0.1"))
+
+	_, _, err = s.Login("user@example.com", "password123", "127.0.0.1")
+	assert.NoError(t, err)
+}
+
+func TestPurgeDeletedAccountsAnonymizesAfterRetention(t *testing.T) {
+	s, repos := newTestAuthService(t)
+	login := signUpTestUser(t, s, repos, "user@example.com", "password123")
+	signUpTestUser(t, s, repos, "active@example.com", "password123")
+	userService := newTestUserService(s)
+	claims, err := s.VerifyToken(login.Token)
+	require.NoError(t, err)
+
+	_, err = userService.DeleteAccount(claims.UserID, "password123", "")
+	require.NoError(t, err)
+
+	// 보관 기간 전에는 아무것도 지우지 않는다.
+	purged, err := userService.PurgeDeletedAccounts(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged)
+
+	later := time.Now().Add(31 * 24 * time.Hour)
+	purged, err = userService.PurgeDeletedAccounts(context.Background(), later)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	// 다시 실행해도 이미 익명화한 사용자는 처리하지 않는다.
+	purged, err = userService.PurgeDeletedAccounts(context.Background(), later)
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged)
+
+	_, err = repos.Users.FindDeletedByEmail("user@example.com")
+	assert.Error(t, err)
+	_, err = repos.EmailVerifications.FindLatestByEmail("user@example.com", models.EmailVerificationPurposeSignUp)
+	assert.Error(t, err)
+	assert.Error(t, s.RestoreAccount("user@example.com", "password123", "127.0.0.1"))
+
+	_, err = repos.Users.FindByEmail("active@example.com")
+	assert.NoError(t, err)
+
+	// 개인정보가 지워진 뒤에는 같은 이메일로 다시 가입할 수 있다.
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+}
+
+func TestMarketingConsentsAreRecordedAndReconfirmed(t *testing.T) {
+	s, repos, mail := newTestAuthServiceWithMailer(t)
+	signUpTestUser(t, s, repos, "user@example.com", "password123")
+	stored, err := repos.Users.FindByEmail("user@example.com")
+	require.NoError(t, err)
+	userService := newTestUserService(s)
+	agreed, withdrawn := true, false
+
+	consents, err := userService.UpdateMarketingConsents(stored.ID, &models.UpdateMarketingConsentRequest{
+		Consents: []models.MarketingConsentChange{
+			{Channel: models.MarketingChannelEmail, Agreed: &agreed},
+			{Channel: models.MarketingChannelSMS, Agreed: &withdrawn},
+		},
+	}, "10.0.0.1")
+	require.NoError(t, err)
+	// 현재 상태와 같은 항목(동의한 적 없는 SMS 철회)은 이력에 남기지 않는다.
+	require.Len(t, consents.History, 1)
+	assert.Equal(t, "10.0.0.1", consents.History[0].IPAddress)
+	assert.Equal(t, "1", consents.History[0].TermsVersion)
+	assert.True(t, consents.Consents[0].Agreed)
+	assert.False(t, consents.Consents[1].Agreed)
+	require.NotNil(t, consents.ConfirmedAt)
+
+	profile, err := userService.GetProfile(stored.ID)
+	require.NoError(t, err)
+	assert.True(t, profile.AgreedMarketingOptIn)
+
+	now := time.Now()
+	sent, err := userService.SendMarketingReconfirmations(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// 2년이 지나면 한 번 안내하고, 안내 시간이 새 재확인 기준이 된다.
+	later := now.Add(731 * 24 * time.Hour)
+	sent, err = userService.SendMarketingReconfirmations(context.Background(), later)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	sent, err = userService.SendMarketingReconfirmations(context.Background(), later)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	msg, ok := mail.LastTo("user@example.com")
+	require.True(t, ok)
+	assert.Contains(t, msg.TextBody, "이메일: 수신 동의")
+
+	consents, err = userService.GetMarketingConsents(stored.ID)
+	require.NoError(t, err)
+	require.Len(t, consents.History, 2)
+	assert.Equal(t, models.MarketingSourceReconfirmation, consents.History[0].Source)
+	assert.True(t, consents.ConfirmedAt.Equal(later))
+
+	// 회원 정보 수정의 수신 동의 철회는 모든 채널에 적용된다.
+	_, err = userService.UpdateProfile(stored.ID, &models.UpdateProfileRequest{AgreedMarketingOptIn: &withdrawn, UpdatedAt: profile.UpdatedAt}, "10.0.0.2")
+	require.NoError(t, err)
+	consents, err = userService.GetMarketingConsents(stored.ID)
+	require.NoError(t, err)
+	assert.Nil(t, consents.ConfirmedAt)
+	assert.Equal(t, models.MarketingSourceProfile, consents.History[0].Source)
+	for _, status := range consents.Consents {
+		assert.False(t, status.Agreed)
+	}
+}
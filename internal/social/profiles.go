@@ -0,0 +1,95 @@
+package social
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var errMissingProfile = errors.New("missing profile")
+
+// parseKakaoProfile은 카카오 사용자 정보(/v2/user/me)를 해석한다.
+// 이메일은 사용자가 제공에 동의하고 카카오가 유효성과 인증을 모두 확인한 경우에만 확인된 것으로 본다.
+func parseKakaoProfile(body []byte) (*Profile, error) {
+	var data struct {
+		ID           int64 `json:"id"`
+		KakaoAccount struct {
+			Email           string `json:"email"`
+			IsEmailValid    bool   `json:"is_email_valid"`
+			IsEmailVerified bool   `json:"is_email_verified"`
+			Name            string `json:"name"`
+			Profile         struct {
+				Nickname string `json:"nickname"`
+			} `json:"profile"`
+		} `json:"kakao_account"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+	if data.ID == 0 {
+		return nil, errMissingProfile
+	}
+
+	account := data.KakaoAccount
+	name := account.Name
+	if name == "" {
+		name = account.Profile.Nickname
+	}
+	return &Profile{
+		Subject:       strconv.FormatInt(data.ID, 10),
+		Email:         account.Email,
+		EmailVerified: account.Email != "" && account.IsEmailValid && account.IsEmailVerified,
+		Name:          name,
+	}, nil
+}
+
+// parseNaverProfile은 네이버 회원 프로필(/v1/nid/me)을 해석한다.
+// 네이버는 이메일 인증 여부를 알려주지 않으므로, 네이버가 직접 관리하는 @naver.com 주소만 확인된 것으로 본다.
+func parseNaverProfile(body []byte) (*Profile, error) {
+	var data struct {
+		ResultCode string `json:"resultcode"`
+		Response   struct {
+			ID       string `json:"id"`
+			Email    string `json:"email"`
+			Name     string `json:"name"`
+			Nickname string `json:"nickname"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+	if data.ResultCode != "00" {
+		return nil, errMissingProfile
+	}
+
+	name := data.Response.Name
+	if name == "" {
+		name = data.Response.Nickname
+	}
+	return &Profile{
+		Subject:       data.Response.ID,
+		Email:         data.Response.Email,
+		EmailVerified: strings.HasSuffix(strings.ToLower(data.Response.Email), "@naver.com"),
+		Name:          name,
+	}, nil
+}
+
+// parseGoogleProfile은 구글 OpenID Connect userinfo 응답을 해석한다.
+func parseGoogleProfile(body []byte) (*Profile, error) {
+	var data struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+	return &Profile{
+		Subject:       data.Sub,
+		Email:         data.Email,
+		EmailVerified: data.Email != "" && data.EmailVerified,
+		Name:          data.Name,
+	}, nil
+}
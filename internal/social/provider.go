@@ -0,0 +1,185 @@
+package social
+
+import (
+	"auth-go-service/internal/config"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Profile은 제공자에서 받은 사용자 정보다.
+type Profile struct {
+	Subject       string // 제공자 안에서 사용자를 구분하는 ID
+	Email         string
+	EmailVerified bool // 제공자가 이메일 소유를 확인했는지 여부. 확인된 이메일만 기존 계정과 연결한다.
+	Name          string
+}
+
+// Provider는 인가 코드 + PKCE 방식의 소셜 로그인 제공자다.
+type Provider interface {
+	Name() string
+	// AuthCodeURL은 사용자를 보낼 제공자의 로그인(동의) 화면 주소다.
+	AuthCodeURL(state, codeChallenge string) string
+	// Exchange는 인가 코드를 액세스 토큰으로 바꾸고 사용자 정보를 조회한다.
+	Exchange(ctx context.Context, code, codeVerifier string) (*Profile, error)
+}
+
+// profileParsers는 제공자별 사용자 정보 응답 해석 함수다.
+var profileParsers = map[string]func(body []byte) (*Profile, error){
+	"kakao":  parseKakaoProfile,
+	"naver":  parseNaverProfile,
+	"google": parseGoogleProfile,
+}
+
+// NewProviders는 설정된(ClientID가 있는) 제공자를 이름별로 만든다.
+func NewProviders(providers map[string]config.OAuthProvider) (map[string]Provider, error) {
+	result := map[string]Provider{}
+	for name, cfg := range providers {
+		parse, ok := profileParsers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown OAuth provider: %q", name)
+		}
+		if cfg.RedirectURL == "" {
+			return nil, fmt.Errorf("%s: redirect URL is required", name)
+		}
+		result[name] = &oauthProvider{
+			name:         name,
+			cfg:          cfg,
+			parseProfile: parse,
+			client:       &http.Client{Timeout: 10 * time.Second},
+		}
+	}
+	return result, nil
+}
+
+type oauthProvider struct {
+	name         string
+	cfg          config.OAuthProvider
+	parseProfile func(body []byte) (*Profile, error)
+	client       *http.Client
+}
+
+func (p *oauthProvider) Name() string {
+	return p.name
+}
+
+func (p *oauthProvider) AuthCodeURL(state, codeChallenge string) string {
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"state":                 {state},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	if p.name == "google" {
+		params.Set("scope", "openid email profile")
+	}
+
+	separator := "?"
+	if strings.Contains(p.cfg.AuthURL, "?") {
+		separator = "&"
+	}
+	return p.cfg.AuthURL + separator + params.Encode()
+}
+
+func (p *oauthProvider) Exchange(ctx context.Context, code, codeVerifier string) (*Profile, error) {
+	accessToken, err := p.exchangeCode(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	body, err := p.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s userinfo: %w", p.name, err)
+	}
+	profile, err := p.parseProfile(body)
+	if err != nil {
+		return nil, fmt.Errorf("%s userinfo: %w", p.name, err)
+	}
+	if profile.Subject == "" {
+		return nil, fmt.Errorf("%s userinfo: missing user id", p.name)
+	}
+	return profile, nil
+}
+
+func (p *oauthProvider) exchangeCode(ctx context.Context, code, codeVerifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"client_secret": {p.cfg.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := p.do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s token: %w", p.name, err)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("%s token: %w", p.name, err)
+	}
+	// 네이버는 실패해도 200과 함께 error를 돌려준다.
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("%s token: %s", p.name, token.Error)
+	}
+	return token.AccessToken, nil
+}
+
+func (p *oauthProvider) do(req *http.Request) ([]byte, error) {
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+// NewCodeVerifier는 PKCE code_verifier(43자)를 만든다.
+func NewCodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge는 code_verifier의 S256 code_challenge를 계산한다.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,72 @@
+// Package authclient는 인증 서비스가 발급한 액세스 토큰을 다른 서비스에서 검증하기 위한 패키지다.
+// JWKS(공개키) 또는 공유 비밀키로 서명을 확인하고, net/http와 gin 미들웨어, scope/역할/권한 확인을 제공한다.
+package authclient
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+	"strings"
+)
+
+const (
+	SubjectTypeUser    = "user"
+	SubjectTypeService = "service"
+)
+
+// Claims는 인증 서비스가 발급하는 액세스 토큰의 클레임이다.
+type Claims struct {
+	UserID      uint     `json:"userId"`
+	Email       string   `json:"email"`
+	Name        string   `json:"name"`
+	SessionID   string   `json:"sid,omitempty"`
+	TokenUse    string   `json:"token_use"`
+	SubjectType string   `json:"sub_type,omitempty"`
+	ClientID    string   `json:"client_id,omitempty"`
+	Scope       string   `json:"scope,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// IsService는 client_credentials로 발급한 서비스 토큰인지 여부다. 서비스 토큰의 Subject는 client_id이고 UserID는 0이다.
+func (c *Claims) IsService() bool {
+	return c.SubjectType == SubjectTypeService
+}
+
+// IsUser는 사용자 토큰인지 여부다. sub_type이 없는 이전 토큰도 사용자 토큰이다.
+func (c *Claims) IsUser() bool {
+	return !c.IsService()
+}
+
+// Scopes는 토큰에 허용된 scope 목록이다. 이 서비스에서 직접 로그인한 사용자 토큰에는 scope가 없다.
+func (c *Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+func (c *Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission은 인증 서비스의 middleware.RequirePermission과 같이 사용자가 역할로 받은 권한을 확인한다.
+// 서비스 토큰의 scope는 권한으로 보지 않으므로, 서비스 토큰은 HasScope로 확인한다.
+func (c *Claims) HasPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
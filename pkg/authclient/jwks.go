@@ -0,0 +1,195 @@
+package authclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval은 JWKS를 다시 받는 최소 간격이다. 잘못된 kid의 토큰이 몰려도 인증 서비스를 계속 호출하지 않는다.
+const minRefreshInterval = 10 * time.Second
+
+type verificationKey struct {
+	algorithm string
+	publicKey interface{}
+}
+
+// jwksCache는 kid별 공개키를 CacheTTL 동안 보관한다.
+// 다시 받지 못하면 이전 키로 계속 검증한다.
+type jwksCache struct {
+	url    string
+	client *http.Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu        sync.RWMutex
+	keys      map[string]*verificationKey
+	expiresAt time.Time
+	retryAt   time.Time
+
+	// 진행 중인 JWKS 요청이 끝나면 닫히는 채널과 그 요청의 결과. 동시에 들어온 요청은 같은 결과를 기다린다.
+	refreshing chan struct{}
+	refreshErr error
+}
+
+func newJWKSCache(url string, client *http.Client, ttl time.Duration) *jwksCache {
+	return &jwksCache{url: url, client: client, ttl: ttl, now: time.Now}
+}
+
+func (c *jwksCache) key(ctx context.Context, kid string) (*verificationKey, error) {
+	key, ok, fresh := c.lookup(kid)
+	// 모르는 kid는 키 교체 직후일 수 있으므로 만료 전이라도 다시 받는다.
+	if !fresh {
+		if err := c.refresh(ctx); err != nil && !ok {
+			return nil, err
+		}
+		key, ok, _ = c.lookup(kid)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %q", kid)
+	}
+	return key, nil
+}
+
+func (c *jwksCache) lookup(kid string) (key *verificationKey, ok, fresh bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	key, ok = c.keys[kid]
+	return key, ok, ok && !c.now().After(c.expiresAt)
+}
+
+// refresh는 JWKS를 다시 받는다. 요청 중에는 잠금을 잡지 않아 캐시된 키로 검증하는 요청을 막지 않으며,
+// 이미 받는 중이면 그 결과를 기다리고, minRefreshInterval 안에는 다시 받지 않는다.
+func (c *jwksCache) refresh(ctx context.Context) error {
+	c.mu.Lock()
+	if done := c.refreshing; done != nil {
+		c.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		return c.refreshErr
+	}
+	now := c.now()
+	if now.Before(c.retryAt) {
+		c.mu.Unlock()
+		return nil
+	}
+	c.retryAt = now.Add(minRefreshInterval)
+	done := make(chan struct{})
+	c.refreshing = done
+	c.mu.Unlock()
+
+	keys, err := c.fetch(ctx)
+
+	c.mu.Lock()
+	if err == nil {
+		c.keys = keys
+		c.expiresAt = now.Add(c.ttl)
+	}
+	c.refreshErr = err
+	c.refreshing = nil
+	c.mu.Unlock()
+	close(done)
+	return err
+}
+
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+}
+
+func (c *jwksCache) fetch(ctx context.Context) (map[string]*verificationKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode JWKS: %w", err)
+	}
+
+	keys := map[string]*verificationKey{}
+	for _, jwk := range body.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// 지원하지 않는 키는 건너뛰고 나머지 키로 검증한다.
+		if key, err := parseJSONWebKey(jwk); err == nil {
+			keys[jwk.KeyID] = key
+		}
+	}
+	return keys, nil
+}
+
+func parseJSONWebKey(jwk jsonWebKey) (*verificationKey, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &verificationKey{algorithm: "RS256", publicKey: &rsa.PublicKey{N: n, E: int(e.Int64())}}, nil
+	case "EC":
+		if jwk.Curve != "P-256" {
+			return nil, fmt.Errorf("unsupported curve: %s", jwk.Curve)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, errors.New("invalid EC point")
+		}
+		return &verificationKey{algorithm: "ES256", publicKey: &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", jwk.KeyType)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,157 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+type contextKey struct{}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// Middleware는 Authorization: Bearer 토큰을 검증해 요청 컨텍스트에 Claims를 담는 net/http 미들웨어다.
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, status, message := v.authenticate(r)
+		if claims == nil {
+			writeError(w, status, message)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, claims)))
+	})
+}
+
+// ClaimsFromContext는 Middleware가 담은 Claims를 꺼낸다.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(*Claims)
+	return claims, ok
+}
+
+// RequireScope는 Middleware 뒤에 두어 토큰에 scope가 있는지 확인한다.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return requireClaims(func(claims *Claims) bool { return claims.HasScope(scope) }, "Insufficient scope")
+}
+
+// RequireRole은 Middleware 뒤에 두어 사용자에게 역할이 있는지 확인한다.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return requireClaims(func(claims *Claims) bool { return claims.HasRole(role) }, "Insufficient role")
+}
+
+// RequirePermission은 Middleware 뒤에 두어 사용자에게 역할로 받은 권한(예: "users:read")이 있는지 확인한다. 서비스 토큰은 RequireScope로 확인한다.
+func RequirePermission(permission string) func(http.Handler) http.Handler {
+	return requireClaims(func(claims *Claims) bool { return claims.HasPermission(permission) }, "Permission denied")
+}
+
+func requireClaims(allowed func(*Claims) bool, message string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				writeError(w, http.StatusUnauthorized, "Authentication is required")
+				return
+			}
+			if !allowed(claims) {
+				writeError(w, http.StatusForbidden, message)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// GinMiddleware는 인증 서비스의 middleware.AuthRequired와 같이 토큰을 검증하고
+// claims, userID, email, name, subjectType, clientID, scope를 gin 컨텍스트에 담는다.
+func (v *Verifier) GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, status, message := v.authenticate(c.Request)
+		if claims == nil {
+			if status == http.StatusUnauthorized {
+				c.Header("WWW-Authenticate", "Bearer")
+			}
+			c.AbortWithStatusJSON(status, errorResponse{Message: message})
+			return
+		}
+
+		subjectType := SubjectTypeUser
+		if claims.IsService() {
+			subjectType = SubjectTypeService
+		}
+
+		c.Set("claims", claims)
+		c.Set("subjectType", subjectType)
+		c.Set("clientID", claims.ClientID)
+		c.Set("scope", claims.Scope)
+		c.Set("userID", claims.UserID)
+		c.Set("email", claims.Email)
+		c.Set("name", claims.Name)
+		c.Next()
+	}
+}
+
+// ClaimsFromGin은 GinMiddleware가 담은 Claims를 꺼낸다.
+func ClaimsFromGin(c *gin.Context) (*Claims, bool) {
+	claims, ok := c.Get("claims")
+	if !ok {
+		return nil, false
+	}
+	typed, ok := claims.(*Claims)
+	return typed, ok
+}
+
+// GinRequireScope는 GinMiddleware 뒤에 두어 토큰에 scope가 있는지 확인한다.
+func GinRequireScope(scope string) gin.HandlerFunc {
+	return ginRequireClaims(func(claims *Claims) bool { return claims.HasScope(scope) }, "Insufficient scope")
+}
+
+// GinRequireRole은 GinMiddleware 뒤에 두어 사용자에게 역할이 있는지 확인한다.
+func GinRequireRole(role string) gin.HandlerFunc {
+	return ginRequireClaims(func(claims *Claims) bool { return claims.HasRole(role) }, "Insufficient role")
+}
+
+// GinRequirePermission은 GinMiddleware 뒤에 두어 사용자에게 역할로 받은 권한이 있는지 확인한다.
+func GinRequirePermission(permission string) gin.HandlerFunc {
+	return ginRequireClaims(func(claims *Claims) bool { return claims.HasPermission(permission) }, "Permission denied")
+}
+
+func ginRequireClaims(allowed func(*Claims) bool, message string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := ClaimsFromGin(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse{Message: "Authentication is required"})
+			return
+		}
+		if !allowed(claims) {
+			c.AbortWithStatusJSON(http.StatusForbidden, errorResponse{Message: message})
+			return
+		}
+		c.Next()
+	}
+}
+
+// authenticate는 요청의 Bearer 토큰을 검증한다. 실패하면 nil과 응답할 상태 코드, 메시지를 반환한다.
+func (v *Verifier) authenticate(r *http.Request) (*Claims, int, string) {
+	tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || tokenString == "" {
+		return nil, http.StatusUnauthorized, "Bearer token is required"
+	}
+
+	claims, err := v.Verify(r.Context(), tokenString)
+	if err != nil {
+		return nil, http.StatusUnauthorized, "Invalid token"
+	}
+	return claims, 0, ""
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Message: message})
+}
@@ -0,0 +1,93 @@
+package authclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPMiddlewareRequiresScope(t *testing.T) {
+	verifier, err := NewVerifier(Config{Secret: "test-secret"})
+	require.NoError(t, err)
+
+	handler := verifier.Middleware(RequireScope("users:read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext(r.Context())
+		require.True(t, ok)
+		w.Write([]byte(claims.Subject))
+	})))
+	serve := func(claims *Claims) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if claims != nil {
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+
+	assert.Equal(t, http.StatusForbidden, serve(userClaims()).Code)
+
+	service := userClaims()
+	service.UserID, service.Subject, service.SubjectType = 0, "billing", SubjectTypeService
+	service.ClientID, service.Scope = "billing", "users:read"
+	rec = serve(service)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "billing", rec.Body.String())
+}
+
+func TestGinMiddlewareSetsCallerAndChecksRoleAndPermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	verifier, err := NewVerifier(Config{Secret: "test-secret"})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/me", verifier.GinMiddleware(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"userId": c.GetUint("userID"), "subjectType": c.GetString("subjectType")})
+	})
+	router.GET("/admin", verifier.GinMiddleware(), GinRequireRole("admin"), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	router.GET("/users", verifier.GinMiddleware(), GinRequirePermission("users:read"), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	serve := func(path string, claims *Claims) *httptest.ResponseRecorder {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/me", userClaims())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"userId": 42, "subjectType": "user"}`, rec.Body.String())
+
+	assert.Equal(t, http.StatusForbidden, serve("/admin", userClaims()).Code)
+	admin := userClaims()
+	admin.Roles = []string{"admin"}
+	assert.Equal(t, http.StatusNoContent, serve("/admin", admin).Code)
+
+	assert.Equal(t, http.StatusForbidden, serve("/users", admin).Code)
+	admin.Permissions = []string{"roles:read", "users:read"}
+	assert.Equal(t, http.StatusNoContent, serve("/users", admin).Code)
+
+	// 서비스 토큰의 scope는 권한으로 보지 않는다.
+	service := userClaims()
+	service.UserID, service.Subject, service.SubjectType = 0, "billing", SubjectTypeService
+	service.ClientID, service.Scope = "billing", "users:read"
+	assert.Equal(t, http.StatusForbidden, serve("/users", service).Code)
+}
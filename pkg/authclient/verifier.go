@@ -0,0 +1,103 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"net/http"
+	"time"
+)
+
+const defaultCacheTTL = 5 * time.Minute
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Config는 Verifier 설정이다. JWKSURL과 Secret 중 하나만 지정한다.
+type Config struct {
+	// JWKSURL은 인증 서비스의 공개키 목록 주소다(예: https://auth.example.com/.well-known/jwks.json).
+	JWKSURL string
+	// Secret은 인증 서비스가 HS256(JWT_SECRET_KEY)으로 서명할 때 쓰는 공유 비밀키다.
+	Secret string
+	// CacheTTL은 받아 둔 공개키를 다시 받기까지의 시간이다(기본 5분). 모르는 kid가 오면 그 전에라도 다시 받는다.
+	CacheTTL time.Duration
+	// HTTPClient는 JWKS를 받을 때 쓴다(기본 http.DefaultClient).
+	HTTPClient *http.Client
+	// ClientIDs는 받아들일 OpenID Connect 클라이언트다. 클라이언트에 위임된 사용자 토큰은 여기에 있는 client_id만 통과한다.
+	ClientIDs []string
+}
+
+// Verifier는 액세스 토큰의 서명, 만료, 용도를 확인한다.
+// 로그아웃 등으로 폐기된 토큰은 알 수 없으므로, 즉시 폐기를 반영해야 하면 인증 서비스의 /v1/oauth/introspect를 사용한다.
+type Verifier struct {
+	secret    []byte
+	jwks      *jwksCache
+	methods   []string
+	clientIDs map[string]bool
+}
+
+func NewVerifier(cfg Config) (*Verifier, error) {
+	if (cfg.JWKSURL == "") == (cfg.Secret == "") {
+		return nil, errors.New("authclient: exactly one of JWKSURL and Secret is required")
+	}
+
+	v := &Verifier{clientIDs: map[string]bool{}}
+	for _, clientID := range cfg.ClientIDs {
+		v.clientIDs[clientID] = true
+	}
+
+	if cfg.Secret != "" {
+		v.secret = []byte(cfg.Secret)
+		v.methods = []string{jwt.SigningMethodHS256.Alg()}
+		return v, nil
+	}
+
+	ttl := cfg.CacheTTL
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	v.jwks = newJWKSCache(cfg.JWKSURL, client, ttl)
+	v.methods = []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}
+	return v, nil
+}
+
+// Verify는 액세스 토큰을 검증하고 클레임을 반환한다. 오류는 ErrInvalidToken을 감싼다.
+func (v *Verifier) Verify(ctx context.Context, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return v.key(ctx, token)
+	}, jwt.WithValidMethods(v.methods), jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	// MFA 대기 토큰, 소셜 가입 토큰 등은 액세스 토큰으로 쓸 수 없다.
+	if claims.TokenUse != "access" || claims.ID == "" || claims.IssuedAt == nil {
+		return nil, fmt.Errorf("%w: not an access token", ErrInvalidToken)
+	}
+	if claims.ClientID != "" && claims.IsUser() && !v.clientIDs[claims.ClientID] {
+		return nil, fmt.Errorf("%w: token was issued to client %q", ErrInvalidToken, claims.ClientID)
+	}
+
+	return claims, nil
+}
+
+func (v *Verifier) key(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	if v.secret != nil {
+		return v.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, err := v.jwks.key(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != key.algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.publicKey, nil
+}
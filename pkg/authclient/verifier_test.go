@@ -0,0 +1,259 @@
+package authclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIssuer는 /.well-known/jwks.json을 제공하고 토큰을 서명하는 가짜 인증 서비스다.
+type fakeIssuer struct {
+	mu       sync.Mutex
+	kid      string
+	key      *ecdsa.PrivateKey
+	retired  map[string]*ecdsa.PrivateKey
+	requests int
+	server   *httptest.Server
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+
+	issuer := &fakeIssuer{retired: map[string]*ecdsa.PrivateKey{}}
+	issuer.rotate(t, "2026-01")
+	issuer.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+		issuer.requests++
+
+		keys := []map[string]string{ecJWK(issuer.kid, &issuer.key.PublicKey)}
+		for kid, key := range issuer.retired {
+			keys = append(keys, ecJWK(kid, &key.PublicKey))
+		}
+		json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+	}))
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"use": "sig",
+		"alg": "ES256",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+// rotate는 새 키로 서명을 시작하고 이전 키는 검증용으로 남긴다.
+func (f *fakeIssuer) rotate(t *testing.T, kid string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.key != nil {
+		f.retired[f.kid] = f.key
+	}
+	f.kid, f.key = kid, key
+}
+
+func (f *fakeIssuer) sign(t *testing.T, claims *Claims) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = f.kid
+	signed, err := token.SignedString(f.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func (f *fakeIssuer) jwksRequests() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests
+}
+
+func userClaims() *Claims {
+	now := time.Now()
+	return &Claims{
+		UserID:      42,
+		Email:       "user@example.com",
+		Name:        "홍길동",
+		TokenUse:    "access",
+		SubjectType: SubjectTypeUser,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-1",
+			Subject:   "42",
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(15 * time.Minute)),
+		},
+	}
+}
+
+func TestVerifierUsesCachedJWKSAndRefetchesOnRotation(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	verifier, err := NewVerifier(Config{JWKSURL: issuer.server.URL})
+	require.NoError(t, err)
+
+	claims, err := verifier.Verify(context.Background(), issuer.sign(t, userClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, uint(42), claims.UserID)
+	assert.True(t, claims.IsUser())
+	_, err = verifier.Verify(context.Background(), issuer.sign(t, userClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, 1, issuer.jwksRequests())
+
+	// 모르는 kid는 키 교체로 보고 다시 받는다. 너무 자주 받지는 않는다.
+	issuer.rotate(t, "2026-10")
+	now := time.Now().Add(minRefreshInterval)
+	verifier.jwks.now = func() time.Time { return now }
+	_, err = verifier.Verify(context.Background(), issuer.sign(t, userClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, 2, issuer.jwksRequests())
+
+	issuer.rotate(t, "2027-01")
+	_, err = verifier.Verify(context.Background(), issuer.sign(t, userClaims()))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.Equal(t, 2, issuer.jwksRequests())
+}
+
+func TestVerifierKeepsVerifyingCachedKeysWhileRefetching(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	verifier, err := NewVerifier(Config{JWKSURL: issuer.server.URL})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	cached := issuer.sign(t, userClaims())
+	_, err = verifier.Verify(ctx, cached)
+	require.NoError(t, err)
+	issuer.rotate(t, "2026-10")
+	rotated := issuer.sign(t, userClaims())
+	now := time.Now().Add(minRefreshInterval)
+	verifier.jwks.now = func() time.Time { return now }
+
+	// 인증 서비스가 JWKS 응답을 늦추는 동안에도 캐시된 키의 토큰은 기다리지 않고 검증한다.
+	issuer.mu.Lock()
+	results := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := verifier.Verify(ctx, rotated)
+			results <- err
+		}()
+	}
+	require.Eventually(t, func() bool {
+		verifier.jwks.mu.RLock()
+		defer verifier.jwks.mu.RUnlock()
+		return verifier.jwks.refreshing != nil
+	}, time.Second, time.Millisecond)
+	_, err = verifier.Verify(ctx, cached)
+	assert.NoError(t, err)
+	issuer.mu.Unlock()
+
+	// 동시에 모르는 kid를 받은 요청은 한 번의 JWKS 요청 결과를 함께 사용한다.
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, <-results)
+	}
+	assert.Equal(t, 2, issuer.jwksRequests())
+}
+
+func TestVerifierRejectsTokensNotMeantForAPIs(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	verifier, err := NewVerifier(Config{JWKSURL: issuer.server.URL, ClientIDs: []string{"admin"}})
+	require.NoError(t, err)
+	verify := func(claims *Claims) error {
+		_, err := verifier.Verify(context.Background(), issuer.sign(t, claims))
+		return err
+	}
+
+	expired := userClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	assert.ErrorIs(t, verify(expired), ErrInvalidToken)
+
+	mfaPending := userClaims()
+	mfaPending.TokenUse = "mfa_pending"
+	assert.ErrorIs(t, verify(mfaPending), ErrInvalidToken)
+
+	otherClient := userClaims()
+	otherClient.ClientID = "partner"
+	assert.ErrorIs(t, verify(otherClient), ErrInvalidToken)
+
+	delegated := userClaims()
+	delegated.ClientID, delegated.Scope = "admin", "openid email"
+	assert.NoError(t, verify(delegated))
+
+	service := &Claims{
+		TokenUse:    "access",
+		SubjectType: SubjectTypeService,
+		ClientID:    "billing",
+		Scope:       "users:read",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-2",
+			Subject:   "billing",
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+	claims, err := verifier.Verify(context.Background(), issuer.sign(t, service))
+	require.NoError(t, err)
+	assert.True(t, claims.IsService())
+	assert.True(t, claims.HasScope("users:read"))
+	assert.False(t, claims.HasScope("users:write"))
+
+	// JWKS 검증기는 공유 비밀키(HS256) 서명을 받지 않는다.
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, userClaims()).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = verifier.Verify(context.Background(), hmacToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestSecretVerifier(t *testing.T) {
+	verifier, err := NewVerifier(Config{Secret: "test-secret"})
+	require.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, userClaims()).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+	claims, err := verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", claims.Email)
+
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, userClaims()).SignedString([]byte("other-secret"))
+	require.NoError(t, err)
+	_, err = verifier.Verify(context.Background(), forged)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = NewVerifier(Config{Secret: "test-secret", JWKSURL: "https://auth.example.com/.well-known/jwks.json"})
+	assert.Error(t, err)
+}
+
+func TestParseJSONWebKeyRSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	parsed, err := parseJSONWebKey(jsonWebKey{
+		KeyType: "RSA",
+		N:       base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", parsed.algorithm)
+	assert.True(t, key.PublicKey.Equal(parsed.publicKey))
+}